@@ -0,0 +1,97 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"github.com/sylabs/wlm-operator/pkg/workload/inventory"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// watchCapacity periodically fetches partition nodes inventory and publishes partition-wide
+// capacity, allocatable resources and node counts per state in virtual nodes annotations.
+func watchCapacity(ctx context.Context, wg *sync.WaitGroup, c *cluster) {
+	defer wg.Done()
+
+	t := time.NewTicker(*updateInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.updateCapacity(ctx); err != nil {
+				log.Printf("Can't update %s cluster virtual nodes capacity %s", c.name, err)
+			}
+		}
+	}
+}
+
+// updateCapacity updates capacity of all virtual nodes managed for the cluster. A failure
+// of a single node doesn't prevent others from being updated, errors are reported together.
+func (c *cluster) updateCapacity(ctx context.Context) error {
+	nodes, err := c.k8s.Nodes().List(metav1.ListOptions{
+		LabelSelector: "type=virtual-kubelet",
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not get virtual nodes")
+	}
+
+	var errs []error
+	for i := range nodes.Items {
+		n := &nodes.Items[i]
+		partition, ok := c.nodePartition(n)
+		if !ok {
+			continue
+		}
+		if err := c.updateNodeCapacity(ctx, n.Name, partition); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateNodeCapacity publishes partition inventory on the virtual node. Node status,
+// including capacity and allocatable resources, is owned by virtual kubelet, which
+// updates the same node concurrently, so inventory is published in annotations only.
+func (c *cluster) updateNodeCapacity(ctx context.Context, node, partition string) error {
+	resp, err := c.slurm.Nodes(ctx, &api.NodesRequest{Partition: partition})
+	if err != nil {
+		return errors.Wrapf(err, "could not get %s partition nodes", partition)
+	}
+	s := inventory.Summarize(resp.Nodes)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": s.Annotations(),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not encode node patch")
+	}
+	if _, err := c.k8s.Nodes().Patch(node, types.MergePatchType, patch); err != nil {
+		return errors.Wrapf(err, "could not update node %s", node)
+	}
+	return nil
+}
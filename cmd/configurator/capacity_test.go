@@ -0,0 +1,107 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// fakeNodes lists the given nodes, records merge patches by node name and subresource
+// and keeps the last updated node.
+type fakeNodes struct {
+	corev1.NodeInterface
+	items   []v1.Node
+	patched map[string]v1.Node
+	updated *v1.Node
+}
+
+func (n *fakeNodes) Update(node *v1.Node) (*v1.Node, error) {
+	n.updated = node
+	return node, nil
+}
+
+func (n *fakeNodes) List(metav1.ListOptions) (*v1.NodeList, error) {
+	return &v1.NodeList{Items: n.items}, nil
+}
+
+func (n *fakeNodes) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.Node, error) {
+	if pt != types.MergePatchType {
+		return nil, errors.Errorf("unexpected patch type %s", pt)
+	}
+	var patch v1.Node
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	key := name
+	for _, s := range subresources {
+		key += "/" + s
+	}
+	n.patched[key] = patch
+	return &patch, nil
+}
+
+func Test_updateCapacity(t *testing.T) {
+	virtualNode := func(partition string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   partitionNodeName(partition, "cluster"),
+				Labels: map[string]string{partitionLabel: partition},
+			},
+		}
+	}
+	nodes := &fakeNodes{
+		items: []v1.Node{
+			virtualNode("debug"),
+			virtualNode("broken"),
+			virtualNode("gpu"),
+			{ObjectMeta: metav1.ObjectMeta{Name: "worker"}},
+		},
+		patched: make(map[string]v1.Node),
+	}
+	c := &cluster{
+		name: "cluster",
+		slurm: &fakeSlurm{nodes: map[string][]*api.NodeInfo{
+			"debug": {{Name: "node-1", State: api.NodeState_IDLE, Cpus: 2, RealMemory: 1024}},
+			"gpu":   {{Name: "node-2", State: api.NodeState_DOWN, Cpus: 4, RealMemory: 2048}},
+		}},
+		k8s: &fakeCore{nodes: nodes},
+	}
+
+	err := c.updateCapacity(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "broken")
+
+	// nodes after the failed one are still updated
+	// node status is owned by virtual kubelet and is not patched
+	require.Len(t, nodes.patched, 2)
+	debug := nodes.patched[partitionNodeName("debug", "cluster")].Annotations
+	require.Equal(t, "1", debug["wlm.sylabs.io/nodes-idle"])
+	require.Equal(t, "2", debug["wlm.sylabs.io/capacity-cpu"])
+	require.Equal(t, "1Gi", debug["wlm.sylabs.io/allocatable-memory"])
+
+	gpu := nodes.patched[partitionNodeName("gpu", "cluster")].Annotations
+	require.Equal(t, "1", gpu["wlm.sylabs.io/nodes-down"])
+	require.Equal(t, "0", gpu["wlm.sylabs.io/allocatable-cpu"])
+}
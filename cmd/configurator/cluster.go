@@ -0,0 +1,142 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	unixPrefix = "unix://"
+
+	// redBoxTLSDir is a path red-box TLS secret is mounted at in virtual kubelet pods.
+	redBoxTLSDir = "/red-box-tls"
+)
+
+// cluster is a single Slurm cluster whose partitions are represented by virtual nodes.
+type cluster struct {
+	// name is used in virtual kubelet pods and nodes names. When configurator
+	// manages a single cluster it is a name of the host configurator runs on.
+	name      string
+	namespace string
+	spec      v1alpha1.SlurmClusterSpec
+	// owner is set on virtual kubelet pods when cluster is described by SlurmCluster.
+	owner *metav1.OwnerReference
+	// socketHostDir is a host directory with red-box socket. It is
+	// mounted into virtual kubelet pods when red-box is served on unix socket.
+	socketHostDir string
+
+	slurm  api.WorkloadManagerClient
+	health healthpb.HealthClient
+	k8s    coreGetter
+	wlm    wlmv1alpha1.WlmPartitionsGetter
+
+	mu     sync.Mutex
+	status clusterStatus
+
+	// usageFailures counts failed usage requests of finished jobs by job pod,
+	// it's accessed by watchJobs only.
+	usageFailures map[types.UID]int
+}
+
+// clusterStatus is the last observed cluster state.
+type clusterStatus struct {
+	healthy      bool
+	probed       time.Time
+	partitions   []string
+	licenses     []v1alpha1.ClusterLicense
+	reconcileErr error
+}
+
+// run manages cluster virtual nodes until ctx is canceled.
+func (c *cluster) run(ctx context.Context) {
+	wg := &sync.WaitGroup{}
+	wg.Add(4)
+	go watchPartitions(ctx, wg, c)
+	go watchHealth(ctx, wg, c)
+	go watchCapacity(ctx, wg, c)
+	go watchJobs(ctx, wg, c)
+	wg.Wait()
+}
+
+// socketPath returns red-box socket path if cluster endpoint is a unix socket.
+func (c *cluster) socketPath() (string, bool) {
+	if !strings.HasPrefix(c.spec.Endpoint, unixPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(c.spec.Endpoint, unixPrefix), true
+}
+
+func (c *cluster) setHealth(healthy bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.healthy = healthy
+	c.status.probed = time.Now()
+}
+
+func (c *cluster) setPartitions(partitions []string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if partitions != nil {
+		c.status.partitions = partitions
+	}
+	c.status.reconcileErr = err
+}
+
+// lastStatus returns a copy of the last observed cluster state.
+func (c *cluster) lastStatus() clusterStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.status
+	s.partitions = append([]string(nil), c.status.partitions...)
+	s.licenses = append([]v1alpha1.ClusterLicense(nil), c.status.licenses...)
+	return s
+}
+
+// selectPartitions returns partitions matching the selector preserving their order.
+func selectPartitions(sel v1alpha1.PartitionSelector, partitions []string) []string {
+	selected := make([]string, 0, len(partitions))
+	for _, p := range partitions {
+		if len(sel.Include) != 0 && !matchAny(sel.Include, p) {
+			continue
+		}
+		if matchAny(sel.Exclude, p) {
+			continue
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+// matchAny checks if name matches any of the shell patterns. Malformed patterns match nothing.
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
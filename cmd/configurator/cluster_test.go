@@ -0,0 +1,66 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func Test_selectPartitions(t *testing.T) {
+	partitions := []string{"debug", "batch", "gpu-a100", "gpu-v100"}
+
+	tt := []struct {
+		name     string
+		sel      v1alpha1.PartitionSelector
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{"debug", "batch", "gpu-a100", "gpu-v100"},
+		},
+		{
+			name:     "include",
+			sel:      v1alpha1.PartitionSelector{Include: []string{"batch", "gpu-*"}},
+			expected: []string{"batch", "gpu-a100", "gpu-v100"},
+		},
+		{
+			name:     "exclude",
+			sel:      v1alpha1.PartitionSelector{Exclude: []string{"debug"}},
+			expected: []string{"batch", "gpu-a100", "gpu-v100"},
+		},
+		{
+			name: "exclude takes precedence",
+			sel: v1alpha1.PartitionSelector{
+				Include: []string{"gpu-*"},
+				Exclude: []string{"*-v100"},
+			},
+			expected: []string{"gpu-a100"},
+		},
+		{
+			name:     "malformed pattern",
+			sel:      v1alpha1.PartitionSelector{Include: []string{"[gpu"}},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, selectPartitions(tc.sel, partitions))
+		})
+	}
+}
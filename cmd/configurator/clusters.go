@@ -0,0 +1,233 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"github.com/sylabs/wlm-operator/pkg/workload/redbox"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// clusterWorker manages virtual nodes of a single SlurmCluster.
+type clusterWorker struct {
+	cluster    *cluster
+	generation int64
+	conn       *grpc.ClientConn
+	err        error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// stop stops the worker and waits until it is finished.
+func (w *clusterWorker) stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	_ = w.conn.Close()
+}
+
+// watchClusters periodically syncs SlurmCluster resources: starts managing
+// virtual nodes of new clusters, restarts workers of changed ones, cleans up
+// virtual nodes of deleted clusters and reports clusters health in status.
+func watchClusters(ctx context.Context, wg *sync.WaitGroup,
+	wlmClient wlmv1alpha1.WlmV1alpha1Interface, k8sClient *corev1.CoreV1Client) {
+
+	defer wg.Done()
+
+	workers := make(map[string]*clusterWorker)
+	defer func() {
+		for _, w := range workers {
+			w.stop()
+		}
+	}()
+
+	t := time.NewTicker(*updateInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := syncClusters(ctx, workers, wlmClient, k8sClient); err != nil {
+				log.Printf("Can't sync slurm clusters %s", err)
+			}
+		}
+	}
+}
+
+// syncClusters converges cluster workers with SlurmCluster resources.
+func syncClusters(ctx context.Context, workers map[string]*clusterWorker,
+	wlmClient wlmv1alpha1.WlmV1alpha1Interface, k8sClient *corev1.CoreV1Client) error {
+
+	clusters, err := wlmClient.SlurmClusters(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "could not get slurm clusters")
+	}
+
+	seen := make(map[string]struct{}, len(clusters.Items))
+	for i := range clusters.Items {
+		sc := &clusters.Items[i]
+		seen[sc.Name] = struct{}{}
+
+		w, ok := workers[sc.Name]
+		// workers that failed to connect are restarted, e.g. TLS secret may be created later
+		if !ok || w.generation != sc.Generation || w.err != nil {
+			if ok {
+				log.Printf("Restarting %s slurm cluster worker", sc.Name)
+				w.stop()
+			}
+			w = startClusterWorker(ctx, sc, wlmClient, k8sClient)
+			workers[sc.Name] = w
+		}
+
+		status := w.clusterStatus()
+		if reflect.DeepEqual(sc.Status, status) {
+			continue
+		}
+		sc.Status = status
+		if _, err := wlmClient.SlurmClusters(namespace).UpdateStatus(sc); err != nil {
+			log.Printf("Can't update %s slurm cluster status %s", sc.Name, err)
+		}
+	}
+
+	for name, w := range workers {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		log.Printf("Slurm cluster %s is deleted, cleaning up virtual nodes", name)
+		w.stop()
+		delete(workers, name)
+		if err := w.cluster.converge(ctx, nil, nil); err != nil {
+			log.Printf("Can't clean up %s slurm cluster virtual nodes %s", name, err)
+		}
+	}
+	return nil
+}
+
+// startClusterWorker connects to the cluster red-box and starts managing its virtual nodes.
+// Connection errors are reported in the returned worker.
+func startClusterWorker(ctx context.Context, sc *v1alpha1.SlurmCluster,
+	wlmClient wlmv1alpha1.WlmPartitionsGetter, k8sClient *corev1.CoreV1Client) *clusterWorker {
+
+	c := &cluster{
+		name:      sc.Name,
+		namespace: sc.Namespace,
+		spec:      *sc.Spec.DeepCopy(),
+		owner:     metav1.NewControllerRef(sc, v1alpha1.SchemeGroupVersion.WithKind("SlurmCluster")),
+		k8s:       k8sClient,
+		wlm:       wlmClient,
+	}
+	w := &clusterWorker{
+		cluster:    c,
+		generation: sc.Generation,
+	}
+
+	conn, err := dialCluster(sc, k8sClient)
+	if err != nil {
+		log.Printf("Can't connect to %s slurm cluster %s", sc.Name, err)
+		w.err = err
+		return w
+	}
+	c.slurm = api.NewWorkloadManagerClient(conn)
+	c.health = healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.conn = conn
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		c.run(ctx)
+	}()
+	return w
+}
+
+// clusterStatus returns SlurmCluster status reflecting the last observed cluster state.
+func (w *clusterWorker) clusterStatus() v1alpha1.SlurmClusterStatus {
+	status := v1alpha1.SlurmClusterStatus{
+		ObservedGeneration: w.generation,
+	}
+	if w.err != nil {
+		status.Message = w.err.Error()
+		return status
+	}
+
+	s := w.cluster.lastStatus()
+	status.Healthy = s.healthy
+	if len(s.partitions) != 0 {
+		status.Partitions = s.partitions
+	}
+	if len(s.licenses) != 0 {
+		status.Licenses = s.licenses
+	}
+	if !s.probed.IsZero() {
+		probed := metav1.NewTime(s.probed)
+		status.LastProbeTime = &probed
+	}
+	switch {
+	case s.reconcileErr != nil:
+		status.Message = s.reconcileErr.Error()
+	case !s.healthy && !s.probed.IsZero():
+		status.Message = "red-box is unreachable or slurm is not available"
+	}
+	return status
+}
+
+// dialCluster connects to the SlurmCluster red-box endpoint. Unix socket is connected
+// in plain text, TCP endpoint requires TLS secret with red-box CA and client certificate.
+func dialCluster(sc *v1alpha1.SlurmCluster, secretsGetter corev1.SecretsGetter) (*grpc.ClientConn, error) {
+	opt := grpc.WithInsecure()
+	if !strings.HasPrefix(sc.Spec.Endpoint, unixPrefix) {
+		if sc.Spec.TLSSecret == "" {
+			return nil, errors.Errorf("TLS secret is required to connect to %s", sc.Spec.Endpoint)
+		}
+		secret, err := secretsGetter.Secrets(sc.Namespace).Get(sc.Spec.TLSSecret, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get %s secret", sc.Spec.TLSSecret)
+		}
+		tlsConfig, err := redbox.ClientTLSConfig(
+			secret.Data[redbox.CACertFile],
+			secret.Data[redbox.CertFile],
+			secret.Data[redbox.KeyFile],
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s secret", sc.Spec.TLSSecret)
+		}
+		opt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	conn, err := grpc.Dial(sc.Spec.Endpoint, opt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to %s", sc.Spec.Endpoint)
+	}
+	return conn, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/internal/red-box/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// unreachableTaintKey is a taint that is set on virtual nodes while WLM is unreachable.
+const unreachableTaintKey = "wlm.sylabs.io/unreachable"
+
+// watchHealth periodically checks red-box health and taints virtual nodes
+// as unschedulable while WLM is unreachable. Taint is removed once WLM recovers.
+func watchHealth(ctx context.Context, wg *sync.WaitGroup, c *cluster) {
+	defer wg.Done()
+
+	t := time.NewTicker(*healthInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			reachable := isWLMReachable(ctx, c.health)
+			c.setHealth(reachable)
+			if err := c.updateUnreachableTaint(!reachable); err != nil {
+				log.Printf("Can't update %s cluster virtual nodes taints %s", c.name, err)
+			}
+		}
+	}
+}
+
+// isWLMReachable checks if red-box is serving, which means WLM is reachable.
+func isWLMReachable(ctx context.Context, healthClient healthpb.HealthClient) bool {
+	ctx, cancel := context.WithTimeout(ctx, *healthInterval)
+	defer cancel()
+
+	resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: health.ServiceName})
+	if err != nil {
+		log.Printf("Can't check red-box health %s", err)
+		return false
+	}
+	return resp.Status == healthpb.HealthCheckResponse_SERVING
+}
+
+// updateUnreachableTaint sets or removes unreachable taint on all
+// virtual nodes managed for the cluster.
+func (c *cluster) updateUnreachableTaint(unreachable bool) error {
+	nodes, err := c.k8s.Nodes().List(metav1.ListOptions{
+		LabelSelector: "type=virtual-kubelet",
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not get virtual nodes")
+	}
+
+	for _, n := range nodes.Items {
+		if _, ok := c.nodePartition(&n); !ok {
+			continue
+		}
+
+		taints, changed := withUnreachableTaint(n.Spec.Taints, unreachable)
+		if !changed {
+			continue
+		}
+
+		if unreachable {
+			log.Printf("Tainting node %s as unreachable", n.Name)
+		} else {
+			log.Printf("Removing unreachable taint from node %s", n.Name)
+		}
+
+		n.Spec.Taints = taints
+		if _, err := c.k8s.Nodes().Update(&n); err != nil {
+			return errors.Wrapf(err, "could not update node %s", n.Name)
+		}
+	}
+	return nil
+}
+
+// withUnreachableTaint adds or removes unreachable taint from the passed taints.
+// Returns resulting taints and whether they differ from the passed ones.
+func withUnreachableTaint(taints []v1.Taint, unreachable bool) ([]v1.Taint, bool) {
+	res := make([]v1.Taint, 0, len(taints)+1)
+	found := false
+	for _, t := range taints {
+		if t.Key == unreachableTaintKey {
+			found = true
+			continue
+		}
+		res = append(res, t)
+	}
+
+	if !unreachable {
+		return res, found
+	}
+
+	now := metav1.Now()
+	res = append(res, v1.Taint{
+		Key:       unreachableTaintKey,
+		Effect:    v1.TaintEffectNoSchedule,
+		TimeAdded: &now,
+	})
+	return res, !found
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// watchJobs periodically looks through job pods scheduled on cluster virtual nodes,
+// stores job IDs and usage of finished jobs in the job pod annotations and cancels
+// jobs on request.
+func watchJobs(ctx context.Context, wg *sync.WaitGroup, c *cluster) {
+	defer wg.Done()
+
+	t := time.NewTicker(*updateInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.updateJobs(ctx); err != nil {
+				log.Printf("Can't update %s cluster job pods %s", c.name, err)
+			}
+		}
+	}
+}
+
+// maxUsageFailures is how many times in a row usage of a finished job may fail
+// to be fetched before its pod is annotated with empty usage, e.g. when job
+// accounting is disabled or job record is purged.
+const maxUsageFailures = 10
+
+// updateJobs updates job pods of all partitions managed for the cluster. Jobs of pods
+// that are not annotated with job ID yet are listed once for all pods. A failure
+// of a single pod doesn't prevent others from being updated, errors are reported
+// together.
+func (c *cluster) updateJobs(ctx context.Context) error {
+	var errs []error
+	var pods []*v1.Pod
+	for _, p := range c.lastStatus().partitions {
+		list, err := c.k8s.Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", partitionNodeName(p, c.name)).String(),
+		})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not get %s partition pods", p))
+			continue
+		}
+		for i := range list.Items {
+			pods = append(pods, &list.Items[i])
+		}
+	}
+
+	jobIDs, err := c.findJobIDs(ctx, pods)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	seen := make(map[types.UID]bool, len(pods))
+	for _, pod := range pods {
+		seen[pod.UID] = true
+		if err := c.updateJobPod(ctx, pod, jobIDs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for uid := range c.usageFailures {
+		if !seen[uid] {
+			delete(c.usageFailures, uid)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateJobPod sets job ID annotation on the job pod once its job is submitted, cancels job
+// on request and sets usage annotation on the job pod once its job is finished. Job IDs
+// of submitted jobs are passed by pod client ID.
+func (c *cluster) updateJobPod(ctx context.Context, pod *v1.Pod, jobIDs map[string]int64) error {
+	id, err := controller.JobIDFromPod(pod)
+	if err != nil {
+		return errors.Wrapf(err, "pod %s/%s", pod.Namespace, pod.Name)
+	}
+	if id == 0 {
+		if isPodFinished(pod) {
+			return nil
+		}
+		if id = jobIDs[controller.ClientID(pod)]; id == 0 {
+			return nil
+		}
+		err := c.annotatePod(pod, map[string]string{controller.JobIDAnnotation: strconv.FormatInt(id, 10)})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !isPodFinished(pod) {
+		if cancel := pod.Annotations[controller.CancelAnnotation]; cancel == "" || cancel == controller.CancelDone {
+			return nil
+		}
+		if _, err := c.slurm.CancelJob(ctx, &api.CancelJobRequest{JobId: id}); err != nil {
+			return errors.Wrapf(err, "could not cancel job %d", id)
+		}
+		return c.annotatePod(pod, map[string]string{controller.CancelAnnotation: controller.CancelDone})
+	}
+
+	if _, ok := pod.Annotations[controller.UsageAnnotation]; ok {
+		return nil
+	}
+	usage, err := c.jobUsage(ctx, id)
+	if err != nil {
+		if c.usageFailures == nil {
+			c.usageFailures = make(map[types.UID]int)
+		}
+		c.usageFailures[pod.UID]++
+		if c.usageFailures[pod.UID] < maxUsageFailures {
+			return err
+		}
+		log.Printf("Giving up on job %d usage after %d attempts: %s", id, maxUsageFailures, err)
+		usage = &v1alpha1.JobUsage{}
+	}
+	delete(c.usageFailures, pod.UID)
+
+	raw, err := json.Marshal(usage)
+	if err != nil {
+		return errors.Wrapf(err, "could not encode job %d usage", id)
+	}
+	return c.annotatePod(pod, map[string]string{controller.UsageAnnotation: string(raw)})
+}
+
+// jobUsage returns resources consumed by a finished job.
+func (c *cluster) jobUsage(ctx context.Context, id int64) (*v1alpha1.JobUsage, error) {
+	resp, err := c.slurm.JobUsage(ctx, &api.JobUsageRequest{JobId: id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get job %d usage", id)
+	}
+	usage, err := controller.UsageFromResponse(resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid job %d usage", id)
+	}
+	return usage, nil
+}
+
+// findJobIDs returns IDs of jobs submitted for pods that are running and not annotated
+// with job ID yet by pod client ID. Jobs are listed once, since the oldest of such pods
+// is created. Pods which jobs are not submitted yet are missing in the result.
+func (c *cluster) findJobIDs(ctx context.Context, pods []*v1.Pod) (map[string]int64, error) {
+	clients := make(map[string]bool)
+	var oldest time.Time
+	for _, pod := range pods {
+		if id, err := controller.JobIDFromPod(pod); err != nil || id != 0 || isPodFinished(pod) {
+			continue
+		}
+		clients[controller.ClientID(pod)] = true
+		if created := pod.CreationTimestamp.Time; oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+	}
+	if len(clients) == 0 {
+		return nil, nil
+	}
+
+	since, err := ptypes.TimestampProto(oldest)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pod creation time")
+	}
+	req := &api.ListJobsRequest{Filter: &api.JobFilter{SubmittedSince: since}}
+	ids := make(map[string]int64)
+	for {
+		resp, err := c.slurm.ListJobs(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list jobs")
+		}
+
+		// the first submitted job is the job of the pod, array
+		// jobs and job steps have ids that are not numbers
+		for _, j := range resp.Jobs {
+			if !clients[j.ClientId] {
+				continue
+			}
+			jobID, err := strconv.ParseInt(j.Id, 10, 64)
+			if err != nil {
+				continue
+			}
+			if id := ids[j.ClientId]; id == 0 || jobID < id {
+				ids[j.ClientId] = jobID
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// annotatePod sets pod annotations with a merge patch.
+func (c *cluster) annotatePod(pod *v1.Pod, annotations map[string]string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.k8s.Pods(pod.Namespace).Patch(pod.Name, types.MergePatchType, patch); err != nil {
+		return errors.Wrapf(err, "could not annotate pod %s/%s", pod.Namespace, pod.Name)
+	}
+	return nil
+}
+
+// isPodFinished checks if job of the pod is in a terminal state.
+func isPodFinished(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type fakeSlurm struct {
+	api.WorkloadManagerClient
+	usage    map[int64]*api.JobUsageResponse
+	nodes    map[string][]*api.NodeInfo
+	statuses map[string]*api.PartitionStatusResponse
+	// jobs are listed in pages of listPageSize jobs, all at once when it's 0.
+	jobs         []*api.JobInfo
+	listPageSize int
+	// listTokens records page tokens of ListJobs calls.
+	listTokens []string
+	listFilter *api.JobFilter
+	// statusCalls counts PartitionStatus calls.
+	statusCalls int
+	// cancelled lists IDs of cancelled jobs.
+	cancelled []int64
+	licenses  []*api.Feature
+}
+
+func (s *fakeSlurm) WorkloadInfo(context.Context, *api.WorkloadInfoRequest, ...grpc.CallOption) (*api.WorkloadInfoResponse, error) {
+	return &api.WorkloadInfoResponse{Name: "slurm", Licenses: s.licenses}, nil
+}
+
+func (s *fakeSlurm) PartitionStatus(_ context.Context, r *api.PartitionStatusRequest, _ ...grpc.CallOption) (*api.PartitionStatusResponse, error) {
+	s.statusCalls++
+	resp := &api.PartitionStatusResponse{Partitions: make(map[string]*api.PartitionStatusResponse)}
+	for _, p := range r.Partitions {
+		if status, ok := s.statuses[p]; ok {
+			resp.Partitions[p] = status
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakeSlurm) Nodes(_ context.Context, r *api.NodesRequest, _ ...grpc.CallOption) (*api.NodesResponse, error) {
+	nodes, ok := s.nodes[r.Partition]
+	if !ok {
+		return nil, errors.Errorf("partition %s not found", r.Partition)
+	}
+	return &api.NodesResponse{Nodes: nodes}, nil
+}
+
+func (s *fakeSlurm) JobUsage(_ context.Context, r *api.JobUsageRequest, _ ...grpc.CallOption) (*api.JobUsageResponse, error) {
+	usage, ok := s.usage[r.JobId]
+	if !ok {
+		return nil, errors.Errorf("job %d not found", r.JobId)
+	}
+	return usage, nil
+}
+
+func (s *fakeSlurm) ListJobs(_ context.Context, r *api.ListJobsRequest, _ ...grpc.CallOption) (*api.ListJobsResponse, error) {
+	s.listTokens = append(s.listTokens, r.PageToken)
+	s.listFilter = r.Filter
+
+	var start int
+	if r.PageToken != "" {
+		var err error
+		if start, err = strconv.Atoi(r.PageToken); err != nil {
+			return nil, err
+		}
+	}
+	resp := &api.ListJobsResponse{}
+	end := len(s.jobs)
+	if s.listPageSize > 0 && start+s.listPageSize < end {
+		end = start + s.listPageSize
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	resp.Jobs = s.jobs[start:end]
+	return resp, nil
+}
+
+func (s *fakeSlurm) CancelJob(_ context.Context, r *api.CancelJobRequest, _ ...grpc.CallOption) (*api.CancelJobResponse, error) {
+	s.cancelled = append(s.cancelled, r.JobId)
+	return &api.CancelJobResponse{}, nil
+}
+
+// fakePods records annotations set with merge patches by pod name.
+type fakePods struct {
+	corev1.PodInterface
+	annotations map[string]map[string]string
+}
+
+func (p *fakePods) Patch(name string, pt types.PatchType, data []byte, _ ...string) (*v1.Pod, error) {
+	if pt != types.MergePatchType {
+		return nil, errors.Errorf("unexpected patch type %s", pt)
+	}
+	var patch v1.Pod
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	if p.annotations[name] == nil {
+		p.annotations[name] = make(map[string]string)
+	}
+	for k, v := range patch.Annotations {
+		p.annotations[name][k] = v
+	}
+	return &patch, nil
+}
+
+type fakeCore struct {
+	coreGetter
+	pods  *fakePods
+	nodes *fakeNodes
+}
+
+func (c *fakeCore) Pods(string) corev1.PodInterface {
+	return c.pods
+}
+
+func (c *fakeCore) Nodes() corev1.NodeInterface {
+	return c.nodes
+}
+
+func Test_updateJobPod(t *testing.T) {
+	usage := &api.JobUsageResponse{
+		Steps: []*api.JobStepUsage{
+			{
+				Id:      "35",
+				Elapsed: ptypes.DurationProto(2 * time.Minute),
+				CpuTime: ptypes.DurationProto(time.Minute),
+				MaxRss:  1024,
+			},
+		},
+		CpuEfficiency: 50,
+	}
+
+	usageJSON, err := json.Marshal(&wlmv1alpha1.JobUsage{
+		CPUTime:       60,
+		Elapsed:       120,
+		MaxRSS:        1024,
+		CPUEfficiency: 50,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		uid             types.UID
+		phase           v1.PodPhase
+		annotations     map[string]string
+		expectPatched   map[string]string
+		expectCancelled []int64
+	}{
+		{
+			name:        "running job",
+			phase:       v1.PodRunning,
+			annotations: map[string]string{controller.JobIDAnnotation: "35"},
+		},
+		{
+			name:  "not submitted job",
+			phase: v1.PodPending,
+		},
+		{
+			name:          "submitted job",
+			uid:           "submitted",
+			phase:         v1.PodRunning,
+			annotations:   map[string]string{"team": "hpc"},
+			expectPatched: map[string]string{controller.JobIDAnnotation: "36"},
+		},
+		{
+			name:  "finished job without id",
+			uid:   "submitted",
+			phase: v1.PodFailed,
+		},
+		{
+			name:            "cancel requested",
+			phase:           v1.PodRunning,
+			annotations:     map[string]string{controller.JobIDAnnotation: "35", controller.CancelAnnotation: "true"},
+			expectPatched:   map[string]string{controller.CancelAnnotation: controller.CancelDone},
+			expectCancelled: []int64{35},
+		},
+		{
+			name:        "already cancelled",
+			phase:       v1.PodRunning,
+			annotations: map[string]string{controller.JobIDAnnotation: "35", controller.CancelAnnotation: controller.CancelDone},
+		},
+		{
+			name:  "already annotated",
+			phase: v1.PodSucceeded,
+			annotations: map[string]string{
+				controller.JobIDAnnotation: "35",
+				controller.UsageAnnotation: "{}",
+			},
+		},
+		{
+			name:          "finished job",
+			phase:         v1.PodSucceeded,
+			annotations:   map[string]string{controller.JobIDAnnotation: "35"},
+			expectPatched: map[string]string{controller.UsageAnnotation: string(usageJSON)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := &fakePods{annotations: make(map[string]map[string]string)}
+			slurm := &fakeSlurm{
+				usage: map[int64]*api.JobUsageResponse{35: usage},
+			}
+			c := &cluster{slurm: slurm, k8s: &fakeCore{pods: pods}}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cow-wlm-job",
+					Namespace:   "default",
+					UID:         tt.uid,
+					Annotations: tt.annotations,
+				},
+				Status: v1.PodStatus{Phase: tt.phase},
+			}
+
+			jobIDs := map[string]int64{"submitted": 36}
+			require.NoError(t, c.updateJobPod(context.Background(), pod, jobIDs))
+			require.Equal(t, tt.expectCancelled, slurm.cancelled)
+			if tt.expectPatched == nil {
+				require.Empty(t, pods.annotations)
+				return
+			}
+			require.Equal(t, tt.expectPatched, pods.annotations[pod.Name])
+		})
+	}
+}
+
+func Test_updateJobPod_usageFailures(t *testing.T) {
+	pods := &fakePods{annotations: make(map[string]map[string]string)}
+	c := &cluster{slurm: &fakeSlurm{}, k8s: &fakeCore{pods: pods}}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cow-wlm-job",
+			UID:         "purged",
+			Annotations: map[string]string{controller.JobIDAnnotation: "35"},
+		},
+		Status: v1.PodStatus{Phase: v1.PodSucceeded},
+	}
+
+	for i := 1; i < maxUsageFailures; i++ {
+		require.EqualError(t, c.updateJobPod(context.Background(), pod, nil), "could not get job 35 usage: job 35 not found")
+		require.Empty(t, pods.annotations)
+	}
+	require.NoError(t, c.updateJobPod(context.Background(), pod, nil))
+	require.Equal(t, map[string]string{controller.UsageAnnotation: "{}"}, pods.annotations[pod.Name])
+	require.Empty(t, c.usageFailures)
+}
+
+func Test_findJobIDs(t *testing.T) {
+	created := time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC)
+	newPod := func(uid string, created time.Time, phase v1.PodPhase, annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              uid,
+				UID:               types.UID(uid),
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       annotations,
+			},
+			Status: v1.PodStatus{Phase: phase},
+		}
+	}
+
+	slurm := &fakeSlurm{
+		jobs: []*api.JobInfo{
+			{Id: "37", ClientId: "cow"},
+			{Id: "36", ClientId: "cow"},
+			{Id: "36.batch", ClientId: "cow"},
+			{Id: "38", ClientId: "other"},
+			{Id: "39", ClientId: "finished"},
+			{Id: "40", ClientId: "annotated"},
+			{Id: "41", ClientId: "sheep"},
+		},
+		listPageSize: 3,
+	}
+	c := &cluster{slurm: slurm}
+	pods := []*v1.Pod{
+		newPod("cow", created.Add(time.Hour), v1.PodRunning, nil),
+		newPod("sheep", created, v1.PodPending, nil),
+		newPod("pending", created.Add(time.Minute), v1.PodPending, nil),
+		newPod("finished", created.Add(-time.Hour), v1.PodSucceeded, nil),
+		newPod("annotated", created.Add(-time.Hour), v1.PodRunning, map[string]string{controller.JobIDAnnotation: "40"}),
+	}
+
+	ids, err := c.findJobIDs(context.Background(), pods)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"cow": 36, "sheep": 41}, ids)
+	require.Equal(t, []string{"", "3", "6"}, slurm.listTokens)
+	since, err := ptypes.Timestamp(slurm.listFilter.SubmittedSince)
+	require.NoError(t, err)
+	require.Equal(t, created, since)
+
+	// jobs are not listed when there are no pods to look up
+	slurm.listTokens = nil
+	ids, err = c.findJobIDs(context.Background(), pods[3:])
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	require.Empty(t, slurm.listTokens)
+}
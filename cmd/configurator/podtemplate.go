@@ -0,0 +1,307 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/workload/redbox"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// templateHashAnnotation holds hash of the template virtual kubelet pod is created from.
+	templateHashAnnotation = "wlm.sylabs.io/pod-template-hash"
+
+	// templatePatchKey is a config map key that holds virtual kubelet pod patch.
+	templatePatchKey = "patch.yaml"
+)
+
+// kubeletImage returns virtual kubelet image used for the cluster.
+func (c *cluster) kubeletImage() string {
+	if c.spec.PodTemplate.Image != "" {
+		return c.spec.PodTemplate.Image
+	}
+	return kubeletImage
+}
+
+// virtualKubeletPodTemplate returns filled pod model ready to be created in k8s.
+// Kubelet pod will create virtual node that will be responsible for handling Slurm jobs.
+func virtualKubeletPodTemplate(c *cluster, partitionName string) *v1.Pod {
+	tmpl := c.spec.PodTemplate
+	pullPolicy := tmpl.ImagePullPolicy
+	if pullPolicy == "" {
+		pullPolicy = v1.PullAlways
+	}
+	sa := tmpl.ServiceAccountName
+	if sa == "" {
+		sa = serviceAccount
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: partitionNodeName(partitionName, c.name),
+		},
+		Spec: v1.PodSpec{
+			ServiceAccountName: sa,
+			NodeSelector:       tmpl.NodeSelector,
+			Tolerations:        tmpl.Tolerations,
+			Containers: []v1.Container{
+				{
+					Name:            "vk",
+					Image:           c.kubeletImage(),
+					ImagePullPolicy: pullPolicy,
+					Resources:       tmpl.Resources,
+					Args: []string{
+						"--nodename",
+						partitionNodeName(partitionName, c.name),
+						"--provider",
+						"wlm",
+						"--startup-timeout",
+						"10s",
+					},
+					Ports: []v1.ContainerPort{
+						{
+							Name:          "metrics",
+							ContainerPort: 10255,
+						},
+					},
+					ReadinessProbe: &v1.Probe{
+						Handler: v1.Handler{
+							HTTPGet: &v1.HTTPGetAction{
+								Path: "/stats/summary",
+								Port: intstr.IntOrString{
+									Type:   intstr.String,
+									StrVal: "metrics",
+								},
+							},
+						},
+					},
+					Env: []v1.EnvVar{
+						{
+							Name: "VK_HOST_NAME",
+							ValueFrom: &v1.EnvVarSource{
+								FieldRef: &v1.ObjectFieldSelector{
+									FieldPath: "spec.nodeName",
+								},
+							},
+						},
+						{
+							Name: "VK_POD_NAME",
+							ValueFrom: &v1.EnvVarSource{
+								FieldRef: &v1.ObjectFieldSelector{
+									FieldPath: "metadata.name",
+								},
+							},
+						},
+						{
+							Name: "VKUBELET_POD_IP",
+							ValueFrom: &v1.EnvVarSource{
+								FieldRef: &v1.ObjectFieldSelector{
+									FieldPath: "status.podIP",
+								},
+							},
+						},
+						{
+							Name:  "PARTITION",
+							Value: partitionName,
+						},
+						{
+							Name:  "APISERVER_CERT_LOCATION",
+							Value: "/kubelet.crt",
+						},
+						{
+							Name:  "APISERVER_KEY_LOCATION",
+							Value: "/kubelet.key",
+						},
+						{
+							Name:  "RESULTS_IMAGE",
+							Value: resultsImage,
+						},
+					},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      "kubelet-crt",
+							MountPath: "/kubelet.crt",
+						},
+						{
+							Name:      "kubelet-key",
+							MountPath: "/kubelet.key",
+						},
+					},
+				},
+			},
+			Volumes: []v1.Volume{
+				{
+					Name: "kubelet-crt", // we need certificates for pod rest api, k8s gets pods logs via rest api
+					VolumeSource: v1.VolumeSource{
+						HostPath: &v1.HostPathVolumeSource{
+							Path: "/var/lib/kubelet/pki/kubelet.crt",
+							Type: &[]v1.HostPathType{v1.HostPathFile}[0],
+						},
+					},
+				},
+				{
+					Name: "kubelet-key",
+					VolumeSource: v1.VolumeSource{
+						HostPath: &v1.HostPathVolumeSource{
+							Path: "/var/lib/kubelet/pki/kubelet.key",
+							Type: &[]v1.HostPathType{v1.HostPathFile}[0],
+						},
+					},
+				},
+			},
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsUser:  &uid,
+				RunAsGroup: &gid,
+			},
+		},
+	}
+	if c.owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*c.owner}
+	}
+
+	vk := &pod.Spec.Containers[0]
+	if sock, ok := c.socketPath(); ok {
+		hostDir := c.socketHostDir
+		if hostDir == "" {
+			hostDir = filepath.Dir(sock)
+		}
+		vk.Env = append(vk.Env, v1.EnvVar{Name: redbox.SockEnv, Value: sock})
+		vk.VolumeMounts = append(vk.VolumeMounts, v1.VolumeMount{
+			Name:      "syslurm-mount",
+			MountPath: filepath.Dir(sock),
+		})
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "syslurm-mount", // directory with red-box socket
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: hostDir,
+					Type: &[]v1.HostPathType{v1.HostPathDirectory}[0],
+				},
+			},
+		})
+	} else {
+		vk.Env = append(vk.Env, v1.EnvVar{Name: redbox.AddrEnv, Value: c.spec.Endpoint})
+	}
+	if c.spec.TLSSecret != "" {
+		vk.Env = append(vk.Env, v1.EnvVar{Name: redbox.TLSDirEnv, Value: redBoxTLSDir})
+		vk.VolumeMounts = append(vk.VolumeMounts, v1.VolumeMount{
+			Name:      "red-box-tls",
+			MountPath: redBoxTLSDir,
+			ReadOnly:  true,
+		})
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "red-box-tls",
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: c.spec.TLSSecret,
+				},
+			},
+		})
+	}
+	vk.Env = append(vk.Env, tmpl.Env...)
+	return pod
+}
+
+// desiredPod returns virtual kubelet pod for the partition with the passed strategic merge
+// patches applied over the defaults. Pod is annotated with its template hash, so pods
+// created from an outdated template can be detected.
+func (c *cluster) desiredPod(partition string, patches []string) (*v1.Pod, error) {
+	pod := virtualKubeletPodTemplate(c, partition)
+	for _, patch := range patches {
+		var err error
+		pod, err = applyPodPatch(pod, patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// name and partition env identify pods managed by configurator, so patches may not change them
+	pod.Name = partitionNodeName(partition, c.name)
+	if p, ok := c.podPartition(pod); !ok || p != partition {
+		return nil, errors.New("pod template patch must keep vk container and its PARTITION env")
+	}
+
+	hash, err := podTemplateHash(pod)
+	if err != nil {
+		return nil, err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[templateHashAnnotation] = hash
+	return pod, nil
+}
+
+// templatePatches returns virtual kubelet pod patches of the cluster: config map
+// patch shared by all clusters, if any, followed by the cluster own patch.
+func (c *cluster) templatePatches() ([]string, error) {
+	var patches []string
+	if *podTemplateConfigMap != "" {
+		cm, err := c.k8s.ConfigMaps(namespace).Get(*podTemplateConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get %s config map", *podTemplateConfigMap)
+		}
+		if patch, ok := cm.Data[templatePatchKey]; ok {
+			patches = append(patches, patch)
+		}
+	}
+	if c.spec.PodTemplatePatch != "" {
+		patches = append(patches, c.spec.PodTemplatePatch)
+	}
+	return patches, nil
+}
+
+// applyPodPatch applies YAML or JSON strategic merge patch to the pod.
+func applyPodPatch(pod *v1.Pod, patch string) (*v1.Pod, error) {
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse pod template patch")
+	}
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal pod template")
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patchJSON, v1.Pod{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply pod template patch")
+	}
+
+	var res v1.Pod
+	if err := json.Unmarshal(patched, &res); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal patched pod template")
+	}
+	return &res, nil
+}
+
+// podTemplateHash returns a short hash of the pod.
+func podTemplateHash(pod *v1.Pod) (string, error) {
+	data, err := json.Marshal(pod)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal pod template")
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	return fmt.Sprintf("%x", h.Sum32()), nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func Test_applyPodPatch(t *testing.T) {
+	c := &cluster{
+		name: "host",
+		spec: v1alpha1.SlurmClusterSpec{Endpoint: "unix:///syslurm/red-box.sock"},
+	}
+	pod := virtualKubeletPodTemplate(c, "debug")
+
+	patched, err := applyPodPatch(pod, `
+spec:
+  tolerations:
+    - key: dedicated
+      operator: Exists
+  containers:
+    - name: vk
+      imagePullPolicy: IfNotPresent
+      resources:
+        limits:
+          memory: 128Mi
+      env:
+        - name: LOG_LEVEL
+          value: debug
+`)
+	require.NoError(t, err)
+
+	require.Equal(t, []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}, patched.Spec.Tolerations)
+	require.Len(t, patched.Spec.Containers, 1)
+	vk := patched.Spec.Containers[0]
+	require.Equal(t, v1.PullIfNotPresent, vk.ImagePullPolicy)
+	require.Equal(t, resource.MustParse("128Mi"), vk.Resources.Limits[v1.ResourceMemory])
+	require.Contains(t, vk.Env, v1.EnvVar{Name: "LOG_LEVEL", Value: "debug"})
+	require.Contains(t, vk.Env, v1.EnvVar{Name: "PARTITION", Value: "debug"})
+	require.Equal(t, pod.Spec.Volumes, patched.Spec.Volumes)
+
+	_, err = applyPodPatch(pod, "spec: [")
+	require.Error(t, err)
+}
+
+func Test_desiredPod(t *testing.T) {
+	c := &cluster{
+		name: "host",
+		spec: v1alpha1.SlurmClusterSpec{Endpoint: "unix:///syslurm/red-box.sock"},
+	}
+
+	pod, err := c.desiredPod("debug", nil)
+	require.NoError(t, err)
+	require.Equal(t, "slurm-host-debug", pod.Name)
+	hash := pod.Annotations[templateHashAnnotation]
+	require.NotEmpty(t, hash)
+
+	same, err := c.desiredPod("debug", nil)
+	require.NoError(t, err)
+	require.Equal(t, hash, same.Annotations[templateHashAnnotation])
+
+	patched, err := c.desiredPod("debug", []string{`{"metadata": {"name": "renamed"}, "spec": {"priorityClassName": "high"}}`})
+	require.NoError(t, err)
+	require.Equal(t, "slurm-host-debug", patched.Name)
+	require.Equal(t, "high", patched.Spec.PriorityClassName)
+	require.NotEqual(t, hash, patched.Annotations[templateHashAnnotation])
+
+	_, err = c.desiredPod("debug", []string{`
+spec:
+  containers:
+    - $patch: replace
+    - name: other
+`})
+	require.Error(t, err)
+}
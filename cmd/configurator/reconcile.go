@@ -0,0 +1,554 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	clusterLabel    = "wlm.sylabs.io/cluster"
+	partitionLabel  = "wlm.sylabs.io/partition"
+	nodesLabel      = "wlm.sylabs.io/nodes"
+	wallTimeLabel   = "wlm.sylabs.io/wall-time"
+	memPerNodeLabel = "wlm.sylabs.io/mem-per-node"
+	cpuPerNodeLabel = "wlm.sylabs.io/cpu-per-node"
+
+	// managedLabelsAnnotation and managedTaintsAnnotation list virtual node labels and
+	// taints set by configurator, so that they are removed once they are not desired.
+	managedLabelsAnnotation = "wlm.sylabs.io/managed-labels"
+	managedTaintsAnnotation = "wlm.sylabs.io/managed-taints"
+
+	// minBackoff and maxBackoff limit delay between failed reconciliations.
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+)
+
+// coreGetter is a subset of core client that is required to manage virtual nodes.
+type coreGetter interface {
+	corev1.PodsGetter
+	corev1.NodesGetter
+	corev1.ConfigMapsGetter
+}
+
+// watchPartitions periodically converges virtual kubelet pods and virtual nodes
+// with Slurm partitions. Failed reconciliations are retried with exponential backoff.
+func watchPartitions(ctx context.Context, wg *sync.WaitGroup, c *cluster) {
+	defer wg.Done()
+
+	var backoff time.Duration
+	t := time.NewTimer(*updateInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.reconcilePartitions(ctx); err != nil {
+				backoff = nextBackoff(backoff)
+				log.Printf("Can't reconcile %s cluster partitions %s, retrying in %s", c.name, err, backoff)
+				t.Reset(backoff)
+				continue
+			}
+			backoff = 0
+			t.Reset(*updateInterval)
+		}
+	}
+}
+
+// nextBackoff returns delay before the next retry given the previous one.
+func nextBackoff(prev time.Duration) time.Duration {
+	if prev < minBackoff {
+		return minBackoff
+	}
+	if prev >= maxBackoff/2 {
+		return maxBackoff
+	}
+	return prev * 2
+}
+
+// reconcilePartitions fetches cluster partitions and converges virtual kubelet pods,
+// virtual nodes and WlmPartitions with the selected ones. Partition resources are
+// fetched once and shared by virtual nodes and WlmPartitions. Cluster licenses
+// are fetched as well, since they are shared by all partitions.
+func (c *cluster) reconcilePartitions(ctx context.Context) error {
+	partitionsResp, err := c.slurm.Partitions(ctx, &api.PartitionsRequest{})
+	if err != nil {
+		err = errors.Wrap(err, "could not get partitions")
+		c.setPartitions(nil, err)
+		return err
+	}
+
+	partitions := selectPartitions(c.spec.Partitions, partitionsResp.Partition)
+	resources, errs := c.partitionResources(ctx, partitions)
+	errs = append(errs,
+		c.converge(ctx, partitions, resources),
+		c.updateWlmPartitions(ctx, partitions, resources),
+		c.updateLicenses(ctx),
+	)
+	err = utilerrors.NewAggregate(errs)
+	c.setPartitions(partitions, err)
+	return err
+}
+
+// updateLicenses fetches cluster licenses and keeps them in cluster status.
+func (c *cluster) updateLicenses(ctx context.Context) error {
+	info, err := c.slurm.WorkloadInfo(ctx, &api.WorkloadInfoRequest{})
+	if err != nil {
+		return errors.Wrap(err, "could not get workload info")
+	}
+
+	licenses := make([]v1alpha1.ClusterLicense, len(info.Licenses))
+	for i, l := range info.Licenses {
+		licenses[i] = v1alpha1.ClusterLicense{Name: l.Name, Total: l.Quantity}
+	}
+	c.mu.Lock()
+	c.status.licenses = licenses
+	c.mu.Unlock()
+	return nil
+}
+
+// partitionResources fetches resources of the partitions. Partitions which resources
+// could not be fetched are missing in the result, errors are returned for them.
+func (c *cluster) partitionResources(ctx context.Context, partitions []string) (map[string]*api.ResourcesResponse, []error) {
+	var errs []error
+	resources := make(map[string]*api.ResourcesResponse, len(partitions))
+	for _, p := range partitions {
+		res, err := c.slurm.Resources(ctx, &api.ResourcesRequest{Partition: p})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not get %s partition resources", p))
+			continue
+		}
+		resources[p] = res
+	}
+	return resources, errs
+}
+
+// converge compares passed partitions with virtual kubelet pods and virtual nodes
+// managed for the cluster: missing or failed pods are (re)created, node labels and taints
+// are updated when partition resources or cluster spec change and pods and nodes
+// of other partitions are deleted. Nodes of partitions with unknown resources are left
+// as they are. Errors do not stop reconciliation of other partitions, all of them are
+// returned at once.
+func (c *cluster) converge(ctx context.Context, partitions []string, resources map[string]*api.ResourcesResponse) error {
+	pods, err := c.k8s.Pods(c.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "could not get pods")
+	}
+	nodes, err := c.k8s.Nodes().List(metav1.ListOptions{
+		LabelSelector: "type=virtual-kubelet",
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not get virtual nodes")
+	}
+
+	actualPods := make(map[string]*v1.Pod)
+	for i := range pods.Items {
+		if p, ok := c.podPartition(&pods.Items[i]); ok {
+			actualPods[p] = &pods.Items[i]
+		}
+	}
+	actualNodes := make(map[string]*v1.Node)
+	for i := range nodes.Items {
+		if p, ok := c.nodePartition(&nodes.Items[i]); ok {
+			actualNodes[p] = &nodes.Items[i]
+		}
+	}
+
+	var patches []string
+	if len(partitions) != 0 {
+		patches, err = c.templatePatches()
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	var outdated []*v1.Pod
+	unavailable := 0
+	desired := make(map[string]struct{}, len(partitions))
+	for _, p := range partitions {
+		desired[p] = struct{}{}
+
+		state, err := c.reconcilePod(p, patches, actualPods[p])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		switch state {
+		case podUnavailable:
+			unavailable++
+		case podOutdated:
+			outdated = append(outdated, actualPods[p])
+		}
+
+		node, ok := actualNodes[p]
+		if !ok {
+			// virtual kubelet has not registered node yet
+			continue
+		}
+		res, ok := resources[p]
+		if !ok {
+			continue
+		}
+		if err := c.reconcileNode(node, c.nodeLabels(p, res)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// pods created from an outdated template are replaced one by one,
+	// next one is replaced only after all virtual kubelets are ready
+	if len(outdated) != 0 && unavailable == 0 {
+		sort.Slice(outdated, func(i, j int) bool { return outdated[i].Name < outdated[j].Name })
+		pod := outdated[0]
+		log.Printf("Replacing pod %s in %s namespace with updated template", pod.Name, c.namespace)
+		err := c.k8s.Pods(c.namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "could not delete pod %s", pod.Name))
+		}
+	}
+
+	// some partitions can be deleted from Slurm, so we need to delete pods
+	// and nodes which represent those deleted partitions
+	for p, pod := range actualPods {
+		if _, ok := desired[p]; ok {
+			continue
+		}
+		log.Printf("Deleting pod %s in %s namespace", pod.Name, c.namespace)
+		err := c.k8s.Pods(c.namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "could not delete pod %s", pod.Name))
+		}
+	}
+	for p, node := range actualNodes {
+		if _, ok := desired[p]; ok {
+			continue
+		}
+		log.Printf("Deleting node %s", node.Name)
+		err := c.k8s.Nodes().Delete(node.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "could not delete node %s", node.Name))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// podState describes virtual kubelet pod state relevant for its replacement.
+type podState int
+
+const (
+	podAvailable podState = iota
+	podUnavailable
+	podOutdated
+)
+
+// reconcilePod makes sure virtual kubelet pod exists for the partition. Finished pods
+// and not ready pods created from an outdated template are deleted right away and will be
+// recreated during the next reconciliation once deletion completes. Ready outdated pods
+// are reported so that they can be replaced one by one.
+func (c *cluster) reconcilePod(partition string, patches []string, pod *v1.Pod) (podState, error) {
+	desired, err := c.desiredPod(partition, patches)
+	if err != nil {
+		return podAvailable, errors.Wrapf(err, "could not render pod template for %s partition", partition)
+	}
+
+	if pod == nil {
+		log.Printf("Creating pod for %s partition in %s namespace", partition, c.namespace)
+		_, err := c.k8s.Pods(c.namespace).Create(desired)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return podUnavailable, errors.Wrapf(err, "could not create pod for %s partition", partition)
+		}
+		return podUnavailable, nil
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return podUnavailable, nil
+	}
+
+	finished := pod.Status.Phase == v1.PodFailed || pod.Status.Phase == v1.PodSucceeded
+	outdated := pod.Annotations[templateHashAnnotation] != desired.Annotations[templateHashAnnotation]
+	ready := isPodReady(pod)
+	switch {
+	case finished, outdated && !ready:
+		log.Printf("Recreating pod %s in %s namespace", pod.Name, c.namespace)
+		err := c.k8s.Pods(c.namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return podUnavailable, errors.Wrapf(err, "could not delete pod %s", pod.Name)
+		}
+		return podUnavailable, nil
+	case outdated:
+		return podOutdated, nil
+	case !ready:
+		return podUnavailable, nil
+	}
+	return podAvailable, nil
+}
+
+// isPodReady checks if pod has ready condition set.
+func isPodReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// reconcileNode updates virtual node labels and taints if they differ from desired ones.
+// Labels and taints set by configurator are recorded in node annotations, so those that
+// are not desired anymore, e.g. removed from cluster spec, are removed from the node.
+func (c *cluster) reconcileNode(node *v1.Node, desiredLabels map[string]string) error {
+	ownedLabels := splitList(node.Annotations[managedLabelsAnnotation])
+	ownedTaints := splitList(node.Annotations[managedTaintsAnnotation])
+	labels, labelsChanged := mergeLabels(node.Labels, withStaleLabels(node.Labels, desiredLabels, ownedLabels))
+	taints, taintsChanged := mergeTaints(node.Spec.Taints, c.spec.NodeTaints, ownedTaints)
+	annotations, annotationsChanged := mergeLabels(node.Annotations, map[string]string{
+		managedLabelsAnnotation: labelKeys(desiredLabels),
+		managedTaintsAnnotation: taintKeys(c.spec.NodeTaints),
+	})
+	if !labelsChanged && !taintsChanged && !annotationsChanged {
+		return nil
+	}
+
+	log.Printf("Updating node %s labels and taints", node.Name)
+	node = node.DeepCopy()
+	node.Labels = labels
+	node.Annotations = annotations
+	node.Spec.Taints = taints
+	if _, err := c.k8s.Nodes().Update(node); err != nil {
+		return errors.Wrapf(err, "could not update node %s", node.Name)
+	}
+	return nil
+}
+
+// nodeLabels returns all labels virtual node of the partition should have.
+// Partition labels take precedence over additional labels from cluster spec.
+func (c *cluster) nodeLabels(partition string, r *api.ResourcesResponse) map[string]string {
+	labels := make(map[string]string, len(c.spec.NodeLabels)+6)
+	for k, v := range c.spec.NodeLabels {
+		labels[k] = v
+	}
+	for k, v := range partitionLabels(partition, r) {
+		labels[k] = v
+	}
+	for _, f := range r.Features {
+		// generic resources can't be requested with constraint, so they are not labeled
+		if strings.HasPrefix(f.Name, slurm.GRESFeaturePrefix) {
+			continue
+		}
+		key, err := controller.FeatureLabel(f.Name)
+		if err != nil {
+			log.Printf("Skipping feature label for partition %s: %v", partition, err)
+			continue
+		}
+		labels[key] = "true"
+	}
+	labels[clusterLabel] = c.name
+	return labels
+}
+
+// withStaleLabels returns desired labels extended with empty values for feature labels
+// and owned labels node currently has but shouldn't, so that they are removed when
+// labels are merged.
+func withStaleLabels(current, desired map[string]string, owned []string) map[string]string {
+	res := make(map[string]string, len(desired))
+	for k, v := range desired {
+		res[k] = v
+	}
+	for k := range current {
+		if _, ok := res[k]; ok {
+			continue
+		}
+		if strings.HasPrefix(k, controller.FeatureLabelPrefix) || contains(owned, k) {
+			res[k] = ""
+		}
+	}
+	return res
+}
+
+// labelKeys returns sorted comma separated keys of labels with non-empty values.
+func labelKeys(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// taintKeys returns sorted comma separated taint keys.
+func taintKeys(taints []v1.Taint) string {
+	keys := make([]string, 0, len(taints))
+	for _, t := range taints {
+		keys = append(keys, taintKey(t))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// taintKey identifies taint by its key and effect, e.g. gpu:NoSchedule.
+func taintKey(t v1.Taint) string {
+	return t.Key + ":" + string(t.Effect)
+}
+
+// splitList splits comma separated list, empty list results in nil.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionLabels returns node labels that describe partition resources. Labels
+// for unknown resources are set to empty values and thus are removed from node.
+func partitionLabels(partition string, r *api.ResourcesResponse) map[string]string {
+	return map[string]string{
+		partitionLabel:  partition,
+		nodesLabel:      positiveOrEmpty(r.Nodes),
+		wallTimeLabel:   positiveOrEmpty(r.WallTime),
+		memPerNodeLabel: positiveOrEmpty(r.MemPerNode),
+		cpuPerNodeLabel: positiveOrEmpty(r.CpuPerNode),
+	}
+}
+
+func positiveOrEmpty(v int64) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// mergeLabels applies desired labels on top of the current ones. Desired labels
+// with empty values are removed. Current labels are never modified, merged
+// labels are returned along with a flag indicating if there was any difference.
+func mergeLabels(current, desired map[string]string) (map[string]string, bool) {
+	merged := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		merged[k] = v
+	}
+
+	changed := false
+	for k, v := range desired {
+		old, ok := merged[k]
+		if v == "" {
+			if ok {
+				delete(merged, k)
+				changed = true
+			}
+			continue
+		}
+		if !ok || old != v {
+			merged[k] = v
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// mergeTaints removes owned taints that are not desired anymore and adds desired taints
+// to the current ones or updates their values, taints are matched by key and effect.
+// Current taints are never modified, merged taints are returned along with a flag
+// indicating if there was any difference.
+func mergeTaints(current, desired []v1.Taint, owned []string) ([]v1.Taint, bool) {
+	desiredKeys := make([]string, 0, len(desired))
+	for _, d := range desired {
+		desiredKeys = append(desiredKeys, taintKey(d))
+	}
+
+	merged := make([]v1.Taint, 0, len(current)+len(desired))
+	changed := false
+	for _, t := range current {
+		key := taintKey(t)
+		if contains(owned, key) && !contains(desiredKeys, key) {
+			changed = true
+			continue
+		}
+		merged = append(merged, t)
+	}
+
+	for _, d := range desired {
+		found := false
+		for i, t := range merged {
+			if t.Key != d.Key || t.Effect != d.Effect {
+				continue
+			}
+			found = true
+			if t.Value != d.Value {
+				merged[i].Value = d.Value
+				changed = true
+			}
+			break
+		}
+		if !found {
+			merged = append(merged, d)
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// podPartition returns partition of the virtual kubelet pod if the pod
+// is managed for the cluster.
+func (c *cluster) podPartition(pod *v1.Pod) (string, bool) {
+	for _, ct := range pod.Spec.Containers {
+		if ct.Name != "vk" {
+			continue
+		}
+		for _, e := range ct.Env {
+			if e.Name == "PARTITION" && pod.Name == partitionNodeName(e.Value, c.name) {
+				return e.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// nodePartition returns partition of the virtual node if the node
+// is managed for the cluster.
+func (c *cluster) nodePartition(node *v1.Node) (string, bool) {
+	p, ok := node.Labels[partitionLabel]
+	if !ok || node.Name != partitionNodeName(p, c.name) {
+		return "", false
+	}
+	return p, true
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_mergeLabels(t *testing.T) {
+	tt := []struct {
+		name            string
+		current         map[string]string
+		desired         map[string]string
+		expectedLabels  map[string]string
+		expectedChanged bool
+	}{
+		{
+			name:            "no labels",
+			current:         nil,
+			desired:         map[string]string{nodesLabel: ""},
+			expectedLabels:  map[string]string{},
+			expectedChanged: false,
+		},
+		{
+			name:            "up to date",
+			current:         map[string]string{"type": "virtual-kubelet", nodesLabel: "2"},
+			desired:         map[string]string{nodesLabel: "2"},
+			expectedLabels:  map[string]string{"type": "virtual-kubelet", nodesLabel: "2"},
+			expectedChanged: false,
+		},
+		{
+			name:            "drifted",
+			current:         map[string]string{"type": "virtual-kubelet", nodesLabel: "2", wallTimeLabel: "60"},
+			desired:         map[string]string{nodesLabel: "4", wallTimeLabel: "", memPerNodeLabel: "1024"},
+			expectedLabels:  map[string]string{"type": "virtual-kubelet", nodesLabel: "4", memPerNodeLabel: "1024"},
+			expectedChanged: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			labels, changed := mergeLabels(tc.current, tc.desired)
+			require.Equal(t, tc.expectedLabels, labels)
+			require.Equal(t, tc.expectedChanged, changed)
+		})
+	}
+}
+
+func Test_withStaleLabels(t *testing.T) {
+	current := map[string]string{
+		"type":                        "virtual-kubelet",
+		"feature.wlm.sylabs.io/avx2":  "true",
+		"feature.wlm.sylabs.io/intel": "true",
+		"zone":                        "a",
+		"rack":                        "1",
+		nodesLabel:                    "2",
+	}
+	desired := map[string]string{
+		"feature.wlm.sylabs.io/avx2": "true",
+		"rack":                       "2",
+		nodesLabel:                   "4",
+	}
+	owned := []string{"feature.wlm.sylabs.io/avx2", "zone", "rack", nodesLabel}
+	expected := map[string]string{
+		"feature.wlm.sylabs.io/avx2":  "true",
+		"feature.wlm.sylabs.io/intel": "",
+		"zone":                        "",
+		"rack":                        "2",
+		nodesLabel:                    "4",
+	}
+	require.Equal(t, expected, withStaleLabels(current, desired, owned))
+}
+
+func Test_mergeTaints(t *testing.T) {
+	unreachable := v1.Taint{Key: unreachableTaintKey, Effect: v1.TaintEffectNoSchedule}
+	gpu := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}
+	gpuFalse := v1.Taint{Key: "gpu", Value: "false", Effect: v1.TaintEffectNoSchedule}
+	gpuExecute := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoExecute}
+
+	tt := []struct {
+		name            string
+		current         []v1.Taint
+		desired         []v1.Taint
+		owned           []string
+		expectedTaints  []v1.Taint
+		expectedChanged bool
+	}{
+		{
+			name:            "up to date",
+			current:         []v1.Taint{unreachable, gpu},
+			desired:         []v1.Taint{gpu},
+			expectedTaints:  []v1.Taint{unreachable, gpu},
+			expectedChanged: false,
+		},
+		{
+			name:            "missing",
+			current:         []v1.Taint{unreachable},
+			desired:         []v1.Taint{gpu, gpuExecute},
+			expectedTaints:  []v1.Taint{unreachable, gpu, gpuExecute},
+			expectedChanged: true,
+		},
+		{
+			name:            "value changed",
+			current:         []v1.Taint{gpuFalse, unreachable},
+			desired:         []v1.Taint{gpu},
+			expectedTaints:  []v1.Taint{gpu, unreachable},
+			expectedChanged: true,
+		},
+		{
+			name:            "owned removed",
+			current:         []v1.Taint{gpu, unreachable, gpuExecute},
+			desired:         []v1.Taint{gpuExecute},
+			owned:           []string{"gpu:NoSchedule", "gpu:NoExecute"},
+			expectedTaints:  []v1.Taint{unreachable, gpuExecute},
+			expectedChanged: true,
+		},
+		{
+			name:            "not owned kept",
+			current:         []v1.Taint{gpu, unreachable},
+			desired:         nil,
+			owned:           []string{"gpu:NoExecute"},
+			expectedTaints:  []v1.Taint{gpu, unreachable},
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			taints, changed := mergeTaints(tc.current, tc.desired, tc.owned)
+			require.Equal(t, tc.expectedTaints, taints)
+			require.Equal(t, tc.expectedChanged, changed)
+		})
+	}
+}
+
+func Test_reconcileNode(t *testing.T) {
+	gpu := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}
+	unreachable := v1.Taint{Key: unreachableTaintKey, Effect: v1.TaintEffectNoSchedule}
+	nodes := &fakeNodes{}
+	c := &cluster{
+		spec: v1alpha1.SlurmClusterSpec{
+			NodeLabels: map[string]string{"zone": "a"},
+			NodeTaints: []v1.Taint{gpu},
+		},
+		k8s: &fakeCore{nodes: nodes},
+	}
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "slurm-cluster-debug",
+			Labels: map[string]string{"type": "virtual-kubelet", "rack": "1"},
+		},
+		Spec: v1.NodeSpec{Taints: []v1.Taint{unreachable}},
+	}
+
+	require.NoError(t, c.reconcileNode(node, map[string]string{"zone": "a", nodesLabel: "2", wallTimeLabel: ""}))
+	node = nodes.updated
+	require.NotNil(t, node)
+	require.Equal(t, map[string]string{"type": "virtual-kubelet", "rack": "1", "zone": "a", nodesLabel: "2"}, node.Labels)
+	require.Equal(t, []v1.Taint{unreachable, gpu}, node.Spec.Taints)
+	require.Equal(t, map[string]string{
+		managedLabelsAnnotation: nodesLabel + ",zone",
+		managedTaintsAnnotation: "gpu:NoSchedule",
+	}, node.Annotations)
+
+	// labels and taints removed from cluster spec are removed from node
+	nodes.updated = nil
+	c.spec = v1alpha1.SlurmClusterSpec{}
+	require.NoError(t, c.reconcileNode(node, map[string]string{nodesLabel: "2"}))
+	node = nodes.updated
+	require.NotNil(t, node)
+	require.Equal(t, map[string]string{"type": "virtual-kubelet", "rack": "1", nodesLabel: "2"}, node.Labels)
+	require.Equal(t, []v1.Taint{unreachable}, node.Spec.Taints)
+	require.Equal(t, map[string]string{managedLabelsAnnotation: nodesLabel}, node.Annotations)
+
+	// nothing is updated once node is up to date
+	nodes.updated = nil
+	require.NoError(t, c.reconcileNode(node, map[string]string{nodesLabel: "2"}))
+	require.Nil(t, nodes.updated)
+}
+
+func Test_nodeLabels(t *testing.T) {
+	c := &cluster{name: "slurm-cluster"}
+	labels := c.nodeLabels("debug", &api.ResourcesResponse{
+		Nodes: 2,
+		Features: []*api.Feature{
+			{Name: "gpu", Quantity: 1},
+			{Name: "gres/gpu", Version: "tesla", Quantity: 2},
+		},
+	})
+	require.Equal(t, "true", labels["feature.wlm.sylabs.io/gpu"])
+	for k := range labels {
+		require.NotContains(t, k, "gres")
+	}
+}
+
+func Test_updateLicenses(t *testing.T) {
+	c := &cluster{slurm: &fakeSlurm{licenses: []*api.Feature{{Name: "matlab", Quantity: 10}}}}
+	require.NoError(t, c.updateLicenses(context.Background()))
+
+	w := &clusterWorker{cluster: c, generation: 2}
+	require.Equal(t, v1alpha1.SlurmClusterStatus{
+		Licenses:           []v1alpha1.ClusterLicense{{Name: "matlab", Total: 10}},
+		ObservedGeneration: 2,
+	}, w.clusterStatus())
+}
+
+func Test_nextBackoff(t *testing.T) {
+	var got []time.Duration
+	var d time.Duration
+	for i := 0; i < 12; i++ {
+		d = nextBackoff(d)
+		got = append(got, d)
+	}
+	require.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		32 * time.Second, 64 * time.Second, 128 * time.Second, 256 * time.Second, maxBackoff, maxBackoff, maxBackoff,
+	}, got)
+}
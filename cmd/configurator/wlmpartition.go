@@ -0,0 +1,164 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// updateWlmPartitions creates or updates WlmPartition of each selected cluster partition
+// and deletes WlmPartitions of partitions that are removed or no longer selected. Status
+// of all partitions is fetched at once, partitions with unknown resources are skipped.
+func (c *cluster) updateWlmPartitions(ctx context.Context, partitions []string, resources map[string]*api.ResourcesResponse) error {
+	existing, err := c.wlm.WlmPartitions(c.namespace).List(metav1.ListOptions{
+		LabelSelector: clusterLabel + "=" + c.name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not get wlm partitions")
+	}
+	byName := make(map[string]*v1alpha1.WlmPartition, len(existing.Items))
+	for i := range existing.Items {
+		byName[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	var statuses map[string]*api.PartitionStatusResponse
+	if len(partitions) != 0 {
+		resp, err := c.slurm.PartitionStatus(ctx, &api.PartitionStatusRequest{Partitions: partitions})
+		if err != nil {
+			return errors.Wrap(err, "could not get partitions status")
+		}
+		statuses = resp.Partitions
+	}
+
+	var errs []error
+	desired := make(map[string]struct{})
+	for _, p := range partitions {
+		name := partitionNodeName(p, c.name)
+		desired[name] = struct{}{}
+
+		res, ok := resources[p]
+		if !ok {
+			continue
+		}
+		status, ok := statuses[p]
+		if !ok {
+			errs = append(errs, errors.Errorf("status of %s partition is missing", p))
+			continue
+		}
+		if err := c.updateWlmPartition(p, res, status, byName[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for name := range byName {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		log.Printf("Deleting wlm partition %s in %s namespace", name, c.namespace)
+		err := c.wlm.WlmPartitions(c.namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "could not delete wlm partition %s", name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// updateWlmPartition refreshes WlmPartition status of the partition, WlmPartition is created if missing.
+func (c *cluster) updateWlmPartition(partition string, res *api.ResourcesResponse,
+	status *api.PartitionStatusResponse, wp *v1alpha1.WlmPartition) error {
+
+	if wp == nil {
+		name := partitionNodeName(partition, c.name)
+		log.Printf("Creating wlm partition %s in %s namespace", name, c.namespace)
+		created, err := c.wlm.WlmPartitions(c.namespace).Create(c.wlmPartitionTemplate(partition))
+		if apierrors.IsAlreadyExists(err) {
+			created, err = c.wlm.WlmPartitions(c.namespace).Get(name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not create wlm partition %s", name)
+		}
+		wp = created
+	}
+
+	wp = wp.DeepCopy()
+	wp.Status = wlmPartitionStatus(res, status, time.Now())
+	if _, err := c.wlm.WlmPartitions(c.namespace).UpdateStatus(wp); err != nil {
+		return errors.Wrapf(err, "could not update wlm partition %s status", wp.Name)
+	}
+	return nil
+}
+
+// wlmPartitionTemplate returns WlmPartition ready to be created for the partition.
+func (c *cluster) wlmPartitionTemplate(partition string) *v1alpha1.WlmPartition {
+	name := partitionNodeName(partition, c.name)
+	wp := &v1alpha1.WlmPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.namespace,
+			Labels: map[string]string{
+				clusterLabel:   c.name,
+				partitionLabel: partition,
+			},
+		},
+		Spec: v1alpha1.WlmPartitionSpec{
+			Cluster:   c.name,
+			Partition: partition,
+			Node:      name,
+		},
+	}
+	if c.owner != nil {
+		wp.OwnerReferences = []metav1.OwnerReference{*c.owner}
+	}
+	return wp
+}
+
+// wlmPartitionStatus converts partition resources and status into WlmPartition status.
+// Unknown or unlimited limits are left unset.
+func wlmPartitionStatus(res *api.ResourcesResponse, status *api.PartitionStatusResponse, now time.Time) v1alpha1.WlmPartitionStatus {
+	s := v1alpha1.WlmPartitionStatus{
+		State:          status.State,
+		MaxTime:        nonNegative(res.WallTime),
+		MaxNodes:       nonNegative(res.Nodes),
+		CPUPerNode:     nonNegative(res.CpuPerNode),
+		MemPerNode:     nonNegative(res.MemPerNode),
+		PendingJobs:    status.PendingJobs,
+		RunningJobs:    status.RunningJobs,
+		LastUpdateTime: metav1.NewTime(now),
+	}
+	for _, f := range res.Features {
+		s.Features = append(s.Features, v1alpha1.PartitionFeature{
+			Name:     f.Name,
+			Version:  f.Version,
+			Quantity: f.Quantity,
+		})
+	}
+	return s
+}
+
+func nonNegative(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/fake"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_updateWlmPartitions(t *testing.T) {
+	stale := &v1alpha1.WlmPartition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      partitionNodeName("old", "cluster"),
+			Namespace: "default",
+			Labels:    map[string]string{clusterLabel: "cluster"},
+		},
+	}
+	wlmC := fake.NewSimpleClientset(stale).WlmV1alpha1()
+	slurm := &fakeSlurm{statuses: map[string]*api.PartitionStatusResponse{
+		"debug": {State: "UP", PendingJobs: 1},
+		"gpu":   {State: "DRAIN", RunningJobs: 2},
+	}}
+	c := &cluster{
+		name:      "cluster",
+		namespace: "default",
+		slurm:     slurm,
+		wlm:       wlmC,
+	}
+	resources := map[string]*api.ResourcesResponse{
+		"debug": {Nodes: 2},
+		"gpu":   {Nodes: 1},
+	}
+
+	err := c.updateWlmPartitions(context.Background(), []string{"debug", "gpu", "broken"}, resources)
+	require.NoError(t, err)
+	require.Equal(t, 1, slurm.statusCalls)
+
+	list, err := wlmC.WlmPartitions("default").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	states := make(map[string]string)
+	for _, wp := range list.Items {
+		states[wp.Spec.Partition] = wp.Status.State
+	}
+	// partition with unknown resources is not created, stale one is deleted
+	require.Equal(t, map[string]string{"debug": "UP", "gpu": "DRAIN"}, states)
+}
+
+func Test_wlmPartitionStatus(t *testing.T) {
+	now := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	res := &api.ResourcesResponse{
+		Nodes:      -1,
+		CpuPerNode: 4,
+		MemPerNode: 2048,
+		WallTime:   3600,
+		Features: []*api.Feature{
+			{Name: "gpu", Version: "v100", Quantity: 2},
+			{Name: "avx2", Quantity: 3},
+		},
+	}
+	status := &api.PartitionStatusResponse{
+		State:       "DRAIN",
+		PendingJobs: 5,
+		RunningJobs: 2,
+	}
+
+	require.Equal(t, v1alpha1.WlmPartitionStatus{
+		State:      "DRAIN",
+		MaxTime:    3600,
+		MaxNodes:   0,
+		CPUPerNode: 4,
+		MemPerNode: 2048,
+		Features: []v1alpha1.PartitionFeature{
+			{Name: "gpu", Version: "v100", Quantity: 2},
+			{Name: "avx2", Quantity: 3},
+		},
+		PendingJobs:    5,
+		RunningJobs:    2,
+		LastUpdateTime: metav1.NewTime(now),
+	}, wlmPartitionStatus(res, status, now))
+}
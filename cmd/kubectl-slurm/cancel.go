@@ -0,0 +1,58 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var cancelCmd = &command{
+	name:  "cancel",
+	args:  "NAME...",
+	help:  "Cancel SlurmJobs, cancelled jobs are kept along with their status",
+	flags: flag.NewFlagSet("cancel", flag.ContinueOnError),
+	run:   runCancel,
+}
+
+// runCancel requests slurm jobs to be cancelled with an annotation, so that cancellation
+// goes through API server and is allowed to users who may update the SlurmJob only.
+func runCancel(_ context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 1, -1, "cancel NAME..."); err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{controller.CancelAnnotation: "true"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		if _, err := e.wlm.SlurmJobs(e.namespace).Patch(name, types.MergePatchType, patch); err != nil {
+			return errors.Wrapf(err, "could not cancel job %s", name)
+		}
+		fmt.Fprintf(e.out, "slurmjob.wlm.sylabs.io/%s cancel requested\n", name)
+	}
+	return nil
+}
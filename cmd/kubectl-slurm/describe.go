@@ -0,0 +1,176 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var describeCmd = &command{
+	name:  "describe",
+	args:  "NAME",
+	help:  "Show SlurmJob status along with slurm job details",
+	flags: flag.NewFlagSet("describe", flag.ContinueOnError),
+	run:   runDescribe,
+}
+
+func runDescribe(ctx context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 1, 1, "describe NAME"); err != nil {
+		return err
+	}
+
+	sj, err := e.wlm.SlurmJobs(e.namespace).Get(args[0], metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not get job %s", args[0])
+	}
+	pod, err := jobPod(e, sj.Name)
+	if err != nil {
+		return err
+	}
+
+	// slurm details are optional, e.g. red-box may be unreachable
+	// from user host, so errors are printed instead of job info
+	var info *api.JobInfo
+	var infoErr error
+	if pod != nil {
+		var id int64
+		id, infoErr = controller.JobIDFromPod(pod)
+		if infoErr == nil && id != 0 {
+			info, infoErr = jobInfo(ctx, e, id)
+		}
+	}
+
+	return printDescription(e.out, sj, pod, info, infoErr)
+}
+
+// printDescription prints slurm job along with its pod and slurm job info in
+// kubectl describe format. Pod and info may be nil if they are not known.
+func printDescription(out io.Writer, sj *v1alpha1.SlurmJob, pod *corev1.Pod, info *api.JobInfo, infoErr error) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", sj.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", sj.Namespace)
+	fmt.Fprintf(w, "Created:\t%s\n", sj.CreationTimestamp.Format(time.RFC1123Z))
+	fmt.Fprintf(w, "Status:\t%s\n", orNone(sj.Status.Status))
+	if pod != nil {
+		fmt.Fprintf(w, "Pod:\t%s\n", pod.Name)
+		fmt.Fprintf(w, "Node:\t%s\n", orNone(pod.Spec.NodeName))
+	} else {
+		fmt.Fprintf(w, "Pod:\t<none>\n")
+	}
+	if len(sj.Spec.NodeSelector) != 0 {
+		var selector []string
+		for k, v := range sj.Spec.NodeSelector {
+			selector = append(selector, k+"="+v)
+		}
+		sort.Strings(selector)
+		fmt.Fprintf(w, "Node Selector:\t%s\n", strings.Join(selector, ","))
+	}
+	if r := sj.Spec.Results; r != nil {
+		fmt.Fprintf(w, "Results:\t%s -> volume %s\n", r.From, r.Mount.Name)
+	}
+
+	if len(sj.Status.Conditions) != 0 {
+		fmt.Fprintf(w, "Conditions:\n")
+		fmt.Fprintf(w, "  Type\tStatus\tReason\tMessage\n")
+		for _, c := range sj.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+		}
+	}
+
+	if u := sj.Status.Usage; u != nil {
+		fmt.Fprintf(w, "Usage:\n")
+		fmt.Fprintf(w, "  Elapsed:\t%s\n", time.Duration(u.Elapsed)*time.Second)
+		fmt.Fprintf(w, "  CPU Time:\t%s\n", time.Duration(u.CPUTime)*time.Second)
+		fmt.Fprintf(w, "  Max RSS:\t%s\n", resource.NewQuantity(u.MaxRSS, resource.BinarySI))
+		fmt.Fprintf(w, "  CPU Efficiency:\t%d%%\n", u.CPUEfficiency)
+		fmt.Fprintf(w, "  Memory Efficiency:\t%d%%\n", u.MemoryEfficiency)
+	}
+
+	fmt.Fprintf(w, "Slurm Job:\n")
+	switch {
+	case infoErr != nil:
+		fmt.Fprintf(w, "  <unavailable: %v>\n", infoErr)
+	case info == nil:
+		fmt.Fprintf(w, "  <not submitted>\n")
+	default:
+		fmt.Fprintf(w, "  ID:\t%s\n", info.Id)
+		fmt.Fprintf(w, "  Name:\t%s\n", info.Name)
+		fmt.Fprintf(w, "  State:\t%s\n", info.Status)
+		fmt.Fprintf(w, "  Partition:\t%s\n", info.Partition)
+		fmt.Fprintf(w, "  Nodes:\t%s\n", orNone(info.NodeList))
+		fmt.Fprintf(w, "  Submitted:\t%s\n", formatTimestamp(info.SubmitTime))
+		fmt.Fprintf(w, "  Started:\t%s\n", formatTimestamp(info.StartTime))
+		fmt.Fprintf(w, "  Run Time:\t%s\n", formatDuration(info.RunTime))
+		fmt.Fprintf(w, "  Time Limit:\t%s\n", formatDuration(info.TimeLimit))
+		fmt.Fprintf(w, "  Exit Code:\t%s\n", orNone(info.ExitCode))
+		fmt.Fprintf(w, "  Working Dir:\t%s\n", orNone(info.WorkingDir))
+		fmt.Fprintf(w, "  StdOut:\t%s\n", orNone(info.StdOut))
+		fmt.Fprintf(w, "  StdErr:\t%s\n", orNone(info.StdErr))
+	}
+
+	fmt.Fprintf(w, "Batch:\n")
+	for _, line := range strings.Split(strings.TrimRight(sj.Spec.Batch, "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	return w.Flush()
+}
+
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return "<none>"
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "<invalid>"
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func formatDuration(d *duration.Duration) string {
+	if d == nil {
+		return "<none>"
+	}
+	dur, err := ptypes.Duration(d)
+	if err != nil {
+		return "<invalid>"
+	}
+	return dur.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
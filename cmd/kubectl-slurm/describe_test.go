@@ -0,0 +1,100 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_printDescription(t *testing.T) {
+	created := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	sj := &v1alpha1.SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "cow",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v1alpha1.SlurmJobSpec{
+			Batch:        "#!/bin/sh\nsrun hostname\n",
+			NodeSelector: map[string]string{"wlm.sylabs.io/partition": "debug"},
+		},
+		Status: v1alpha1.SlurmJobStatus{Status: "Running"},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "cow-job"},
+		Spec:       corev1.PodSpec{NodeName: "slurm-login-debug"},
+	}
+	submitted, _ := ptypes.TimestampProto(created.Add(time.Minute))
+	info := &api.JobInfo{
+		Id:         "42",
+		Name:       "cow",
+		Status:     api.JobStatus_RUNNING,
+		SubmitTime: submitted,
+		RunTime:    ptypes.DurationProto(90 * time.Second),
+		TimeLimit:  ptypes.DurationProto(time.Hour),
+		Partition:  "debug",
+		NodeList:   "node1",
+		WorkingDir: "/home/user",
+		StdOut:     "/home/user/slurm-42.out",
+	}
+
+	t.Run("slurm job info", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, printDescription(&out, sj, pod, info, nil))
+		require.Equal(t, ""+
+			"Name:           cow\n"+
+			"Namespace:      default\n"+
+			"Created:        Wed, 01 May 2019 10:00:00 +0000\n"+
+			"Status:         Running\n"+
+			"Pod:            cow-job\n"+
+			"Node:           slurm-login-debug\n"+
+			"Node Selector:  wlm.sylabs.io/partition=debug\n"+
+			"Slurm Job:\n"+
+			"  ID:           42\n"+
+			"  Name:         cow\n"+
+			"  State:        RUNNING\n"+
+			"  Partition:    debug\n"+
+			"  Nodes:        node1\n"+
+			"  Submitted:    Wed, 01 May 2019 10:01:00 +0000\n"+
+			"  Started:      <none>\n"+
+			"  Run Time:     1m30s\n"+
+			"  Time Limit:   1h0m0s\n"+
+			"  Exit Code:    <none>\n"+
+			"  Working Dir:  /home/user\n"+
+			"  StdOut:       /home/user/slurm-42.out\n"+
+			"  StdErr:       <none>\n"+
+			"Batch:\n"+
+			"  #!/bin/sh\n"+
+			"  srun hostname\n",
+			out.String())
+	})
+
+	t.Run("red-box unavailable", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, printDescription(&out, sj, nil, nil, errors.New("connection refused")))
+		require.Contains(t, out.String(), "Pod:            <none>\n")
+		require.Contains(t, out.String(), "Slurm Job:\n  <unavailable: connection refused>\n")
+	})
+}
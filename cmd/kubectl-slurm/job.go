@@ -0,0 +1,56 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller/slurmjob"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// jobPod returns job-companion pod of the slurm job, nil is returned
+// if pod is not created yet.
+func jobPod(e *env, name string) (*corev1.Pod, error) {
+	pod, err := e.core.Pods(e.namespace).Get(slurmjob.PodName(name), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get pod of job %s", name)
+	}
+	return pod, nil
+}
+
+// jobInfo returns information about slurm job. For array jobs information
+// about the array root is returned.
+func jobInfo(ctx context.Context, e *env, id int64) (*api.JobInfo, error) {
+	slurm, err := e.redBox()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := slurm.JobInfo(ctx, &api.JobInfoRequest{JobId: id})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get slurm job %d info", id)
+	}
+	if len(resp.Info) == 0 {
+		return nil, errors.Errorf("slurm job %d is not found", id)
+	}
+	return resp.Info[0], nil
+}
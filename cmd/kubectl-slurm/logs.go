@@ -0,0 +1,120 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	logsFlags  = flag.NewFlagSet("logs", flag.ContinueOnError)
+	logsFollow = logsFlags.Bool("f", false, "follow job output until job is finished")
+	logsTail   = logsFlags.Int64("tail", 0, "number of last lines to print, all lines are printed by default")
+
+	logsCmd = &command{
+		name:  "logs",
+		args:  "NAME",
+		help:  "Print output of a SlurmJob",
+		flags: logsFlags,
+		run:   runLogs,
+	}
+)
+
+// runLogs prints logs of the job pod, which virtual kubelet serves from slurm job output.
+func runLogs(ctx context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 1, 1, "logs NAME"); err != nil {
+		return err
+	}
+
+	name := args[0]
+	if _, err := e.wlm.SlurmJobs(e.namespace).Get(name, metav1.GetOptions{}); err != nil {
+		return errors.Wrapf(err, "could not get job %s", name)
+	}
+	pod, err := jobPod(e, name)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		return errors.Errorf("job %s is not scheduled yet", name)
+	}
+
+	opts := &corev1.PodLogOptions{Follow: *logsFollow}
+	if *logsTail > 0 {
+		opts.TailLines = logsTail
+	}
+	logs, err := e.core.Pods(e.namespace).GetLogs(pod.Name, opts).Stream()
+	if err != nil {
+		return errors.Wrapf(err, "could not get job %s logs", name)
+	}
+	defer logs.Close()
+
+	// stream is not bound to context, so it is closed on interruption
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = logs.Close()
+		case <-done:
+		}
+	}()
+
+	_, err = io.Copy(e.out, logs)
+	if ctx.Err() != nil {
+		// interrupted by user
+		return nil
+	}
+	return errors.Wrap(err, "could not write job logs")
+}
+
+// download writes the whole file to w.
+func download(ctx context.Context, e *env, path string, w io.Writer) error {
+	slurm, err := e.redBox()
+	if err != nil {
+		return err
+	}
+	stream, err := slurm.OpenFile(ctx, &api.OpenFileRequest{Path: path})
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", path)
+	}
+	return copyChunks(stream, w)
+}
+
+type chunkReceiver interface {
+	Recv() (*api.Chunk, error)
+}
+
+// copyChunks writes received chunks to w until stream is ended.
+func copyChunks(stream chunkReceiver, w io.Writer) error {
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not receive file")
+		}
+		if _, err := w.Write(chunk.Content); err != nil {
+			return errors.Wrap(err, "could not write file")
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command kubectl-slurm is a kubectl plugin that lets users submit batch scripts
+// as SlurmJobs and manage them from a terminal without writing YAML.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const unixPrefix = "unix://"
+
+var version = "unknown"
+
+// command is a single kubectl-slurm subcommand.
+type command struct {
+	name  string
+	args  string
+	help  string
+	flags *flag.FlagSet
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = []*command{
+	submitCmd,
+	logsCmd,
+	cancelCmd,
+	describeCmd,
+	partitionsCmd,
+	resultsCmd,
+}
+
+// options are flags accepted by every command.
+type options struct {
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+	redBoxSock  string
+}
+
+func (o *options) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	fs.StringVar(&o.kubeContext, "context", "", "name of the kubeconfig context to use")
+	fs.StringVar(&o.namespace, "namespace", "", "namespace of slurm jobs, current context namespace by default")
+	fs.StringVar(&o.namespace, "n", "", "shorthand for -namespace")
+	fs.StringVar(&o.redBoxSock, "red-box", "/var/run/syslurm/red-box.sock", "path to red-box socket")
+}
+
+// env holds clients shared by commands.
+type env struct {
+	namespace  string
+	wlm        wlmv1alpha1.WlmV1alpha1Interface
+	core       corev1.CoreV1Interface
+	redBoxSock string
+	out        io.Writer
+
+	slurm api.WorkloadManagerClient
+}
+
+func newEnv(o *options) (*env, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.kubeContext}
+	overrides.Context.Namespace = o.namespace
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load kubeconfig")
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get namespace")
+	}
+
+	wlmC, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create wlm client")
+	}
+	coreC, err := corev1.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create core client")
+	}
+
+	return &env{
+		namespace:  namespace,
+		wlm:        wlmC.WlmV1alpha1(),
+		core:       coreC,
+		redBoxSock: o.redBoxSock,
+		out:        os.Stdout,
+	}, nil
+}
+
+// redBox returns client of red-box that serves the slurm cluster. Connection is
+// established on the first call, so commands that don't need red-box work without it.
+func (e *env) redBox() (api.WorkloadManagerClient, error) {
+	if e.slurm != nil {
+		return e.slurm, nil
+	}
+	conn, err := grpc.Dial(unixPrefix+e.redBoxSock, grpc.WithInsecure())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to red-box at %s", e.redBoxSock)
+	}
+	e.slurm = api.NewWorkloadManagerClient(conn)
+	return e.slurm, nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+		return
+	case "version":
+		fmt.Printf("version: %s\n", version)
+		return
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	var opts options
+	opts.addFlags(cmd.flags)
+	cmd.flags.Usage = func() {
+		fmt.Fprintf(cmd.flags.Output(), "%s\n\nUsage:\n  kubectl slurm %s [flags] %s\n\nFlags:\n", cmd.help, cmd.name, cmd.args)
+		cmd.flags.PrintDefaults()
+	}
+	args, err := parseArgs(cmd.flags, os.Args[2:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(2)
+	}
+
+	e, err := newEnv(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, unix.SIGINT, unix.SIGTERM)
+		<-sig
+		cancel()
+	}()
+
+	if err := cmd.run(ctx, e, args); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "kubectl slurm submits and manages Slurm jobs running in Kubernetes.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-12s%s\n", cmd.name, cmd.help)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Use \"kubectl slurm <command> -h\" for more information about a command.")
+}
+
+// parseArgs parses flags that may be interspersed with positional arguments,
+// e.g. 'logs cow -f', and returns positional arguments. Arguments after '--'
+// are treated as positional.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// requireArgs checks that command got the expected number of positional arguments,
+// negative max means any number. Usage is a command synopsis, e.g. 'logs NAME'.
+func requireArgs(args []string, min, max int, usage string) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return errors.Errorf("usage: kubectl slurm %s", usage)
+	}
+	return nil
+}
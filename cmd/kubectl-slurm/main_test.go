@@ -0,0 +1,85 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseArgs(t *testing.T) {
+	tt := []struct {
+		name         string
+		args         []string
+		expectArgs   []string
+		expectFollow bool
+		expectNs     string
+		expectErr    bool
+	}{
+		{
+			name:       "no flags",
+			args:       []string{"cow"},
+			expectArgs: []string{"cow"},
+		},
+		{
+			name:         "flags first",
+			args:         []string{"-f", "-n", "test", "cow"},
+			expectArgs:   []string{"cow"},
+			expectFollow: true,
+			expectNs:     "test",
+		},
+		{
+			name:         "interspersed flags",
+			args:         []string{"cow", "--namespace=test", "fox", "-f"},
+			expectArgs:   []string{"cow", "fox"},
+			expectFollow: true,
+			expectNs:     "test",
+		},
+		{
+			name:       "terminator",
+			args:       []string{"-n", "test", "--", "-f"},
+			expectArgs: []string{"-f"},
+			expectNs:   "test",
+		},
+		{
+			name:      "unknown flag",
+			args:      []string{"cow", "-x"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			follow := fs.Bool("f", false, "")
+			var opts options
+			opts.addFlags(fs)
+
+			args, err := parseArgs(fs, tc.args)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectArgs, args)
+			require.Equal(t, tc.expectFollow, *follow)
+			require.Equal(t, tc.expectNs, opts.namespace)
+		})
+	}
+}
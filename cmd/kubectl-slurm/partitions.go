@@ -0,0 +1,109 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var partitionsCmd = &command{
+	name:  "partitions",
+	help:  "List partitions of connected slurm clusters",
+	flags: flag.NewFlagSet("partitions", flag.ContinueOnError),
+	run:   runPartitions,
+}
+
+func runPartitions(ctx context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 0, 0, "partitions"); err != nil {
+		return err
+	}
+
+	// partitions are mirrored to configurator namespace, which
+	// is not necessarily the one jobs are submitted to
+	partitions, err := e.wlm.WlmPartitions(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "could not list partitions")
+	}
+	return printPartitions(e.out, partitions.Items)
+}
+
+// printPartitions prints partitions table sorted by cluster and partition names.
+func printPartitions(out io.Writer, partitions []v1alpha1.WlmPartition) error {
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Spec.Cluster != partitions[j].Spec.Cluster {
+			return partitions[i].Spec.Cluster < partitions[j].Spec.Cluster
+		}
+		return partitions[i].Spec.Partition < partitions[j].Spec.Partition
+	})
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tPARTITION\tSTATE\tNODES\tCPU/NODE\tMEM/NODE\tMAX TIME\tPENDING\tRUNNING\tFEATURES")
+	for _, p := range partitions {
+		s := p.Status
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			p.Spec.Cluster,
+			p.Spec.Partition,
+			orNone(s.State),
+			limit(s.MaxNodes, ""),
+			limit(s.CPUPerNode, ""),
+			limit(s.MemPerNode, "M"),
+			maxTime(s.MaxTime),
+			s.PendingJobs,
+			s.RunningJobs,
+			features(s.Features),
+		)
+	}
+	return w.Flush()
+}
+
+// limit formats partition limit, zero limits are unknown.
+func limit(v int64, unit string) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%s", v, unit)
+}
+
+func maxTime(secs int64) string {
+	if secs == 0 {
+		return "unlimited"
+	}
+	return (time.Duration(secs) * time.Second).String()
+}
+
+func features(ff []v1alpha1.PartitionFeature) string {
+	if len(ff) == 0 {
+		return "<none>"
+	}
+	names := make([]string, len(ff))
+	for i, f := range ff {
+		names[i] = f.Name
+		if f.Version != "" {
+			names[i] += ":" + f.Version
+		}
+	}
+	return strings.Join(names, ",")
+}
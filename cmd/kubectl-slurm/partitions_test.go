@@ -0,0 +1,53 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func Test_printPartitions(t *testing.T) {
+	partitions := []v1alpha1.WlmPartition{
+		{
+			Spec: v1alpha1.WlmPartitionSpec{Cluster: "hpc", Partition: "gpu"},
+			Status: v1alpha1.WlmPartitionStatus{
+				State:       "UP",
+				MaxNodes:    4,
+				CPUPerNode:  32,
+				MemPerNode:  128000,
+				MaxTime:     7200,
+				Features:    []v1alpha1.PartitionFeature{{Name: "gpu", Version: "v100", Quantity: 8}, {Name: "ib"}},
+				PendingJobs: 3,
+				RunningJobs: 1,
+			},
+		},
+		{
+			Spec:   v1alpha1.WlmPartitionSpec{Cluster: "hpc", Partition: "debug"},
+			Status: v1alpha1.WlmPartitionStatus{State: "DRAIN"},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printPartitions(&out, partitions))
+	require.Equal(t, ""+
+		"CLUSTER  PARTITION  STATE  NODES  CPU/NODE  MEM/NODE  MAX TIME   PENDING  RUNNING  FEATURES\n"+
+		"hpc      debug      DRAIN  -      -         -         unlimited  0        0        <none>\n"+
+		"hpc      gpu        UP     4      32        128000M   2h0m0s     3        1        gpu:v100,ib\n",
+		out.String())
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	resultsFlags = flag.NewFlagSet("results", flag.ContinueOnError)
+	resultsDir   = resultsFlags.String("dir", ".", "directory to download results to")
+
+	resultsCmd = &command{
+		name:  "results",
+		args:  "NAME [FILE...]",
+		help:  "Download SlurmJob results, files collected by the job are downloaded unless FILE is passed",
+		flags: resultsFlags,
+		run:   runResults,
+	}
+)
+
+func runResults(ctx context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 1, -1, "results NAME [FILE...]"); err != nil {
+		return err
+	}
+
+	sj, err := e.wlm.SlurmJobs(e.namespace).Get(args[0], metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not get job %s", args[0])
+	}
+	files := args[1:]
+	if len(files) == 0 {
+		if sj.Spec.Results == nil || sj.Spec.Results.From == "" {
+			return errors.Errorf("job %s does not collect results, pass files to download", sj.Name)
+		}
+		files = []string{sj.Spec.Results.From}
+	}
+
+	if err := os.MkdirAll(*resultsDir, 0755); err != nil {
+		return errors.Wrap(err, "could not create results directory")
+	}
+	for _, from := range files {
+		to := filepath.Join(*resultsDir, path.Base(from))
+		if err := downloadFile(ctx, e, from, to); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.out, "%s downloaded to %s\n", from, to)
+	}
+	return nil
+}
+
+// downloadFile downloads file from slurm cluster. Paths are relative to
+// red-box working directory, as they are when results are collected.
+func downloadFile(ctx context.Context, e *env, from, to string) error {
+	f, err := os.Create(to)
+	if err != nil {
+		return errors.Wrap(err, "could not create results file")
+	}
+	if err := download(ctx, e, from, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(to)
+		return err
+	}
+	return f.Close()
+}
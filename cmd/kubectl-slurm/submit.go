@@ -0,0 +1,141 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	submitFlags     = flag.NewFlagSet("submit", flag.ContinueOnError)
+	submitName      = submitFlags.String("name", "", "job name, script file name without extension by default")
+	submitPartition = submitFlags.String("partition", "", "partition to submit job to")
+	submitDryRun    = submitFlags.Bool("dry-run", false, "print job instead of submitting it")
+
+	submitCmd = &command{
+		name:  "submit",
+		args:  "SCRIPT",
+		help:  "Submit batch script as a SlurmJob, use - to read script from stdin",
+		flags: submitFlags,
+		run:   runSubmit,
+	}
+)
+
+// invalidNameChars matches characters that are not allowed in job names.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func runSubmit(ctx context.Context, e *env, args []string) error {
+	if err := requireArgs(args, 1, 1, "submit SCRIPT"); err != nil {
+		return err
+	}
+
+	var batch []byte
+	var err error
+	if args[0] == "-" {
+		batch, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		batch, err = ioutil.ReadFile(args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not read script")
+	}
+
+	name := *submitName
+	if name == "" {
+		if name = jobName(args[0]); name == "" {
+			return errors.Errorf("could not get job name from %s, use -name flag", args[0])
+		}
+	}
+	sj, err := newSlurmJob(name, e.namespace, string(batch), *submitPartition)
+	if err != nil {
+		return err
+	}
+
+	if *submitDryRun {
+		out, err := yaml.Marshal(sj)
+		if err != nil {
+			return errors.Wrap(err, "could not encode job")
+		}
+		_, err = e.out.Write(out)
+		return err
+	}
+
+	sj, err = e.wlm.SlurmJobs(e.namespace).Create(sj)
+	if err != nil {
+		return errors.Wrap(err, "could not create job")
+	}
+	fmt.Fprintf(e.out, "slurmjob.wlm.sylabs.io/%s created\n", sj.Name)
+	return nil
+}
+
+// newSlurmJob returns slurm job that runs batch script. When partition
+// is not empty job is scheduled on the virtual node of that partition.
+func newSlurmJob(name, namespace, batch, partition string) (*v1alpha1.SlurmJob, error) {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		return nil, errors.Errorf("invalid job name %q: %s", name, strings.Join(errs, ", "))
+	}
+	if strings.TrimSpace(batch) == "" {
+		return nil, errors.New("script is empty")
+	}
+
+	sj := &v1alpha1.SlurmJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "SlurmJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.SlurmJobSpec{
+			Batch: batch,
+		},
+	}
+	if partition != "" {
+		sj.Spec.NodeSelector = map[string]string{controller.PartitionLabel: partition}
+	}
+	return sj, nil
+}
+
+// jobName returns job name made of script file name, e.g.
+// my-job for /home/user/My_Job.sh. Empty name is returned when
+// file name has no suitable characters.
+func jobName(script string) string {
+	if script == "-" {
+		return ""
+	}
+	name := filepath.Base(script)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength], "-")
+	}
+	return name
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_jobName(t *testing.T) {
+	tt := []struct {
+		script string
+		expect string
+	}{
+		{script: "cow.sh", expect: "cow"},
+		{script: "/home/user/My_Job.v2.sh", expect: "my-job-v2"},
+		{script: "__job__", expect: "job"},
+		{script: "_.sh", expect: ""},
+		{script: "-", expect: ""},
+		{
+			script: "a-very-long-script-name-that-does-not-fit-into-kubernetes-label-limit.sh",
+			expect: "a-very-long-script-name-that-does-not-fit-into-kubernetes-label",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.script, func(t *testing.T) {
+			require.Equal(t, tc.expect, jobName(tc.script))
+		})
+	}
+}
+
+func Test_newSlurmJob(t *testing.T) {
+	sj, err := newSlurmJob("cow", "test", "#!/bin/sh\nsrun hostname\n", "debug")
+	require.NoError(t, err)
+	require.Equal(t, "wlm.sylabs.io/v1alpha1", sj.APIVersion)
+	require.Equal(t, "SlurmJob", sj.Kind)
+	require.Equal(t, "cow", sj.Name)
+	require.Equal(t, "test", sj.Namespace)
+	require.Equal(t, "#!/bin/sh\nsrun hostname\n", sj.Spec.Batch)
+	require.Equal(t, map[string]string{"wlm.sylabs.io/partition": "debug"}, sj.Spec.NodeSelector)
+
+	sj, err = newSlurmJob("cow", "test", "#!/bin/sh\nsrun hostname\n", "")
+	require.NoError(t, err)
+	require.Nil(t, sj.Spec.NodeSelector)
+
+	_, err = newSlurmJob("Cow", "test", "#!/bin/sh\nsrun hostname\n", "")
+	require.Error(t, err)
+
+	_, err = newSlurmJob("cow", "test", " \n", "")
+	require.EqualError(t, err, "script is empty")
+}
+
+func Test_runSubmit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubectl-slurm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "cow.sh")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\nsrun hostname\n"), 0644))
+
+	var out bytes.Buffer
+	e := &env{
+		namespace: "test",
+		wlm:       fake.NewSimpleClientset().WlmV1alpha1(),
+		out:       &out,
+	}
+	*submitPartition = "debug"
+	defer func() { *submitPartition = "" }()
+
+	require.NoError(t, runSubmit(context.Background(), e, []string{script}))
+	require.Equal(t, "slurmjob.wlm.sylabs.io/cow created\n", out.String())
+
+	sj, err := e.wlm.SlurmJobs("test").Get("cow", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/sh\nsrun hostname\n", sj.Spec.Batch)
+	require.Equal(t, "debug", sj.Spec.NodeSelector["wlm.sylabs.io/partition"])
+
+	require.Error(t, runSubmit(context.Background(), e, []string{script}), "job already exists")
+}
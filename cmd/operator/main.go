@@ -28,6 +28,7 @@ import (
 	"github.com/sylabs/wlm-operator/pkg/operator/apis"
 	"github.com/sylabs/wlm-operator/pkg/operator/controller/slurmjob"
 	"github.com/sylabs/wlm-operator/pkg/operator/controller/wlmjob"
+	"github.com/sylabs/wlm-operator/pkg/operator/webhook"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -40,6 +41,9 @@ var (
 
 	metricsHost       = "0.0.0.0"
 	metricsPort int32 = 8383
+
+	webhookAddr    = flag.String("webhook-addr", ":9443", "address to serve admission webhooks on")
+	webhookCertDir = flag.String("webhook-cert-dir", "", "directory with tls.crt and tls.key for admission webhooks, webhooks are disabled if empty")
 )
 
 func printVersion() {
@@ -103,6 +107,14 @@ func main() {
 		glog.Fatalf("Failed to add wlm job controller to manager: %v", err)
 	}
 
+	if *webhookCertDir != "" {
+		// certificate secret is optional in deployment, so keep
+		// running without webhooks rather than failing
+		if err := webhook.AddToManager(mgr, *webhookAddr, *webhookCertDir); err != nil {
+			glog.Errorf("Admission webhooks are disabled: %v", err)
+		}
+	}
+
 	// Create Service object to expose the metrics port.
 	_, err = metrics.ExposeMetricsPort(ctx, metricsPort)
 	if err != nil {
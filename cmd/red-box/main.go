@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -23,14 +24,24 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	sgrpc "github.com/sylabs/wlm-operator/internal/red-box/api"
+	"github.com/sylabs/wlm-operator/internal/red-box/audit"
+	"github.com/sylabs/wlm-operator/internal/red-box/health"
+	"github.com/sylabs/wlm-operator/internal/red-box/metrics"
+	"github.com/sylabs/wlm-operator/internal/red-box/middleware"
 	"github.com/sylabs/wlm-operator/pkg/slurm"
 	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"github.com/sylabs/wlm-operator/pkg/workload/redbox"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"gopkg.in/yaml.v2"
 )
 
@@ -41,6 +52,12 @@ func main() {
 
 	configPath := flag.String("config", "", "path to a red-box config")
 	sock := flag.String("socket", "/var/run/syslurm/red-box.sock", "unix socket to serve slurm API")
+	listen := flag.String("listen", "", "TCP address to serve slurm API with TLS on in addition to unix socket, e.g. :8765")
+	tlsCert := flag.String("tls-cert", "", "server certificate file, required with --listen")
+	tlsKey := flag.String("tls-key", "", "server private key file, required with --listen")
+	clientCA := flag.String("client-ca", "", "CA certificate file client certificates are verified with, required with --listen")
+	metricsAddr := flag.String("metrics-addr", "127.0.0.1:9101", "address to serve prometheus metrics on, empty to disable")
+	healthInterval := flag.Duration("health-interval", 10*time.Second, "how often slurm controller availability is checked")
 	flag.Parse()
 
 	config, err := config(*configPath)
@@ -49,19 +66,62 @@ func main() {
 	}
 	spew.Dump(config)
 
+	al, err := audit.NewLogger(config.Audit)
+	if err != nil {
+		log.Fatalf("Could not create audit logger: %v", err)
+	}
+	defer al.Close()
+
 	ln, err := net.Listen("unix", *sock)
 	if err != nil {
 		log.Fatalf("Could not listen unix: %v", err)
 	}
+	ln = audit.Listener(ln)
 
-	c, err := slurm.NewClient()
+	c, err := slurm.NewClient(slurm.WithExecHook(metrics.ObserveExec))
 	if err != nil {
 		log.Fatalf("Could not create slurm client: %s", err)
 	}
 
-	s := grpc.NewServer()
-	a := sgrpc.NewSlurm(c, config)
-	api.RegisterWorkloadManagerServer(s, a)
+	a := sgrpc.NewSlurm(c, config, al)
+	hs := grpchealth.NewServer()
+	s := newServer(a, hs)
+
+	// TCP connections are served by a separate server, since TLS
+	// credentials are set per server and unix socket is served without them
+	var tcpServer *grpc.Server
+	var tcpLn net.Listener
+	if *listen != "" {
+		tlsConfig, err := redbox.ServerTLSConfig(*tlsCert, *tlsKey, *clientCA)
+		if err != nil {
+			log.Fatalf("Could not configure TLS: %v", err)
+		}
+		tcpLn, err = net.Listen("tcp", *listen)
+		if err != nil {
+			log.Fatalf("Could not listen tcp: %v", err)
+		}
+		tcpServer = newServer(a, hs, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go health.NewChecker(c, hs, *healthInterval).Run(ctx)
+	go a.Run(ctx)
+
+	if *metricsAddr != "" {
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(prometheus.NewGoCollector())
+		metrics.MustRegister(reg)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler(reg))
+		go func() {
+			log.Printf("Serving metrics on %s", *metricsAddr)
+			if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+				log.Printf("Could not serve metrics: %v", err)
+			}
+		}()
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -71,9 +131,23 @@ func main() {
 		sig := make(chan os.Signal, 1)
 		signal.Notify(sig, unix.SIGINT, unix.SIGTERM, unix.SIGQUIT)
 		log.Printf("Shutting down due to %v", <-sig)
+		cancel()
+		hs.Shutdown()
+		if tcpServer != nil {
+			tcpServer.GracefulStop()
+		}
 		s.GracefulStop()
 	}()
 
+	if tcpServer != nil {
+		go func() {
+			log.Printf("Starting TLS server on %s", tcpLn.Addr())
+			if err := tcpServer.Serve(tcpLn); err != nil {
+				log.Fatalf("Could not serve TLS requests: %v", err)
+			}
+		}()
+	}
+
 	log.Printf("Starting server on %s", ln.Addr())
 	if err := s.Serve(ln); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Could not serve requests: %v", err)
@@ -81,17 +155,24 @@ func main() {
 	wg.Wait()
 }
 
+// newServer returns gRPC server serving slurm API and health service.
+func newServer(a api.WorkloadManagerServer, hs healthpb.HealthServer, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append(middleware.ServerOptions(), opts...)...)
+	api.RegisterWorkloadManagerServer(s, a)
+	healthpb.RegisterHealthServer(s, hs)
+	return s
+}
+
 func config(path string) (sgrpc.Config, error) {
 	if path == "" {
-		// sgrpc.Config is a map under the hood, and the default config is empty.
-		// Here we return default value for map - nil. This will make any further
+		// The default config is empty. Partitions map is nil, this will make any further
 		// read successful, and fetched values will be empty PartitionResources.
-		return sgrpc.Config(nil), nil
+		return sgrpc.Config{}, nil
 	}
 
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not open config file")
+		return sgrpc.Config{}, errors.Wrapf(err, "could not open config file")
 	}
 	defer file.Close()
 
@@ -0,0 +1,337 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command crdversions merges CRDs generated by controller-gen for each API version
+// into a single multi-version CRD, since controller-gen produces a separate manifest
+// per version. Merged CRD uses webhook conversion served by the operator.
+//
+// Webhook conversion requires structural schemas that controller-gen does not produce,
+// so merged schemas are completed using API types: quantities are marked as int-or-string,
+// maps get schema of their values and objects with no schema preserve unknown fields.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// caBundleComment is added to conversion webhook client config, as in deploy/webhook.yaml.
+const caBundleComment = "# base64 encoded CA certificate that signed wlm-operator-webhook-tls secret"
+
+var (
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	objectMetaType  = reflect.TypeOf(metav1.ObjectMeta{})
+)
+
+func main() {
+	dir := flag.String("dir", "deploy/crds", "directory with generated CRDs")
+	storage := flag.String("storage", "v1alpha1", "storage version of merged CRDs")
+	service := flag.String("service", "wlm-operator-webhook", "name of the service that serves conversion webhook")
+	namespace := flag.String("namespace", "default", "namespace of the service that serves conversion webhook")
+	path := flag.String("path", "/convert", "path conversion webhook is served on")
+	flag.Parse()
+
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		log.Fatalf("Could not register API types: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*_*_*.yaml"))
+	if err != nil {
+		log.Fatalf("Could not list CRDs: %v", err)
+	}
+
+	byName := make(map[string][]string)
+	crds := make(map[string]*apiextv1beta1.CustomResourceDefinition)
+	for _, f := range files {
+		crd, err := readCRD(f)
+		if err != nil {
+			log.Fatalf("Could not read CRD: %v", err)
+		}
+		byName[crd.Name] = append(byName[crd.Name], f)
+		crds[f] = crd
+	}
+
+	for name, files := range byName {
+		if len(files) < 2 {
+			continue
+		}
+		versions := make([]*apiextv1beta1.CustomResourceDefinition, len(files))
+		for i, f := range files {
+			versions[i] = crds[f]
+		}
+
+		merged, err := merge(versions, *storage)
+		if err != nil {
+			log.Fatalf("Could not merge %s: %v", name, err)
+		}
+		merged.Spec.Conversion = &apiextv1beta1.CustomResourceConversion{
+			Strategy: apiextv1beta1.WebhookConverter,
+			WebhookClientConfig: &apiextv1beta1.WebhookClientConfig{
+				Service: &apiextv1beta1.ServiceReference{
+					Namespace: *namespace,
+					Name:      *service,
+					Path:      path,
+				},
+			},
+		}
+
+		obj, err := structural(merged, scheme)
+		if err != nil {
+			log.Fatalf("Could not complete %s schemas: %v", name, err)
+		}
+
+		out := filepath.Join(*dir, fmt.Sprintf("%s_%s.yaml",
+			strings.SplitN(merged.Spec.Group, ".", 2)[0], strings.ToLower(merged.Spec.Names.Kind)))
+		if err := writeCRD(out, obj); err != nil {
+			log.Fatalf("Could not write CRD: %v", err)
+		}
+		for _, f := range files {
+			if err := os.Remove(f); err != nil {
+				log.Fatalf("Could not remove merged CRD: %v", err)
+			}
+		}
+	}
+}
+
+// merge merges single version CRDs into a multi-version one. Schemas, subresources
+// and printer columns are set per version unless they are identical in all versions.
+func merge(crds []*apiextv1beta1.CustomResourceDefinition, storage string) (*apiextv1beta1.CustomResourceDefinition, error) {
+	sort.Slice(crds, func(i, j int) bool {
+		return crds[i].Spec.Version < crds[j].Spec.Version
+	})
+
+	var merged *apiextv1beta1.CustomResourceDefinition
+	for _, crd := range crds {
+		if crd.Spec.Version == storage {
+			merged = crd.DeepCopy()
+		}
+	}
+	if merged == nil {
+		return nil, errors.Errorf("storage version %s is not found", storage)
+	}
+
+	merged.Spec.Version = ""
+	merged.Spec.Validation = nil
+	merged.Spec.Subresources = nil
+	merged.Spec.AdditionalPrinterColumns = nil
+	for _, crd := range crds {
+		// structural schemas require type of the root object
+		if v := crd.Spec.Validation; v != nil && v.OpenAPIV3Schema != nil && v.OpenAPIV3Schema.Type == "" {
+			v.OpenAPIV3Schema.Type = "object"
+		}
+		merged.Spec.Versions = append(merged.Spec.Versions, apiextv1beta1.CustomResourceDefinitionVersion{
+			Name:                     crd.Spec.Version,
+			Served:                   true,
+			Storage:                  crd.Spec.Version == storage,
+			Schema:                   crd.Spec.Validation,
+			Subresources:             crd.Spec.Subresources,
+			AdditionalPrinterColumns: crd.Spec.AdditionalPrinterColumns,
+		})
+	}
+
+	versions := merged.Spec.Versions
+	if allEqual(len(versions), func(i int) interface{} { return versions[i].Schema }) {
+		merged.Spec.Validation = versions[0].Schema
+		for i := range versions {
+			versions[i].Schema = nil
+		}
+	}
+	if allEqual(len(versions), func(i int) interface{} { return versions[i].Subresources }) {
+		merged.Spec.Subresources = versions[0].Subresources
+		for i := range versions {
+			versions[i].Subresources = nil
+		}
+	}
+	if allEqual(len(versions), func(i int) interface{} { return versions[i].AdditionalPrinterColumns }) {
+		merged.Spec.AdditionalPrinterColumns = versions[0].AdditionalPrinterColumns
+		for i := range versions {
+			versions[i].AdditionalPrinterColumns = nil
+		}
+	}
+	return merged, nil
+}
+
+// structural returns merged CRD with structural schemas of all versions that disables
+// preserving unknown fields. Conversion webhook config gets an empty CA bundle to fill in.
+func structural(crd *apiextv1beta1.CustomResourceDefinition, scheme *runtime.Scheme) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	spec := obj["spec"].(map[string]interface{})
+	spec["preserveUnknownFields"] = false
+	conversion := spec["conversion"].(map[string]interface{})
+	conversion["webhookClientConfig"].(map[string]interface{})["caBundle"] = ""
+
+	for i, v := range crd.Spec.Versions {
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}
+		typed, err := scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		t := reflect.TypeOf(typed).Elem()
+
+		version := spec["versions"].([]interface{})[i].(map[string]interface{})
+		if s, ok := version["schema"].(map[string]interface{}); ok {
+			completeSchema(s["openAPIV3Schema"].(map[string]interface{}), t)
+		} else if i == 0 {
+			// schema is shared by all versions, so it is completed once
+			s := spec["validation"].(map[string]interface{})
+			completeSchema(s["openAPIV3Schema"].(map[string]interface{}), t)
+		}
+	}
+	return obj, nil
+}
+
+// completeSchema makes schema of the type structural.
+func completeSchema(s map[string]interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == quantityType || t == intOrStringType:
+		delete(s, "type")
+		s["anyOf"] = []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string"},
+		}
+		s["x-kubernetes-int-or-string"] = true
+		return
+	case t == objectMetaType:
+		// metadata schema is provided by API server
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if s["type"] != "object" {
+			// e.g. time and duration are strings
+			return
+		}
+		props, ok := s["properties"].(map[string]interface{})
+		if !ok {
+			s["x-kubernetes-preserve-unknown-fields"] = true
+			return
+		}
+		completeProperties(props, t)
+	case reflect.Map:
+		if _, ok := s["additionalProperties"]; !ok {
+			s["additionalProperties"] = valueSchema(t.Elem())
+		}
+		if v, ok := s["additionalProperties"].(map[string]interface{}); ok {
+			completeSchema(v, t.Elem())
+		}
+	case reflect.Slice:
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			completeSchema(items, t.Elem())
+		}
+	}
+}
+
+// completeProperties completes schemas of struct fields, fields of embedded
+// structs are properties of the same object.
+func completeProperties(props map[string]interface{}, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			if f.Type.Kind() == reflect.Struct && f.Type != objectMetaType {
+				completeProperties(props, f.Type)
+			}
+			continue
+		}
+		if p, ok := props[name].(map[string]interface{}); ok {
+			completeSchema(p, f.Type)
+		}
+	}
+}
+
+// valueSchema returns schema of map values controller-gen leaves out.
+func valueSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	}
+	return map[string]interface{}{"type": "object"}
+}
+
+func allEqual(n int, get func(int) interface{}) bool {
+	for i := 1; i < n; i++ {
+		if !reflect.DeepEqual(get(0), get(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func readCRD(path string) (*apiextv1beta1.CustomResourceDefinition, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var crd apiextv1beta1.CustomResourceDefinition
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", path)
+	}
+	return &crd, nil
+}
+
+func writeCRD(path string, crd map[string]interface{}) error {
+	data, err := yaml.Marshal(crd)
+	if err != nil {
+		return err
+	}
+
+	var out []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == `caBundle: ""` {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			out = append(out, indent+caBundleComment)
+		}
+		out = append(out, line)
+	}
+	return ioutil.WriteFile(path, []byte(strings.Join(out, "\n")), 0644)
+}
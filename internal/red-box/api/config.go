@@ -0,0 +1,157 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/internal/red-box/audit"
+)
+
+const defaultJobCacheRefreshInterval = 5 * time.Second
+
+type (
+	// Config is a red-box configuration.
+	Config struct {
+		// Partitions configure resources for each partition available.
+		Partitions map[string]PartitionResources `yaml:"partitions"`
+		// Audit configures audit log of WLM actions.
+		Audit audit.Config `yaml:"audit"`
+		// JobCache configures job info snapshot cache.
+		JobCache JobCacheConfig `yaml:"job_cache"`
+	}
+
+	// JobCacheConfig configures how often job info snapshot is refreshed.
+	JobCacheConfig struct {
+		// RefreshInterval is an interval between snapshot refreshes. When not set
+		// defaultJobCacheRefreshInterval is used, negative value disables the cache.
+		RefreshInterval time.Duration `yaml:"refresh_interval"`
+	}
+
+	// PartitionResources configure how red-box will see slurm partition resources.
+	// In auto mode red-box will attempt to query partition resources from slurm, but
+	// administrator can set up them manually. Batch scripts of jobs submitted to the
+	// partition may be customized with a script template.
+	PartitionResources struct {
+		AutoNodes      bool `yaml:"auto_nodes"`
+		AutoCPUPerNode bool `yaml:"auto_cpu_per_node"`
+		AutoMemPerNode bool `yaml:"auto_mem_per_node"`
+		AutoWallTime   bool `yaml:"auto_wall_time"`
+
+		Nodes      int64         `yaml:"nodes"`
+		CPUPerNode int64         `yaml:"cpu_per_node"`
+		MemPerNode int64         `yaml:"mem_per_node"`
+		WallTime   time.Duration `yaml:"wall_time"`
+
+		AdditionalFeatures []Feature `yaml:"additional_features"`
+
+		ScriptTemplate *ScriptTemplate `yaml:"script_template"`
+	}
+
+	// ScriptTemplate is a text/template that renders container job batch script,
+	// e.g. to load modules or prepare scratch directory before the job commands.
+	// Template is executed with scriptData, quote function shell-quotes a string.
+	ScriptTemplate struct {
+		*template.Template
+	}
+
+	// Feature represents slurm partition feature.
+	Feature struct {
+		Name     string `yaml:"name"`
+		Version  string `yaml:"version"`
+		Quantity int64  `yaml:"quantity"`
+	}
+)
+
+// UnmarshalYAML implements yaml.Unmarshaler. Template is parsed
+// once config is read, so that template errors are reported on start.
+func (t *ScriptTemplate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(text, "#!") {
+		return errors.New("script template must start with interpreter line, e.g. #!/bin/sh")
+	}
+
+	tmpl, err := template.New("script").Funcs(template.FuncMap{"quote": shellQuote}).Parse(text)
+	if err != nil {
+		return errors.Wrap(err, "could not parse script template")
+	}
+	t.Template = tmpl
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Previously config was a plain map
+// of partition resources, such configs are still accepted for backward compatibility.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	if isLegacyConfig(raw) {
+		c.Partitions = nil
+		return unmarshal(&c.Partitions)
+	}
+
+	type config Config // prevents recursion
+	return unmarshal((*config)(c))
+}
+
+// isLegacyConfig checks whether top level values of the config are partition resources.
+// Format is detected from values rather than keys, since legacy config may have
+// partitions named as config sections, e.g. audit. Values of partition resources are
+// never maps, while partitions section is a map of partition resources and other sections
+// have keys that are not partition resources fields. Empty values are ambiguous, they are
+// treated as config sections when keyed by a section name.
+func isLegacyConfig(raw map[string]interface{}) bool {
+	for k, v := range raw {
+		fields, ok := v.(map[interface{}]interface{})
+		if !ok || len(fields) == 0 {
+			if v != nil && !ok {
+				return false
+			}
+			switch k {
+			case "partitions", "audit", "job_cache":
+				return false
+			}
+			continue
+		}
+		for name, value := range fields {
+			if _, isMap := value.(map[interface{}]interface{}); isMap {
+				return false
+			}
+			if n, _ := name.(string); !partitionFields[n] {
+				return false
+			}
+		}
+	}
+	return len(raw) != 0
+}
+
+// partitionFields holds yaml names of PartitionResources fields.
+var partitionFields = func() map[string]bool {
+	t := reflect.TypeOf(PartitionResources{})
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		fields[t.Field(i).Tag.Get("yaml")] = true
+	}
+	return fields
+}()
@@ -0,0 +1,115 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/internal/red-box/audit"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConfig_UnmarshalYAML(t *testing.T) {
+	tt := []struct {
+		name   string
+		in     string
+		expect Config
+	}{
+		{
+			name: "legacy",
+			in: `
+debug:
+  nodes: 10
+  wall_time: 10h
+`,
+			expect: Config{
+				Partitions: map[string]PartitionResources{
+					"debug": {Nodes: 10, WallTime: 10 * time.Hour},
+				},
+			},
+		},
+		{
+			name: "full",
+			in: `
+partitions:
+  debug:
+    nodes: 10
+audit:
+  path: /var/log/red-box/audit.log
+  max_size_mb: 100
+  max_backups: 3
+`,
+			expect: Config{
+				Partitions: map[string]PartitionResources{
+					"debug": {Nodes: 10},
+				},
+				Audit: audit.Config{
+					Path:       "/var/log/red-box/audit.log",
+					MaxSizeMB:  100,
+					MaxBackups: 3,
+				},
+			},
+		},
+		{
+			name: "legacy partitions named as sections",
+			in: `
+audit:
+  nodes: 2
+partitions:
+  auto_nodes: true
+  additional_features:
+  - name: gpu
+    quantity: 2
+`,
+			expect: Config{
+				Partitions: map[string]PartitionResources{
+					"audit":      {Nodes: 2},
+					"partitions": {AutoNodes: true, AdditionalFeatures: []Feature{{Name: "gpu", Quantity: 2}}},
+				},
+			},
+		},
+		{
+			name: "partitions named as fields",
+			in: `
+partitions:
+  nodes:
+    nodes: 4
+job_cache:
+  refresh_interval: 10s
+`,
+			expect: Config{
+				Partitions: map[string]PartitionResources{
+					"nodes": {Nodes: 4},
+				},
+				JobCache: JobCacheConfig{RefreshInterval: 10 * time.Second},
+			},
+		},
+		{
+			name:   "empty",
+			in:     `{}`,
+			expect: Config{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Config
+			require.NoError(t, yaml.Unmarshal([]byte(tc.in), &c))
+			require.Equal(t, tc.expect, c)
+		})
+	}
+}
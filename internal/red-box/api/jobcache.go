@@ -0,0 +1,247 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sylabs/wlm-operator/internal/red-box/metrics"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+)
+
+// jobInfoSource provides information about slurm jobs.
+type jobInfoSource interface {
+	SJobInfo(jobID int64) ([]*slurm.JobInfo, error)
+	SJobsInfo() ([]*slurm.JobInfo, error)
+	SQueue() ([]*slurm.JobInfo, error)
+}
+
+// maxStaleRefreshes is a number of refresh intervals after which
+// snapshot is considered stale and is not served anymore.
+const maxStaleRefreshes = 3
+
+// jobCache keeps a periodically refreshed snapshot of all jobs submitted
+// via red-box, so that job info requests don't spawn a slurm process each.
+// On cache miss job info is queried directly, as well as when snapshot
+// could not be refreshed for a while, so that job states don't freeze.
+// Active jobs list is kept as well while jobs are being listed.
+type jobCache struct {
+	src      jobInfoSource
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	tracked   map[int64]struct{}
+	snapshot  map[int64][]*slurm.JobInfo
+	refreshed time.Time
+
+	// active is the latest squeue output, it is refreshed along with
+	// snapshot till listed is older than maxStaleRefreshes intervals.
+	active          []*slurm.JobInfo
+	activeRefreshed time.Time
+	listed          time.Time
+	// queryMu serializes direct squeue calls, so that concurrent
+	// callers share a single one.
+	queryMu sync.Mutex
+}
+
+func newJobCache(src jobInfoSource, interval time.Duration) *jobCache {
+	return &jobCache{
+		src:      src,
+		interval: interval,
+		now:      time.Now,
+		tracked:  make(map[int64]struct{}),
+		snapshot: make(map[int64][]*slurm.JobInfo),
+	}
+}
+
+// run refreshes snapshot every interval till ctx is done.
+func (c *jobCache) run(ctx context.Context) {
+	t := time.NewTicker(c.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.refresh(); err != nil {
+				log.Printf("Could not refresh job info snapshot: %v", err)
+			}
+		}
+	}
+}
+
+// track adds job to the set of jobs kept in the snapshot.
+func (c *jobCache) track(jobID int64) {
+	c.mu.Lock()
+	c.tracked[jobID] = struct{}{}
+	c.mu.Unlock()
+}
+
+// jobInfo returns job info from the snapshot. In case job is not in the snapshot
+// yet or snapshot is stale, job is queried directly and tracked afterwards.
+func (c *jobCache) jobInfo(jobID int64) ([]*slurm.JobInfo, error) {
+	c.mu.RLock()
+	info, ok := c.snapshot[jobID]
+	fresh := c.now().Sub(c.refreshed) <= maxStaleRefreshes*c.interval
+	c.mu.RUnlock()
+	if ok && fresh {
+		return info, nil
+	}
+
+	info, err := c.src.SJobInfo(jobID)
+	if err != nil {
+		return nil, err
+	}
+	c.track(jobID)
+	return info, nil
+}
+
+// activeJobs returns active jobs from the latest squeue output. In case it is
+// missing or stale squeue is called directly and its output is kept up to date
+// by the following refreshes.
+func (c *jobCache) activeJobs() ([]*slurm.JobInfo, error) {
+	if active, ok := c.freshActive(); ok {
+		return active, nil
+	}
+
+	c.queryMu.Lock()
+	defer c.queryMu.Unlock()
+	// other caller may have already queried squeue while we were waiting
+	if active, ok := c.freshActive(); ok {
+		return active, nil
+	}
+	return c.queryActive(c.now())
+}
+
+// freshActive returns the latest squeue output if it is not stale
+// and marks active jobs as listed.
+func (c *jobCache) freshActive() ([]*slurm.JobInfo, bool) {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listed = now
+	if c.active == nil || now.Sub(c.activeRefreshed) > maxStaleRefreshes*c.interval {
+		return nil, false
+	}
+	return c.active, true
+}
+
+// refresh fetches info about all jobs with a single slurm call and
+// replaces snapshot with the tracked ones. Jobs that are no longer known
+// to slurm are not tracked anymore. Job state metrics are updated as well,
+// so that jobs nobody asks about are still accounted. Active jobs list is
+// refreshed too in case jobs were listed recently.
+func (c *jobCache) refresh() error {
+	now := c.now()
+	c.mu.RLock()
+	n := len(c.tracked)
+	listing := now.Sub(c.listed) <= maxStaleRefreshes*c.interval
+	c.mu.RUnlock()
+
+	var activeErr error
+	if listing {
+		activeErr = c.refreshActive(now)
+	}
+	if err := c.refreshSnapshot(now, n); err != nil {
+		return err
+	}
+	return activeErr
+}
+
+// refreshActive replaces active jobs list with the current squeue output.
+func (c *jobCache) refreshActive(now time.Time) error {
+	c.queryMu.Lock()
+	defer c.queryMu.Unlock()
+
+	_, err := c.queryActive(now)
+	return err
+}
+
+// queryActive calls squeue and keeps its output, queryMu must be held.
+func (c *jobCache) queryActive(now time.Time) ([]*slurm.JobInfo, error) {
+	active, err := c.src.SQueue()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.active = active
+	c.activeRefreshed = now
+	c.mu.Unlock()
+	return active, nil
+}
+
+// refreshSnapshot replaces snapshot of n tracked jobs with the current scontrol output.
+func (c *jobCache) refreshSnapshot(now time.Time, n int) error {
+	if n == 0 {
+		c.mu.Lock()
+		c.refreshed = now
+		c.mu.Unlock()
+		return nil
+	}
+
+	infos, err := c.src.SJobsInfo()
+	if err != nil {
+		return err
+	}
+	all := indexJobInfo(infos)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[int64][]*slurm.JobInfo, len(c.tracked))
+	for id := range c.tracked {
+		info, ok := all[id]
+		if !ok {
+			delete(c.tracked, id)
+			metrics.Jobs.Forget(id)
+			continue
+		}
+		snapshot[id] = info
+		metrics.Jobs.Observe(id, info[0].State)
+	}
+	c.snapshot = snapshot
+	c.refreshed = now
+	return nil
+}
+
+// indexJobInfo groups job info by job id. Job array tasks are also grouped
+// under the array job id with the array root going first, as 'scontrol show jobid' does.
+func indexJobInfo(infos []*slurm.JobInfo) map[int64][]*slurm.JobInfo {
+	index := make(map[int64][]*slurm.JobInfo)
+	for _, info := range infos {
+		id, err := strconv.ParseInt(info.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		index[id] = append([]*slurm.JobInfo{info}, index[id]...)
+
+		if info.ArrayJobID == "" || info.ArrayJobID == info.ID {
+			continue
+		}
+		arrayID, err := strconv.ParseInt(info.ArrayJobID, 10, 64)
+		if err != nil {
+			continue
+		}
+		index[arrayID] = append(index[arrayID], info)
+	}
+	return index
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+)
+
+// fakeJobSource imitates slurm and counts process spawns.
+type fakeJobSource struct {
+	mu     sync.Mutex
+	jobs   map[int64]*slurm.JobInfo
+	spawns int
+	fail   bool
+}
+
+func newFakeJobSource(n int) *fakeJobSource {
+	src := &fakeJobSource{jobs: make(map[int64]*slurm.JobInfo)}
+	for i := 1; i <= n; i++ {
+		src.jobs[int64(i)] = &slurm.JobInfo{ID: strconv.Itoa(i), State: "RUNNING"}
+	}
+	return src
+}
+
+func (s *fakeJobSource) SJobInfo(jobID int64) ([]*slurm.JobInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spawns++
+	if s.fail {
+		return nil, errors.New("slurm_load_jobs error: Unable to contact slurm controller")
+	}
+	info, ok := s.jobs[jobID]
+	if !ok {
+		return nil, errors.New("invalid job id specified")
+	}
+	return []*slurm.JobInfo{info}, nil
+}
+
+func (s *fakeJobSource) SJobsInfo() ([]*slurm.JobInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spawns++
+	if s.fail {
+		return nil, errors.New("slurm_load_jobs error: Unable to contact slurm controller")
+	}
+	infos := make([]*slurm.JobInfo, 0, len(s.jobs))
+	for _, info := range s.jobs {
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (s *fakeJobSource) SQueue() ([]*slurm.JobInfo, error) {
+	return s.SJobsInfo()
+}
+
+func TestJobCache(t *testing.T) {
+	src := newFakeJobSource(2)
+	c := newJobCache(src, time.Second)
+
+	c.track(1)
+	require.NoError(t, c.refresh())
+	require.Equal(t, 1, src.spawns)
+
+	info, err := c.jobInfo(1)
+	require.NoError(t, err)
+	require.Equal(t, []*slurm.JobInfo{src.jobs[1]}, info)
+	require.Equal(t, 1, src.spawns, "cached job info should not be queried")
+
+	// not tracked job is queried directly and cached after next refresh
+	info, err = c.jobInfo(2)
+	require.NoError(t, err)
+	require.Equal(t, []*slurm.JobInfo{src.jobs[2]}, info)
+	require.Equal(t, 2, src.spawns)
+
+	require.NoError(t, c.refresh())
+	_, err = c.jobInfo(2)
+	require.NoError(t, err)
+	require.Equal(t, 3, src.spawns)
+
+	// job purged from slurm is no longer tracked
+	delete(src.jobs, 1)
+	require.NoError(t, c.refresh())
+	require.NotContains(t, c.tracked, int64(1))
+	_, err = c.jobInfo(1)
+	require.Error(t, err)
+}
+
+func TestJobCache_refreshFails(t *testing.T) {
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := newFakeJobSource(1)
+	c := newJobCache(src, time.Second)
+	c.now = func() time.Time { return now }
+
+	c.track(1)
+	require.NoError(t, c.refresh())
+	require.Equal(t, 1, src.spawns)
+
+	// slurm fails for a while, snapshot is served till it's stale
+	src.fail = true
+	src.jobs[1] = &slurm.JobInfo{ID: "1", State: "COMPLETED"}
+	for i := 0; i < maxStaleRefreshes; i++ {
+		now = now.Add(time.Second)
+		require.Error(t, c.refresh())
+		info, err := c.jobInfo(1)
+		require.NoError(t, err)
+		require.Equal(t, "RUNNING", info[0].State)
+	}
+	require.Equal(t, 1+maxStaleRefreshes, src.spawns)
+
+	// stale snapshot is not served, errors are reported
+	now = now.Add(time.Second)
+	require.Error(t, c.refresh())
+	_, err := c.jobInfo(1)
+	require.Error(t, err)
+
+	// once slurm recovers actual state is returned even before next refresh
+	src.fail = false
+	info, err := c.jobInfo(1)
+	require.NoError(t, err)
+	require.Equal(t, "COMPLETED", info[0].State)
+
+	require.NoError(t, c.refresh())
+	spawns := src.spawns
+	info, err = c.jobInfo(1)
+	require.NoError(t, err)
+	require.Equal(t, "COMPLETED", info[0].State)
+	require.Equal(t, spawns, src.spawns, "fresh snapshot should be served")
+}
+
+func TestJobCache_load(t *testing.T) {
+	const (
+		jobs    = 500
+		callers = 300
+		rounds  = 5
+	)
+
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := newFakeJobSource(jobs)
+	c := newJobCache(src, time.Second)
+	c.now = func() time.Time { return now }
+
+	// jobs are tracked on submit, first listing queries squeue directly
+	for i := 1; i <= jobs; i++ {
+		c.track(int64(i))
+	}
+	_, err := c.activeJobs()
+	require.NoError(t, err)
+	require.NoError(t, c.refresh())
+	require.Equal(t, 3, src.spawns)
+
+	for r := 0; r < rounds; r++ {
+		now = now.Add(c.interval)
+		spawns := src.spawns
+
+		var wg sync.WaitGroup
+		errs := make(chan error, callers+1)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%2 == 0 {
+					_, err := c.activeJobs()
+					errs <- err
+					return
+				}
+				for j := i; j < i+jobs; j += callers {
+					if _, err := c.jobInfo(int64(j%jobs + 1)); err != nil {
+						errs <- err
+						return
+					}
+				}
+				errs <- nil
+			}(i)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.refresh()
+		}()
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, src.spawns-spawns, "each refresh should spawn single scontrol and squeue")
+	}
+
+	// squeue is not called once jobs are not listed anymore
+	now = now.Add((maxStaleRefreshes + 1) * c.interval)
+	spawns := src.spawns
+	require.NoError(t, c.refresh())
+	require.Equal(t, 1, src.spawns-spawns)
+}
+
+func Test_indexJobInfo(t *testing.T) {
+	root := &slurm.JobInfo{ID: "10", ArrayJobID: "10"}
+	task1 := &slurm.JobInfo{ID: "11", ArrayJobID: "10"}
+	task2 := &slurm.JobInfo{ID: "12", ArrayJobID: "10"}
+	single := &slurm.JobInfo{ID: "20"}
+	invalid := &slurm.JobInfo{ID: "foo"}
+
+	index := indexJobInfo([]*slurm.JobInfo{task1, root, task2, single, invalid})
+	require.Equal(t, map[int64][]*slurm.JobInfo{
+		10: {root, task1, task2},
+		11: {task1},
+		12: {task2},
+		20: {single},
+	}, index)
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/internal/red-box/audit"
+	"github.com/sylabs/wlm-operator/internal/red-box/metrics"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/tail"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+const (
+	// jobLogsPollInterval is how often job state is checked while following job logs.
+	jobLogsPollInterval = 2 * time.Second
+	// jobLogsMaxLookupFailures is how many consecutive failed job lookups make
+	// job considered finished while following job logs.
+	jobLogsMaxLookupFailures = 5
+	// logsChunkSize is a maximum size of a single job logs chunk.
+	logsChunkSize = 32 << 10
+)
+
+// logSource is a job output file along with the stream it belongs to.
+type logSource struct {
+	stream api.LogStream
+	path   string
+}
+
+// JobLogs streams job standard output and error files. Paths are resolved from
+// 'scontrol show jobid', or from accounting if job is no longer known to controller.
+// With follow set, files are streamed until job reaches a terminal state.
+func (s *Slurm) JobLogs(req *api.JobLogsRequest, stream api.WorkloadManager_JobLogsServer) error {
+	info, err := s.logsJobInfo(req.JobId)
+	var sources []logSource
+	if err == nil {
+		sources, err = logSources(info, req.Stream)
+	}
+	paths := make([]string, len(sources))
+	for i, src := range sources {
+		paths[i] = src.path
+	}
+	s.audit.Log(stream.Context(), audit.Record{RPC: "JobLogs", JobID: req.JobId, Path: strings.Join(paths, ",")}, err)
+	if err != nil {
+		return errors.Wrapf(err, "could not get job %d logs", req.JobId)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var finished chan struct{}
+	if req.Follow && !metrics.IsTerminalState(info.State) {
+		finished = make(chan struct{})
+		go s.waitJobFinished(ctx, req.JobId, finished)
+	}
+
+	chunks := make(chan *api.JobLogsChunk)
+	errs := make(chan error, len(sources))
+	for _, src := range sources {
+		go func(src logSource) {
+			errs <- s.streamLog(ctx, src, req, finished, chunks)
+		}(src)
+	}
+
+	for running := len(sources); running > 0; {
+		select {
+		case chunk := <-chunks:
+			if err := stream.Send(chunk); err != nil {
+				return errors.Wrap(err, "could not send chunk")
+			}
+		case err := <-errs:
+			if err != nil {
+				return err
+			}
+			running--
+		}
+	}
+	return nil
+}
+
+// logsJobInfo returns job info needed to resolve output paths.
+func (s *Slurm) logsJobInfo(jobID int64) (*slurm.JobInfo, error) {
+	infos, err := s.jobInfo(jobID)
+	if err == nil && len(infos) != 0 {
+		return infos[0], nil
+	}
+
+	info, acctErr := s.client.SAcctJobInfo(jobID)
+	if acctErr != nil {
+		if err == nil {
+			err = acctErr
+		}
+		return nil, errors.Wrap(err, "could not get job info")
+	}
+	return info, nil
+}
+
+// waitJobFinished closes finished once job reaches a terminal state. Job that is
+// not found for jobLogsMaxLookupFailures polls in a row is treated as finished too,
+// since it is purged from controller and may never appear in accounting.
+func (s *Slurm) waitJobFinished(ctx context.Context, jobID int64, finished chan<- struct{}) {
+	t := time.NewTicker(jobLogsPollInterval)
+	defer t.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		info, err := s.logsJobInfo(jobID)
+		if err != nil {
+			failures++
+			log.Printf("Could not check job %d state: %v", jobID, err)
+		} else {
+			failures = 0
+		}
+		if failures >= jobLogsMaxLookupFailures || (err == nil && metrics.IsTerminalState(info.State)) {
+			close(finished)
+			return
+		}
+	}
+}
+
+// streamLog sends content of job output file as chunks. When finished is not nil,
+// file is followed until finished is closed, otherwise it is read once. Missing
+// file is treated as empty, since output appears only after job is started.
+func (s *Slurm) streamLog(ctx context.Context, src logSource, req *api.JobLogsRequest,
+	finished <-chan struct{}, chunks chan<- *api.JobLogsChunk) error {
+	offset, err := s.logOffset(src.path, req.SinceBytes, req.TailLines)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	var tr *tail.Subscriber
+	if finished == nil {
+		f, err := s.client.Open(src.path)
+		if err == slurm.ErrFileNotFound {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not open %s", src.path)
+		}
+		defer f.Close()
+		r = &skipReader{r: f, skip: offset}
+	} else {
+		tr, err = s.tails.Subscribe(src.path, offset)
+		if err != nil {
+			return errors.Wrapf(err, "could not tail %s", src.path)
+		}
+		defer tr.Close()
+		r = tr
+	}
+
+	buf := make([]byte, logsChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := &api.JobLogsChunk{Stream: src.stream, Content: append([]byte(nil), buf[:n]...)}
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", src.path)
+		}
+		if n > 0 {
+			continue
+		}
+		// file is read once when job is not followed
+		if tr == nil {
+			return nil
+		}
+
+		// tail reader returns no data until file grows,
+		// so wait for it or stop once job is finished
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-finished:
+			// read the rest of the file and get EOF
+			finished = nil
+			tr.StopAtEOF()
+		case <-tr.Ready():
+		}
+	}
+}
+
+// logOffset returns offset to start streaming file from. When tail lines
+// are requested, offset is moved to the start of last lines, but never
+// before since bytes.
+func (s *Slurm) logOffset(path string, sinceBytes, tailLines int64) (int64, error) {
+	if sinceBytes < 0 || tailLines < 0 {
+		return 0, errors.New("since bytes and tail lines must not be negative")
+	}
+	if tailLines == 0 {
+		return sinceBytes, nil
+	}
+
+	f, err := s.client.Open(path)
+	if err == slurm.ErrFileNotFound {
+		return sinceBytes, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not open %s", path)
+	}
+	defer f.Close()
+
+	ra, ok := f.(readerAtSeeker)
+	if !ok {
+		return sinceBytes, nil
+	}
+	size, err := ra.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not get %s size", path)
+	}
+	offset, err := lastLinesOffset(ra, size, tailLines)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not find last lines of %s", path)
+	}
+	if offset < sinceBytes {
+		offset = sinceBytes
+	}
+	return offset, nil
+}
+
+type readerAtSeeker interface {
+	io.ReaderAt
+	io.Seeker
+}
+
+// lastLinesOffset returns offset of the first of n last lines. Trailing
+// newline doesn't start a new line, as in tail utility.
+func lastLinesOffset(r io.ReaderAt, size, n int64) (int64, error) {
+	const blockSize = 4 << 10
+
+	buf := make([]byte, blockSize)
+	end := size
+	for end > 0 {
+		start := end - blockSize
+		if start < 0 {
+			start = 0
+		}
+		block := buf[:end-start]
+		if _, err := r.ReadAt(block, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := len(block) - 1; i >= 0; i-- {
+			pos := start + int64(i)
+			if block[i] != '\n' || pos == size-1 {
+				continue
+			}
+			n--
+			if n == 0 {
+				return pos + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// logSources returns output files of the requested streams. When standard
+// output and error go to the same file, it is returned once as standard output.
+func logSources(info *slurm.JobInfo, stream api.LogStream) ([]logSource, error) {
+	stdout, stderr := info.OutputPaths()
+	switch stream {
+	case api.LogStream_STDOUT:
+		return []logSource{{stream: api.LogStream_STDOUT, path: stdout}}, nil
+	case api.LogStream_STDERR:
+		return []logSource{{stream: api.LogStream_STDERR, path: stderr}}, nil
+	case api.LogStream_BOTH:
+		sources := []logSource{{stream: api.LogStream_STDOUT, path: stdout}}
+		if stderr != stdout {
+			sources = append(sources, logSource{stream: api.LogStream_STDERR, path: stderr})
+		}
+		return sources, nil
+	}
+	return nil, errors.Errorf("unknown log stream %s", stream)
+}
+
+// skipReader discards first skip bytes read from r.
+type skipReader struct {
+	r    io.Reader
+	skip int64
+}
+
+func (s *skipReader) Read(p []byte) (int, error) {
+	for s.skip > 0 {
+		buf := p
+		if int64(len(buf)) > s.skip {
+			buf = buf[:s.skip]
+		}
+		n, err := s.r.Read(buf)
+		s.skip -= int64(n)
+		if err != nil || n == 0 {
+			return 0, err
+		}
+	}
+	return s.r.Read(p)
+}
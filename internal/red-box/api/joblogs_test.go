@@ -0,0 +1,147 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+func Test_lastLinesOffset(t *testing.T) {
+	long := strings.Repeat("x", 5000)
+	tests := []struct {
+		name    string
+		content string
+		lines   int64
+		want    int64
+	}{
+		{name: "empty", content: "", lines: 2, want: 0},
+		{name: "fewer lines", content: "a\nb\n", lines: 5, want: 0},
+		{name: "trailing newline", content: "a\nb\nc\n", lines: 2, want: 2},
+		{name: "no trailing newline", content: "a\nb\nc", lines: 2, want: 2},
+		{name: "last line", content: "a\nb\nc\n", lines: 1, want: 4},
+		{name: "empty lines", content: "a\n\n\n", lines: 2, want: 2},
+		{name: "across blocks", content: "a\n" + long + "\n" + long + "\n", lines: 2, want: 2},
+		{name: "long last line", content: "a\n" + long + "\n" + long + "\n", lines: 1, want: 5003},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := lastLinesOffset(strings.NewReader(tt.content), int64(len(tt.content)), tt.lines)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, offset)
+		})
+	}
+}
+
+func Test_logSources(t *testing.T) {
+	separate := &slurm.JobInfo{ID: "53", WorkDir: "/home/vagrant", StdOut: "%j.out", StdErr: "%j.err"}
+	shared := &slurm.JobInfo{ID: "53", WorkDir: "/home/vagrant"}
+
+	tests := []struct {
+		name   string
+		info   *slurm.JobInfo
+		stream api.LogStream
+		want   []logSource
+	}{
+		{
+			name:   "stdout",
+			info:   separate,
+			stream: api.LogStream_STDOUT,
+			want:   []logSource{{stream: api.LogStream_STDOUT, path: "/home/vagrant/53.out"}},
+		},
+		{
+			name:   "stderr",
+			info:   separate,
+			stream: api.LogStream_STDERR,
+			want:   []logSource{{stream: api.LogStream_STDERR, path: "/home/vagrant/53.err"}},
+		},
+		{
+			name:   "both",
+			info:   separate,
+			stream: api.LogStream_BOTH,
+			want: []logSource{
+				{stream: api.LogStream_STDOUT, path: "/home/vagrant/53.out"},
+				{stream: api.LogStream_STDERR, path: "/home/vagrant/53.err"},
+			},
+		},
+		{
+			name:   "both in one file",
+			info:   shared,
+			stream: api.LogStream_BOTH,
+			want:   []logSource{{stream: api.LogStream_STDOUT, path: "/home/vagrant/slurm-53.out"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, err := logSources(tt.info, tt.stream)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, sources)
+		})
+	}
+}
+
+func TestSlurm_streamLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joblogs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "slurm-53.out")
+	require.NoError(t, ioutil.WriteFile(path, []byte("one\ntwo\nthree\n"), 0644))
+
+	tests := []struct {
+		name string
+		path string
+		req  *api.JobLogsRequest
+		want string
+	}{
+		{name: "whole file", path: path, req: &api.JobLogsRequest{}, want: "one\ntwo\nthree\n"},
+		{name: "since bytes", path: path, req: &api.JobLogsRequest{SinceBytes: 4}, want: "two\nthree\n"},
+		{name: "tail lines", path: path, req: &api.JobLogsRequest{TailLines: 1}, want: "three\n"},
+		{name: "since bytes after tail", path: path, req: &api.JobLogsRequest{SinceBytes: 10, TailLines: 2}, want: "ree\n"},
+		{name: "missing file", path: filepath.Join(dir, "missing"), req: &api.JobLogsRequest{}, want: ""},
+	}
+
+	s := &Slurm{client: &slurm.Client{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := make(chan *api.JobLogsChunk)
+			errs := make(chan error, 1)
+			src := logSource{stream: api.LogStream_STDERR, path: tt.path}
+			go func() {
+				errs <- s.streamLog(context.Background(), src, tt.req, nil, chunks)
+				close(chunks)
+			}()
+
+			var out bytes.Buffer
+			for chunk := range chunks {
+				require.Equal(t, api.LogStream_STDERR, chunk.Stream)
+				out.Write(chunk.Content)
+			}
+			require.NoError(t, <-errs)
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+const (
+	defaultListPageSize = 100
+	maxListPageSize     = 1000
+)
+
+// listedJob is a slurm job along with its parsed id.
+type listedJob struct {
+	id   int64
+	info *slurm.JobInfo
+}
+
+// ListJobs returns jobs matching the filter ordered by id. Active jobs are
+// taken from 'squeue', which output is cached along with job info snapshot,
+// and finished ones from 'sacct'.
+func (s *Slurm) ListJobs(_ context.Context, req *api.ListJobsRequest) (*api.ListJobsResponse, error) {
+	f := req.Filter
+	if f == nil {
+		f = &api.JobFilter{}
+	}
+
+	var after int64
+	if req.PageToken != "" {
+		var err error
+		after, err = strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid page token %q", req.PageToken)
+		}
+	}
+
+	var since time.Time
+	if f.SubmittedSince != nil {
+		var err error
+		since, err = ptypes.Timestamp(f.SubmittedSince)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid submitted since time")
+		}
+	}
+
+	active, err := s.activeJobs()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active jobs")
+	}
+	history, err := s.client.SAcctJobs(since)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finished jobs")
+	}
+
+	var matched []*slurm.JobInfo
+	for _, j := range mergeJobLists(active, history) {
+		if j.id > after && matchJob(f, since, j.info) {
+			matched = append(matched, j.info)
+		}
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize > maxListPageSize {
+		pageSize = maxListPageSize
+	}
+
+	var next string
+	if len(matched) > pageSize {
+		matched = matched[:pageSize]
+		next = matched[pageSize-1].ID
+	}
+
+	jobs, err := mapSInfoToProtoInfo(matched)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert slurm info into proto info")
+	}
+	return &api.ListJobsResponse{Jobs: jobs, NextPageToken: next}, nil
+}
+
+// mergeJobLists merges active and finished jobs ordered by id. Since jobs may
+// be present in both lists, active ones take precedence as more up to date.
+func mergeJobLists(active, history []*slurm.JobInfo) []listedJob {
+	seen := make(map[int64]bool, len(active)+len(history))
+	jobs := make([]listedJob, 0, len(active)+len(history))
+	for _, infos := range [][]*slurm.JobInfo{active, history} {
+		for _, info := range infos {
+			id, err := strconv.ParseInt(info.ID, 10, 64)
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			jobs = append(jobs, listedJob{id: id, info: info})
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].id < jobs[j].id
+	})
+	return jobs
+}
+
+// matchJob checks whether job matches the filter.
+func matchJob(f *api.JobFilter, since time.Time, info *slurm.JobInfo) bool {
+	if len(f.States) != 0 && !containsStatus(f.States, toProtoStatus(info.State)) {
+		return false
+	}
+	if len(f.Partitions) != 0 && !containsString(f.Partitions, info.Partition) {
+		return false
+	}
+	if len(f.Users) != 0 && !containsString(f.Users, info.UserID) {
+		return false
+	}
+	if !strings.HasPrefix(info.Name, f.NamePrefix) {
+		return false
+	}
+	if f.ClientId != "" && f.ClientId != info.Comment {
+		return false
+	}
+	if !since.IsZero() && (info.SubmitTime == nil || info.SubmitTime.Before(since)) {
+		return false
+	}
+	return true
+}
+
+func containsStatus(ss []api.JobStatus, s api.JobStatus) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
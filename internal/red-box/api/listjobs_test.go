@@ -0,0 +1,110 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+func Test_mergeJobLists(t *testing.T) {
+	running := &slurm.JobInfo{ID: "3", State: "RUNNING"}
+	staleRunning := &slurm.JobInfo{ID: "3", State: "PENDING"}
+	completed := &slurm.JobInfo{ID: "1", State: "COMPLETED"}
+	pending := &slurm.JobInfo{ID: "10", State: "PENDING"}
+	invalid := &slurm.JobInfo{ID: "foo"}
+
+	got := mergeJobLists(
+		[]*slurm.JobInfo{pending, running},
+		[]*slurm.JobInfo{staleRunning, completed, invalid},
+	)
+	require.Equal(t, []listedJob{
+		{id: 1, info: completed},
+		{id: 3, info: running},
+		{id: 10, info: pending},
+	}, got)
+}
+
+func Test_matchJob(t *testing.T) {
+	submitted := time.Date(2019, 2, 20, 11, 16, 55, 0, time.UTC)
+	job := &slurm.JobInfo{
+		ID:         "1",
+		Name:       "cow.job",
+		UserID:     "vagrant",
+		State:      "RUNNING",
+		Partition:  "debug",
+		SubmitTime: &submitted,
+		Comment:    "client-1",
+	}
+
+	tests := []struct {
+		name   string
+		filter *api.JobFilter
+		since  time.Time
+		want   bool
+	}{
+		{
+			name:   "empty filter",
+			filter: &api.JobFilter{},
+			want:   true,
+		},
+		{
+			name: "all match",
+			filter: &api.JobFilter{
+				States:     []api.JobStatus{api.JobStatus_PENDING, api.JobStatus_RUNNING},
+				Partitions: []string{"debug"},
+				Users:      []string{"vagrant"},
+				NamePrefix: "cow",
+				ClientId:   "client-1",
+			},
+			since: submitted,
+			want:  true,
+		},
+		{
+			name:   "state mismatch",
+			filter: &api.JobFilter{States: []api.JobStatus{api.JobStatus_COMPLETED}},
+		},
+		{
+			name:   "partition mismatch",
+			filter: &api.JobFilter{Partitions: []string{"gpu"}},
+		},
+		{
+			name:   "user mismatch",
+			filter: &api.JobFilter{Users: []string{"root"}},
+		},
+		{
+			name:   "name mismatch",
+			filter: &api.JobFilter{NamePrefix: "job"},
+		},
+		{
+			name:   "client mismatch",
+			filter: &api.JobFilter{ClientId: "client-2"},
+		},
+		{
+			name:   "submitted before",
+			filter: &api.JobFilter{},
+			since:  submitted.Add(time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, matchJob(tt.filter, tt.since, job))
+		})
+	}
+}
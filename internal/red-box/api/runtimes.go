@@ -0,0 +1,57 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"os/exec"
+	"sort"
+
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+// runtimeBinaries are executables that indicate a container runtime is installed.
+var runtimeBinaries = map[api.ContainerRuntime]string{
+	api.ContainerRuntime_SINGULARITY:  "singularity",
+	api.ContainerRuntime_APPTAINER:    "apptainer",
+	api.ContainerRuntime_ENROOT:       "enroot",
+	api.ContainerRuntime_PODMAN_HPC:   "podman-hpc",
+	api.ContainerRuntime_CHARLIECLOUD: "ch-run",
+}
+
+// detectRuntimes returns container runtimes installed on red-box host. Enroot is
+// reported only when pyxis plugin is loaded, which is checked with srun help. Runtimes
+// are detected on red-box host only, compute nodes are expected to have the same software.
+func detectRuntimes(lookPath func(string) (string, error), srunHelp func() ([]byte, error)) []api.ContainerRuntime {
+	var res []api.ContainerRuntime
+	for rt, binary := range runtimeBinaries {
+		if _, err := lookPath(binary); err != nil {
+			continue
+		}
+		if rt == api.ContainerRuntime_ENROOT {
+			help, err := srunHelp()
+			if err != nil || !bytes.Contains(help, []byte("--container-image")) {
+				continue
+			}
+		}
+		res = append(res, rt)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+func srunHelp() ([]byte, error) {
+	return exec.Command("srun", "--help").Output()
+}
@@ -0,0 +1,536 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+const dockerPrefix = "docker://"
+
+// envName matches environment variable names that can be exported from shell.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// runtimeScripts generate batch script lines that run the requested image with a container runtime.
+var runtimeScripts = map[api.ContainerRuntime]func(r *api.SubmitJobContainerRequest) ([]string, error){
+	api.ContainerRuntime_SINGULARITY:  singularityScript("singularity"),
+	api.ContainerRuntime_APPTAINER:    singularityScript("apptainer"),
+	api.ContainerRuntime_ENROOT:       enrootScript,
+	api.ContainerRuntime_PODMAN_HPC:   podmanHPCScript,
+	api.ContainerRuntime_CHARLIECLOUD: charliecloudScript,
+}
+
+// scriptData is passed to partition script templates.
+type scriptData struct {
+	// Partition is a partition job is submitted to.
+	Partition string
+	// Runtime is a container runtime name, e.g. singularity.
+	Runtime string
+	// Directives are #SBATCH lines with requested resources.
+	Directives []string
+	// Commands export job environment and run container.
+	Commands []string
+}
+
+// buildSLURMScript generates batch script that runs the requested image. When partition
+// has script template configured, the script is rendered with it, otherwise directives
+// and commands are put one after another.
+func buildSLURMScript(r *api.SubmitJobContainerRequest, tmpl *ScriptTemplate) (string, error) {
+	const (
+		timeT       = `#SBATCH --time=0:%d` //seconds
+		memT        = `#SBATCH --mem=%d`    //mbs
+		nodesT      = `#SBATCH --nodes=%d`
+		cpuPerTaskT = `#SBATCH --cpus-per-task=%d`
+		minCPUsT    = `#SBATCH --mincpus=%d`
+		nTasksT     = `#SBATCH --ntasks=%d`
+		perNodeT    = `#SBATCH --ntasks-per-node=%d`
+	)
+
+	if err := validateImageName(r.ImageName); err != nil {
+		return "", err
+	}
+	if r.Mpi != nil && r.Mpi.Type != api.MPIType_MPI_DEFAULT && mpiPlugins[r.Mpi.Type] == "" {
+		return "", errors.Errorf("unknown mpi type %s", r.Mpi.Type)
+	}
+	script, ok := runtimeScripts[r.Runtime]
+	if !ok {
+		return "", errors.Errorf("unknown container runtime %s", r.Runtime)
+	}
+	runtime := strings.Replace(strings.ToLower(r.Runtime.String()), "_", "-", -1)
+	commands, err := script(r)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s options", runtime)
+	}
+
+	var directives []string
+	if r.WallTime != 0 {
+		directives = append(directives, fmt.Sprintf(timeT, r.WallTime))
+	}
+
+	if r.MemPerNode != 0 {
+		directives = append(directives, fmt.Sprintf(memT, r.MemPerNode))
+	}
+
+	if r.Nodes != 0 {
+		directives = append(directives, fmt.Sprintf(nodesT, r.Nodes))
+	}
+
+	// cpus are requested per node, while MPI jobs run several tasks per node,
+	// so cpus are split between tasks when their number per node is known
+	switch {
+	case r.CpuPerNode == 0:
+	case r.Mpi == nil:
+		directives = append(directives, fmt.Sprintf(cpuPerTaskT, r.CpuPerNode))
+	case r.Mpi.TasksPerNode != 0:
+		if r.CpuPerNode%r.Mpi.TasksPerNode != 0 {
+			return "", errors.Errorf("cpus per node %d are not divisible by %d tasks per node",
+				r.CpuPerNode, r.Mpi.TasksPerNode)
+		}
+		directives = append(directives, fmt.Sprintf(cpuPerTaskT, r.CpuPerNode/r.Mpi.TasksPerNode))
+	default:
+		directives = append(directives, fmt.Sprintf(minCPUsT, r.CpuPerNode))
+	}
+
+	if r.Mpi != nil {
+		directives = append(directives, fmt.Sprintf(nTasksT, r.Mpi.Ntasks))
+		if r.Mpi.TasksPerNode != 0 {
+			directives = append(directives, fmt.Sprintf(perNodeT, r.Mpi.TasksPerNode))
+		}
+	}
+
+	if tmpl == nil || tmpl.Template == nil {
+		lines := append([]string{"#!/bin/sh"}, directives...)
+		lines = append(lines, commands...)
+		return strings.Join(lines, "\n"), nil
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, scriptData{
+		Partition:  r.Partition,
+		Runtime:    runtime,
+		Directives: directives,
+		Commands:   commands,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "could not execute partition script template")
+	}
+	return buf.String(), nil
+}
+
+// validateImageName checks that image name is not empty and has no control characters,
+// that are not expected in image references and paths.
+func validateImageName(image string) error {
+	if image == "" {
+		return errors.New("image is required")
+	}
+	if strings.IndexFunc(image, unicode.IsControl) != -1 {
+		return errors.Errorf("image %q contains control characters", image)
+	}
+	return nil
+}
+
+// mpiPlugins maps MPI types to srun --mpi values.
+var mpiPlugins = map[api.MPIType]string{
+	api.MPIType_MPI_PMIX: "pmix",
+	api.MPIType_MPI_PMI2: "pmi2",
+	api.MPIType_MPI_NONE: "none",
+}
+
+// srunArgs returns srun command that starts job application. MPI jobs are run with the
+// hybrid model: srun starts each rank in its own container and wires it up with MPI plugin.
+func srunArgs(r *api.SubmitJobContainerRequest) []string {
+	if r.Mpi == nil || r.Mpi.Type == api.MPIType_MPI_DEFAULT {
+		return []string{"srun"}
+	}
+	return []string{"srun", "--mpi=" + mpiPlugins[r.Mpi.Type]}
+}
+
+// prepareSrun returns srun command that runs job preparation steps, e.g. image pull.
+// As MPI jobs request several tasks, steps are limited to a single task to run once.
+func prepareSrun(r *api.SubmitJobContainerRequest) []string {
+	if r.Mpi == nil {
+		return []string{"srun"}
+	}
+	return []string{"srun", "--nodes=1", "--ntasks=1"}
+}
+
+// singularityScript returns generator of singularity script lines. Apptainer has the same
+// command line interface as singularity, so the generator is shared by passing a binary name.
+func singularityScript(binary string) func(r *api.SubmitJobContainerRequest) ([]string, error) {
+	return func(r *api.SubmitJobContainerRequest) ([]string, error) {
+		opts := r.Options
+		if opts == nil {
+			opts = &api.SingularityOptions{}
+		}
+		if err := validateSingularityOptions(opts); err != nil {
+			return nil, err
+		}
+		env, err := exportEnv(strings.ToUpper(binary)+"ENV_", r.Env)
+		if err != nil {
+			return nil, err
+		}
+
+		srun := prepareSrun(r)
+		step := func(args ...string) string {
+			return shellJoin(append(append([]string{}, srun...), args...))
+		}
+		run := func(image string) string {
+			return buildRunCommand(srunArgs(r), binary, opts, image, r.Command, r.Args) + " || exit"
+		}
+
+		// checks if sif is located somewhere on the host machine
+		if strings.HasPrefix(r.ImageName, localFilePrefix) {
+			image := strings.TrimPrefix(r.ImageName, localFilePrefix)
+			lines := env
+			if !opts.AllowUnsigned {
+				lines = append(lines, step(binary, "verify", image)+" || exit")
+			}
+			return append(lines, run(image)), nil
+		}
+
+		pull := []string{binary, "pull", "--name"} // secure pull
+		if opts.AllowUnsigned {
+			pull = []string{binary, "pull", "-U", "--name"} // unsecured pull
+		}
+
+		id := uuid.New().String()
+		return append(env,
+			step(append(pull, id, r.ImageName)...)+" || exit",
+			run(id),
+			step("rm", id),
+		), nil
+	}
+}
+
+// buildRunCommand returns command that runs image with singularity options. Image
+// runscript is run unless command is set, args are passed to either.
+func buildRunCommand(srun []string, binary string, opt *api.SingularityOptions, image string, command, args []string) string {
+	run := append(append([]string{}, srun...), binary, "run")
+	if len(command) != 0 {
+		run = append(append([]string{}, srun...), binary, "exec")
+	}
+
+	if opt.App != "" {
+		run = append(run, "--app="+opt.App)
+	}
+	if opt.HostName != "" {
+		run = append(run, "--hostname="+opt.HostName)
+	}
+
+	if len(opt.Binds) != 0 {
+		run = append(run, "--bind="+strings.Join(opt.Binds, ","))
+	}
+
+	if opt.ClearEnv {
+		run = append(run, "-c")
+	}
+	if opt.FakeRoot {
+		run = append(run, "-f")
+	}
+	if opt.Ipc {
+		run = append(run, "-i")
+	}
+	if opt.Pid {
+		run = append(run, "-p")
+	}
+	if opt.NoPrivs {
+		run = append(run, "--no-privs")
+	}
+	if opt.Writable {
+		run = append(run, "-w")
+	}
+
+	run = append(run, image)
+	run = append(run, command...)
+	run = append(run, args...)
+	return shellJoin(run)
+}
+
+var (
+	// appName matches singularity SCIF app names.
+	appName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	// hostName matches RFC 1123 host names.
+	hostName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+)
+
+// validateSingularityOptions checks that string options are well formed, so that they are
+// passed to singularity exactly as requested. Boolean options are always valid.
+func validateSingularityOptions(opts *api.SingularityOptions) error {
+	if opts.App != "" && !appName.MatchString(opts.App) {
+		return errors.Errorf("invalid app name %q", opts.App)
+	}
+	if opts.HostName != "" && (len(opts.HostName) > 253 || !hostName.MatchString(opts.HostName)) {
+		return errors.Errorf("invalid host name %q", opts.HostName)
+	}
+	for _, spec := range opts.Binds {
+		for _, bind := range strings.Split(spec, ",") {
+			if err := validateBind(bind); err != nil {
+				return errors.Wrapf(err, "invalid bind %q", spec)
+			}
+		}
+	}
+	return nil
+}
+
+// validateBind checks single singularity bind of format src[:dest[:opts]].
+func validateBind(bind string) error {
+	if strings.IndexFunc(bind, unicode.IsControl) != -1 {
+		return errors.New("control characters are not allowed")
+	}
+	parts := strings.Split(bind, ":")
+	if len(parts) > 3 {
+		return errors.New("format must be src[:dest[:opts]]")
+	}
+	if parts[0] == "" {
+		return errors.New("source path is required")
+	}
+	if len(parts) > 1 && !strings.HasPrefix(parts[1], "/") {
+		return errors.Errorf("destination %q must be an absolute path", parts[1])
+	}
+	if len(parts) > 2 && parts[2] != "ro" && parts[2] != "rw" {
+		return errors.Errorf("unknown option %q, must be ro or rw", parts[2])
+	}
+	return nil
+}
+
+// enrootScript runs image with pyxis srun plugin, pyxis imports image with enroot itself.
+func enrootScript(r *api.SubmitJobContainerRequest) ([]string, error) {
+	opts := r.Enroot
+	if opts == nil || len(opts.Command) == 0 {
+		return nil, errors.New("command is required")
+	}
+	if len(r.Command) != 0 || len(r.Args) != 0 {
+		return nil, errors.New("job command and args are not supported, options command is used")
+	}
+
+	image, err := pyxisImage(r.ImageName)
+	if err != nil {
+		return nil, err
+	}
+	// pyxis passes job environment into container
+	env, err := exportEnv("", r.Env)
+	if err != nil {
+		return nil, err
+	}
+	args := append(srunArgs(r), "--container-image="+image)
+	if len(opts.Mounts) != 0 {
+		args = append(args, "--container-mounts="+strings.Join(opts.Mounts, ","))
+	}
+	if opts.Workdir != "" {
+		args = append(args, "--container-workdir="+opts.Workdir)
+	}
+	if opts.MountHome {
+		args = append(args, "--container-mount-home")
+	} else {
+		args = append(args, "--no-container-mount-home")
+	}
+	if opts.RemapRoot {
+		args = append(args, "--container-remap-root")
+	}
+	if opts.Writable {
+		args = append(args, "--container-writable")
+	} else {
+		args = append(args, "--container-readonly")
+	}
+	args = append(args, opts.Command...)
+	return append(env, shellJoin(args)+" || exit"), nil
+}
+
+// pyxisImage converts image reference to pyxis format, where registry
+// is separated with #, e.g. docker://nvcr.io/nvidia/cuda becomes nvcr.io#nvidia/cuda.
+// Local images are passed as paths to squashfs files.
+func pyxisImage(image string) (string, error) {
+	if strings.HasPrefix(image, localFilePrefix) {
+		return strings.TrimPrefix(image, localFilePrefix), nil
+	}
+	if !strings.HasPrefix(image, dockerPrefix) {
+		return "", errors.Errorf("image %s is not supported, docker or local image is expected", image)
+	}
+
+	ref := strings.TrimPrefix(image, dockerPrefix)
+	if i := strings.IndexByte(ref, '/'); i != -1 {
+		host := ref[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref = host + "#" + ref[i+1:]
+		}
+	}
+	return ref, nil
+}
+
+// podmanHPCScript pulls image with podman-hpc, which also converts it
+// to squashfs format available on compute nodes, and runs it.
+func podmanHPCScript(r *api.SubmitJobContainerRequest) ([]string, error) {
+	if !strings.HasPrefix(r.ImageName, dockerPrefix) {
+		return nil, errors.Errorf("image %s is not supported, docker image is expected", r.ImageName)
+	}
+	image := strings.TrimPrefix(r.ImageName, dockerPrefix)
+	env, err := exportEnv("", r.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	// values are passed from the environment to keep them off command lines
+	args := append(srunArgs(r), "podman-hpc", "run", "--rm")
+	for _, name := range sortedKeys(r.Env) {
+		args = append(args, "--env", name)
+	}
+	if len(r.Command) != 0 {
+		entrypoint, err := json.Marshal(r.Command)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode entrypoint")
+		}
+		args = append(args, "--entrypoint", string(entrypoint))
+	}
+	if opts := r.PodmanHPC; opts != nil {
+		for _, v := range opts.Volumes {
+			args = append(args, "--volume", v)
+		}
+		if opts.Workdir != "" {
+			args = append(args, "--workdir", opts.Workdir)
+		}
+		if opts.Gpu {
+			args = append(args, "--gpu")
+		}
+		if opts.Mpi {
+			args = append(args, "--mpi")
+		}
+	}
+	args = append(args, image)
+	args = append(args, r.Args...)
+
+	return append(env,
+		shellJoin([]string{"podman-hpc", "pull", image})+" || exit",
+		shellJoin(args)+" || exit",
+	), nil
+}
+
+// charliecloudScript pulls image with charliecloud builder and converts it to a squashfs
+// file in job working directory, as builder storage is local to the batch host. Local images,
+// either squashfs files or directories, are run in place.
+func charliecloudScript(r *api.SubmitJobContainerRequest) ([]string, error) {
+	opts := r.Charliecloud
+	if opts == nil || len(opts.Command) == 0 {
+		return nil, errors.New("command is required")
+	}
+	if len(r.Command) != 0 || len(r.Args) != 0 {
+		return nil, errors.New("job command and args are not supported, options command is used")
+	}
+	// ch-run passes host environment into container
+	env, err := exportEnv("", r.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	var image, pulled string
+	switch {
+	case strings.HasPrefix(r.ImageName, localFilePrefix):
+		image = strings.TrimPrefix(r.ImageName, localFilePrefix)
+	case strings.HasPrefix(r.ImageName, dockerPrefix):
+		pulled = strings.TrimPrefix(r.ImageName, dockerPrefix)
+		image = uuid.New().String() + ".sqfs"
+	default:
+		return nil, errors.Errorf("image %s is not supported, docker or local image is expected", r.ImageName)
+	}
+
+	args := append(srunArgs(r), "ch-run")
+	for _, b := range opts.Binds {
+		args = append(args, "--bind="+b)
+	}
+	if opts.Writable {
+		args = append(args, "--write")
+	}
+	if opts.SetEnv {
+		args = append(args, "--set-env")
+	}
+	args = append(args, image, "--")
+	args = append(args, opts.Command...)
+	run := shellJoin(args) + " || exit"
+
+	if pulled == "" {
+		return append(env, run), nil
+	}
+	return append(env,
+		shellJoin([]string{"ch-image", "pull", pulled})+" || exit",
+		shellJoin([]string{"ch-convert", pulled, image})+" || exit",
+		run,
+		shellJoin([]string{"rm", image}),
+	), nil
+}
+
+// exportEnv returns lines exporting environment variables sorted by name,
+// each name is prefixed, e.g. with SINGULARITYENV_ to be set in container only.
+// Without prefix variables are set for the whole batch script, so variables
+// that change how slurm and the host run the job are rejected.
+func exportEnv(prefix string, env map[string]string) ([]string, error) {
+	var lines []string
+	for _, name := range sortedKeys(env) {
+		if !envName.MatchString(name) {
+			return nil, errors.Errorf("invalid environment variable name %q", name)
+		}
+		if prefix == "" && slurm.IsReservedEnv(name) {
+			return nil, errors.Errorf("environment variable %s is reserved", name)
+		}
+		lines = append(lines, "export "+prefix+name+"="+shellQuote(env[name]))
+	}
+	return lines, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shellJoin joins arguments into a shell command line quoting them when needed.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s with single quotes unless it consists of characters that
+// have no special meaning in shell.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_./:=,+@%", c)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
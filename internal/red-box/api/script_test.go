@@ -0,0 +1,605 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_buildRunCommand(t *testing.T) {
+	f := func(o *api.SingularityOptions, command, args []string, expected string) {
+		require.EqualValues(t, expected, buildRunCommand([]string{"srun"}, "singularity", o, "lolcow.sif", command, args))
+	}
+
+	f(&api.SingularityOptions{}, nil, nil, `srun singularity run lolcow.sif`)
+	f(&api.SingularityOptions{
+		ClearEnv: true,
+		FakeRoot: true,
+		Ipc:      true,
+		Pid:      true,
+		NoPrivs:  true,
+		Writable: true,
+		HostName: "test1",
+		App:      "main",
+		Binds:    []string{"b1", "b2"},
+	}, nil, nil, `srun singularity run --app=main --hostname=test1 --bind=b1,b2 -c -f -i -p --no-privs -w lolcow.sif`)
+	f(&api.SingularityOptions{Binds: []string{"/data/my files:/data"}}, []string{"/bin/sh", "-c"}, []string{"echo 100% $HOME"},
+		`srun singularity exec '--bind=/data/my files:/data' lolcow.sif /bin/sh -c 'echo 100% $HOME'`)
+	f(&api.SingularityOptions{}, nil, []string{"--verbose", "it's"}, `srun singularity run lolcow.sif --verbose 'it'\''s'`)
+}
+
+func Test_buildSLURMScript(t *testing.T) {
+	uuidRe := regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+	tt := []struct {
+		name        string
+		req         *api.SubmitJobContainerRequest
+		expected    string
+		expectError bool
+	}{
+		{
+			name: "singularity",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://sylabsed/examples/lolcow:latest",
+				Nodes:     2,
+				WallTime:  60,
+				Options:   &api.SingularityOptions{ClearEnv: true},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --time=0:60\n" +
+				"#SBATCH --nodes=2\n" +
+				`srun singularity pull --name UUID library://sylabsed/examples/lolcow:latest || exit` + "\n" +
+				`srun singularity run -c UUID || exit` + "\n" +
+				`srun rm UUID`,
+		},
+		{
+			name: "apptainer local image",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "local.file/home/user/lolcow.sif",
+				Runtime:   api.ContainerRuntime_APPTAINER,
+			},
+			expected: "#!/bin/sh\n" +
+				`srun apptainer verify /home/user/lolcow.sif || exit` + "\n" +
+				`srun apptainer run /home/user/lolcow.sif || exit`,
+		},
+		{
+			name: "enroot",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://nvcr.io/nvidia/pytorch:23.05-py3",
+				Runtime:   api.ContainerRuntime_ENROOT,
+				Enroot: &api.EnrootOptions{
+					Command:   []string{"python", "-c", "print('hi')"},
+					Mounts:    []string{"/data:/data:ro", "/scratch:/scratch"},
+					Workdir:   "/workspace",
+					RemapRoot: true,
+				},
+			},
+			expected: "#!/bin/sh\n" +
+				`srun '--container-image=nvcr.io#nvidia/pytorch:23.05-py3' --container-mounts=/data:/data:ro,/scratch:/scratch ` +
+				`--container-workdir=/workspace --no-container-mount-home --container-remap-root --container-readonly ` +
+				`python -c 'print('\''hi'\'')' || exit`,
+		},
+		{
+			name: "enroot without command",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_ENROOT,
+			},
+			expectError: true,
+		},
+		{
+			name: "podman-hpc",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_PODMAN_HPC,
+				PodmanHPC: &api.PodmanHPCOptions{Volumes: []string{"/scratch:/scratch"}, Workdir: "/scratch", Gpu: true},
+			},
+			expected: "#!/bin/sh\n" +
+				"podman-hpc pull ubuntu:18.04 || exit\n" +
+				"srun podman-hpc run --rm --volume /scratch:/scratch --workdir /scratch --gpu ubuntu:18.04 || exit",
+		},
+		{
+			name: "podman-hpc library image",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://sylabsed/examples/lolcow:latest",
+				Runtime:   api.ContainerRuntime_PODMAN_HPC,
+			},
+			expectError: true,
+		},
+		{
+			name: "charliecloud",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:    "docker://alpine:3.9",
+				Runtime:      api.ContainerRuntime_CHARLIECLOUD,
+				Charliecloud: &api.CharliecloudOptions{Command: []string{"cat", "/etc/os-release"}, Binds: []string{"/data"}},
+			},
+			expected: "#!/bin/sh\n" +
+				"ch-image pull alpine:3.9 || exit\n" +
+				"ch-convert alpine:3.9 UUID.sqfs || exit\n" +
+				"srun ch-run --bind=/data UUID.sqfs -- cat /etc/os-release || exit\n" +
+				"rm UUID.sqfs",
+		},
+		{
+			name: "charliecloud local image",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:    "local.file/home/user/alpine",
+				Runtime:      api.ContainerRuntime_CHARLIECLOUD,
+				Charliecloud: &api.CharliecloudOptions{Command: []string{"true"}, SetEnv: true},
+			},
+			expected: "#!/bin/sh\n" +
+				"srun ch-run --set-env /home/user/alpine -- true || exit",
+		},
+		{
+			name: "singularity mpi",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://sylabsed/examples/mpi:latest",
+				Nodes:     2,
+				Mpi:       &api.MPIOptions{Ntasks: 8, TasksPerNode: 4, Type: api.MPIType_MPI_PMIX},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --nodes=2\n" +
+				"#SBATCH --ntasks=8\n" +
+				"#SBATCH --ntasks-per-node=4\n" +
+				`srun --nodes=1 --ntasks=1 singularity pull --name UUID library://sylabsed/examples/mpi:latest || exit` + "\n" +
+				`srun --mpi=pmix singularity run UUID || exit` + "\n" +
+				`srun --nodes=1 --ntasks=1 rm UUID`,
+		},
+		{
+			name: "singularity mpi cpus per task",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:  "local.file/home/user/mpi.sif",
+				Nodes:      2,
+				CpuPerNode: 8,
+				Options:    &api.SingularityOptions{AllowUnsigned: true},
+				Mpi:        &api.MPIOptions{Ntasks: 8, TasksPerNode: 4},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --nodes=2\n" +
+				"#SBATCH --cpus-per-task=2\n" +
+				"#SBATCH --ntasks=8\n" +
+				"#SBATCH --ntasks-per-node=4\n" +
+				`srun singularity run /home/user/mpi.sif || exit`,
+		},
+		{
+			name: "singularity mpi min cpus",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:  "local.file/home/user/mpi.sif",
+				CpuPerNode: 8,
+				Options:    &api.SingularityOptions{AllowUnsigned: true},
+				Mpi:        &api.MPIOptions{Ntasks: 8},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --mincpus=8\n" +
+				"#SBATCH --ntasks=8\n" +
+				`srun singularity run /home/user/mpi.sif || exit`,
+		},
+		{
+			name: "mpi cpus not divisible by tasks",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:  "local.file/home/user/mpi.sif",
+				CpuPerNode: 6,
+				Mpi:        &api.MPIOptions{Ntasks: 8, TasksPerNode: 4},
+			},
+			expectError: true,
+		},
+		{
+			name: "apptainer mpi local image",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "local.file/home/user/mpi.sif",
+				Runtime:   api.ContainerRuntime_APPTAINER,
+				Mpi:       &api.MPIOptions{Ntasks: 4, Type: api.MPIType_MPI_PMI2},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --ntasks=4\n" +
+				`srun --nodes=1 --ntasks=1 apptainer verify /home/user/mpi.sif || exit` + "\n" +
+				`srun --mpi=pmi2 apptainer run /home/user/mpi.sif || exit`,
+		},
+		{
+			name: "singularity mpi default plugin",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "local.file/home/user/mpi.sif",
+				Options:   &api.SingularityOptions{AllowUnsigned: true},
+				Mpi:       &api.MPIOptions{Ntasks: 2},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --ntasks=2\n" +
+				`srun singularity run /home/user/mpi.sif || exit`,
+		},
+		{
+			name: "enroot mpi",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://nvcr.io/nvidia/hpc-benchmarks:23.5",
+				Runtime:   api.ContainerRuntime_ENROOT,
+				Enroot:    &api.EnrootOptions{Command: []string{"/workspace/hpl.sh"}},
+				Mpi:       &api.MPIOptions{Ntasks: 16, TasksPerNode: 8, Type: api.MPIType_MPI_PMIX},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --ntasks=16\n" +
+				"#SBATCH --ntasks-per-node=8\n" +
+				`srun --mpi=pmix '--container-image=nvcr.io#nvidia/hpc-benchmarks:23.5' ` +
+				`--no-container-mount-home --container-readonly /workspace/hpl.sh || exit`,
+		},
+		{
+			name: "podman-hpc mpi",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_PODMAN_HPC,
+				PodmanHPC: &api.PodmanHPCOptions{Mpi: true},
+				Mpi:       &api.MPIOptions{Ntasks: 4, Type: api.MPIType_MPI_NONE},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --ntasks=4\n" +
+				"podman-hpc pull ubuntu:18.04 || exit\n" +
+				"srun --mpi=none podman-hpc run --rm --mpi ubuntu:18.04 || exit",
+		},
+		{
+			name: "charliecloud mpi",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:    "local.file/home/user/mpi.sqfs",
+				Runtime:      api.ContainerRuntime_CHARLIECLOUD,
+				Charliecloud: &api.CharliecloudOptions{Command: []string{"/hello/hello"}},
+				Mpi:          &api.MPIOptions{Ntasks: 4, TasksPerNode: 2, Type: api.MPIType_MPI_PMI2},
+			},
+			expected: "#!/bin/sh\n" +
+				"#SBATCH --ntasks=4\n" +
+				"#SBATCH --ntasks-per-node=2\n" +
+				"srun --mpi=pmi2 ch-run /home/user/mpi.sqfs -- /hello/hello || exit",
+		},
+		{
+			name: "singularity command and env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://sylabsed/examples/lolcow:latest",
+				Command:   []string{"/bin/sh", "-c"},
+				Args:      []string{"echo $GREETING > out; rm -rf $(pwd)/tmp"},
+				Env:       map[string]string{"GREETING": "it's $HOME `id`", "EMPTY": ""},
+			},
+			expected: "#!/bin/sh\n" +
+				"export SINGULARITYENV_EMPTY=''\n" +
+				`export SINGULARITYENV_GREETING='it'\''s $HOME ` + "`id`'\n" +
+				`srun singularity pull --name UUID library://sylabsed/examples/lolcow:latest || exit` + "\n" +
+				`srun singularity exec UUID /bin/sh -c 'echo $GREETING > out; rm -rf $(pwd)/tmp' || exit` + "\n" +
+				`srun rm UUID`,
+		},
+		{
+			name: "apptainer env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "local.file/home/user/lolcow.sif",
+				Runtime:   api.ContainerRuntime_APPTAINER,
+				Options:   &api.SingularityOptions{AllowUnsigned: true},
+				Args:      []string{"moo"},
+				Env:       map[string]string{"TOKEN": "s3cr3t"},
+			},
+			expected: "#!/bin/sh\n" +
+				"export APPTAINERENV_TOKEN=s3cr3t\n" +
+				`srun apptainer run /home/user/lolcow.sif moo || exit`,
+		},
+		{
+			name: "invalid env name",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://sylabsed/examples/lolcow:latest",
+				Env:       map[string]string{"A;reboot": "1"},
+			},
+			expectError: true,
+		},
+		{
+			name: "enroot env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_ENROOT,
+				Enroot:    &api.EnrootOptions{Command: []string{"env"}},
+				Env:       map[string]string{"GREETING": "hello world"},
+			},
+			expected: "#!/bin/sh\n" +
+				"export GREETING='hello world'\n" +
+				"srun --container-image=ubuntu:18.04 --no-container-mount-home --container-readonly env || exit",
+		},
+		{
+			name: "enroot reserved env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_ENROOT,
+				Enroot:    &api.EnrootOptions{Command: []string{"env"}},
+				Env:       map[string]string{"PATH": "/tmp/bin"},
+			},
+			expectError: true,
+		},
+		{
+			name: "singularity path env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "local.file/home/user/lolcow.sif",
+				Options:   &api.SingularityOptions{AllowUnsigned: true},
+				Env:       map[string]string{"PATH": "/opt/bin"},
+			},
+			expected: "#!/bin/sh\n" +
+				"export SINGULARITYENV_PATH=/opt/bin\n" +
+				"srun singularity run /home/user/lolcow.sif || exit",
+		},
+		{
+			name: "enroot job command",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_ENROOT,
+				Enroot:    &api.EnrootOptions{Command: []string{"env"}},
+				Command:   []string{"true"},
+			},
+			expectError: true,
+		},
+		{
+			name: "podman-hpc command and env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime_PODMAN_HPC,
+				Command:   []string{"/bin/sh", "-c"},
+				Args:      []string{"echo $GREETING"},
+				Env:       map[string]string{"GREETING": "hello world", "DEBUG": "1"},
+			},
+			expected: "#!/bin/sh\n" +
+				"export DEBUG=1\n" +
+				"export GREETING='hello world'\n" +
+				"podman-hpc pull ubuntu:18.04 || exit\n" +
+				`srun podman-hpc run --rm --env DEBUG --env GREETING --entrypoint '["/bin/sh","-c"]' ubuntu:18.04 'echo $GREETING' || exit`,
+		},
+		{
+			name: "charliecloud env",
+			req: &api.SubmitJobContainerRequest{
+				ImageName:    "local.file/home/user/alpine.sqfs",
+				Runtime:      api.ContainerRuntime_CHARLIECLOUD,
+				Charliecloud: &api.CharliecloudOptions{Command: []string{"env"}},
+				Env:          map[string]string{"GREETING": "hello"},
+			},
+			expected: "#!/bin/sh\n" +
+				"export GREETING=hello\n" +
+				"srun ch-run /home/user/alpine.sqfs -- env || exit",
+		},
+		{
+			name: "shell in image and binds",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: `local.file/home/user/"$(reboot)".sif`,
+				Options:   &api.SingularityOptions{AllowUnsigned: true, Binds: []string{"/data/$(id):/data"}},
+			},
+			expected: "#!/bin/sh\n" +
+				`srun singularity run '--bind=/data/$(id):/data' '/home/user/"$(reboot)".sif' || exit`,
+		},
+		{
+			name: "newline in image",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://alpine\nreboot",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid singularity options",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://alpine",
+				Options:   &api.SingularityOptions{HostName: "host;reboot"},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown mpi type",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "library://alpine",
+				Mpi:       &api.MPIOptions{Ntasks: 2, Type: api.MPIType(42)},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown runtime",
+			req: &api.SubmitJobContainerRequest{
+				ImageName: "docker://ubuntu:18.04",
+				Runtime:   api.ContainerRuntime(42),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			script, err := buildSLURMScript(tc.req, nil)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, uuidRe.ReplaceAllString(script, "UUID"))
+		})
+	}
+}
+
+func Test_buildSLURMScriptTemplate(t *testing.T) {
+	var c Config
+	err := yaml.Unmarshal([]byte(`
+partitions:
+  gpu:
+    script_template: |-
+      #!/bin/bash
+      {{range .Directives}}{{.}}
+      {{end}}#SBATCH --gres=gpu:1
+      module load {{.Runtime}}
+      cd /scratch/{{quote .Partition}}
+      {{range .Commands}}{{.}}
+      {{end}}
+`), &c)
+	require.NoError(t, err)
+
+	r := &api.SubmitJobContainerRequest{
+		ImageName: "local.file/home/user/lolcow.sif",
+		Partition: "gpu",
+		Runtime:   api.ContainerRuntime_APPTAINER,
+		Nodes:     1,
+		Options:   &api.SingularityOptions{AllowUnsigned: true},
+	}
+	script, err := buildSLURMScript(r, c.Partitions["gpu"].ScriptTemplate)
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/bash\n"+
+		"#SBATCH --nodes=1\n"+
+		"#SBATCH --gres=gpu:1\n"+
+		"module load apptainer\n"+
+		"cd /scratch/gpu\n"+
+		"srun apptainer run /home/user/lolcow.sif || exit\n", script)
+
+	err = yaml.Unmarshal([]byte(`
+partitions:
+  gpu:
+    script_template: "{{range .Commands}}{{.}}{{end}}"
+`), &c)
+	require.Error(t, err, "interpreter line is required")
+
+	err = yaml.Unmarshal([]byte(`
+partitions:
+  gpu:
+    script_template: "#!/bin/sh\n{{range .Commands}}"
+`), &c)
+	require.Error(t, err, "template must be parsed")
+}
+
+func Test_validateSingularityOptions(t *testing.T) {
+	tt := []struct {
+		name        string
+		opts        *api.SingularityOptions
+		expectError bool
+	}{
+		{name: "empty", opts: &api.SingularityOptions{}},
+		{
+			name: "valid",
+			opts: &api.SingularityOptions{
+				App:      "hello-world_1.0",
+				HostName: "node-1.example.com",
+				Binds:    []string{"/data:/data:ro,/scratch", "/home/user/my files:/mnt"},
+			},
+		},
+		{name: "app with spaces", opts: &api.SingularityOptions{App: "a b"}, expectError: true},
+		{name: "app with quote", opts: &api.SingularityOptions{App: `a"`}, expectError: true},
+		{name: "host name with underscore", opts: &api.SingularityOptions{HostName: "my_host"}, expectError: true},
+		{name: "host name with dollar", opts: &api.SingularityOptions{HostName: "$(hostname)"}, expectError: true},
+		{name: "bind newline", opts: &api.SingularityOptions{Binds: []string{"/data\n/etc"}}, expectError: true},
+		{name: "bind relative dest", opts: &api.SingularityOptions{Binds: []string{"/data:data"}}, expectError: true},
+		{name: "bind unknown option", opts: &api.SingularityOptions{Binds: []string{"/data:/data:exec"}}, expectError: true},
+		{name: "bind empty source", opts: &api.SingularityOptions{Binds: []string{"/data,"}}, expectError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSingularityOptions(tc.opts)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_pyxisImage(t *testing.T) {
+	tt := []struct {
+		image    string
+		expected string
+	}{
+		{image: "docker://ubuntu:18.04", expected: "ubuntu:18.04"},
+		{image: "docker://nvidia/cuda:10.0-base", expected: "nvidia/cuda:10.0-base"},
+		{image: "docker://nvcr.io/nvidia/pytorch:23.05-py3", expected: "nvcr.io#nvidia/pytorch:23.05-py3"},
+		{image: "docker://localhost:5000/app", expected: "localhost:5000#app"},
+		{image: "local.file/home/user/ubuntu.sqsh", expected: "/home/user/ubuntu.sqsh"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.image, func(t *testing.T) {
+			actual, err := pyxisImage(tc.image)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, err := pyxisImage("library://alpine")
+	require.Error(t, err)
+}
+
+func Test_shellQuote(t *testing.T) {
+	tt := []struct {
+		in       string
+		expected string
+	}{
+		{in: "", expected: "''"},
+		{in: "/data:/data:ro", expected: "/data:/data:ro"},
+		{in: "hello world", expected: "'hello world'"},
+		{in: "$(id)", expected: "'$(id)'"},
+		{in: "it's", expected: `'it'\''s'`},
+		{in: "#comment", expected: "'#comment'"},
+		{in: "a\nb", expected: "'a\nb'"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.in, func(t *testing.T) {
+			require.Equal(t, tc.expected, shellQuote(tc.in))
+		})
+	}
+}
+
+func Test_detectRuntimes(t *testing.T) {
+	installed := map[string]bool{"singularity": true, "enroot": true, "ch-run": true}
+	lookPath := func(file string) (string, error) {
+		if installed[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", os.ErrNotExist
+	}
+
+	tt := []struct {
+		name     string
+		srunHelp func() ([]byte, error)
+		expected []api.ContainerRuntime
+	}{
+		{
+			name: "pyxis",
+			srunHelp: func() ([]byte, error) {
+				return []byte("Options provided by plugins:\n      --container-image=[USER@][REGISTRY#]IMAGE[:TAG]|PATH\n"), nil
+			},
+			expected: []api.ContainerRuntime{
+				api.ContainerRuntime_SINGULARITY,
+				api.ContainerRuntime_ENROOT,
+				api.ContainerRuntime_CHARLIECLOUD,
+			},
+		},
+		{
+			name:     "no pyxis",
+			srunHelp: func() ([]byte, error) { return []byte("Usage: srun [OPTIONS(0)...]"), nil },
+			expected: []api.ContainerRuntime{
+				api.ContainerRuntime_SINGULARITY,
+				api.ContainerRuntime_CHARLIECLOUD,
+			},
+		},
+		{
+			name:     "no srun",
+			srunHelp: func() ([]byte, error) { return nil, errors.New("srun is not found") },
+			expected: []api.ContainerRuntime{
+				api.ContainerRuntime_SINGULARITY,
+				api.ContainerRuntime_CHARLIECLOUD,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, detectRuntimes(lookPath, tc.srunHelp))
+		})
+	}
+}
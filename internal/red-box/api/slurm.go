@@ -16,20 +16,21 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/exec"
 	"strings"
-	"time"
-
-	"github.com/google/uuid"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/internal/red-box/audit"
+	"github.com/sylabs/wlm-operator/internal/red-box/metrics"
 	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/tail"
 	"github.com/sylabs/wlm-operator/pkg/workload/api"
 )
 
@@ -41,48 +42,52 @@ type (
 		uid    int64
 		cfg    Config
 		client *slurm.Client
-	}
+		audit  *audit.Logger
+		jobs   *jobCache
+		tails  *tail.Hub
 
-	// Config is a red-box configuration for each partition available.
-	Config map[string]PartitionResources
-
-	// PartitionResources configure how red-box will see slurm partition resources.
-	// In auto mode red-box will attempt to query partition resources from slurm, but
-	// administrator can set up them manually.
-	PartitionResources struct {
-		AutoNodes      bool `yaml:"auto_nodes"`
-		AutoCPUPerNode bool `yaml:"auto_cpu_per_node"`
-		AutoMemPerNode bool `yaml:"auto_mem_per_node"`
-		AutoWallTime   bool `yaml:"auto_wall_time"`
+		runtimesOnce sync.Once
+		runtimes     []api.ContainerRuntime
+	}
+)
 
-		Nodes      int64         `yaml:"nodes"`
-		CPUPerNode int64         `yaml:"cpu_per_node"`
-		MemPerNode int64         `yaml:"mem_per_node"`
-		WallTime   time.Duration `yaml:"wall_time"`
+// NewSlurm creates a new instance of Slurm. Audit logger may be nil
+// if audit is disabled.
+func NewSlurm(c *slurm.Client, cfg Config, al *audit.Logger) *Slurm {
+	s := &Slurm{client: c, cfg: cfg, audit: al, uid: int64(os.Geteuid()), tails: tail.NewHub()}
 
-		AdditionalFeatures []Feature `yaml:"additional_features"`
+	interval := cfg.JobCache.RefreshInterval
+	if interval == 0 {
+		interval = defaultJobCacheRefreshInterval
 	}
-
-	// Feature represents slurm partition feature.
-	Feature struct {
-		Name     string `yaml:"name"`
-		Version  string `yaml:"version"`
-		Quantity int64  `yaml:"quantity"`
+	if interval > 0 {
+		s.jobs = newJobCache(c, interval)
 	}
-)
+	return s
+}
 
-// NewSlurm creates a new instance of Slurm.
-func NewSlurm(c *slurm.Client, cfg Config) *Slurm {
-	return &Slurm{client: c, cfg: cfg, uid: int64(os.Geteuid())}
+// Run starts Slurm background routines, e.g. job info cache refresh, till ctx is done.
+func (s *Slurm) Run(ctx context.Context) {
+	if s.jobs != nil {
+		s.jobs.run(ctx)
+	}
 }
 
 // SubmitJob submits job and returns id of it in case of success.
 func (s *Slurm) SubmitJob(ctx context.Context, req *api.SubmitJobRequest) (*api.SubmitJobResponse, error) {
-	// todo use client id from req
-	id, err := s.client.SBatch(req.Script, req.Partition)
+	id, err := s.client.SBatch(req.Script, req.Partition, req.ClientId)
+	s.audit.Log(ctx, audit.Record{
+		RPC:          "SubmitJob",
+		ClientID:     req.ClientId,
+		JobID:        id,
+		Partition:    req.Partition,
+		ScriptSHA256: audit.ScriptHash(req.Script),
+	}, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not submit sbatch script")
 	}
+	metrics.Jobs.Observe(id, api.JobStatus_PENDING.String())
+	s.trackJob(id)
 
 	return &api.SubmitJobResponse{
 		JobId: id,
@@ -91,12 +96,25 @@ func (s *Slurm) SubmitJob(ctx context.Context, req *api.SubmitJobRequest) (*api.
 
 // SubmitJobContainer starts a container from the provided image name inside a sbatch script.
 func (s *Slurm) SubmitJobContainer(ctx context.Context, r *api.SubmitJobContainerRequest) (*api.SubmitJobContainerResponse, error) {
-	script := buildSLURMScript(r)
-
-	id, err := s.client.SBatch(script, r.Partition)
+	script, err := buildSLURMScript(r, s.cfg.Partitions[r.Partition].ScriptTemplate)
+	if err != nil {
+		s.audit.Log(ctx, audit.Record{RPC: "SubmitJobContainer", ClientID: r.ClientId, Partition: r.Partition}, err)
+		return nil, errors.Wrap(err, "could not build sbatch script")
+	}
+
+	id, err := s.client.SBatch(script, r.Partition, r.ClientId)
+	s.audit.Log(ctx, audit.Record{
+		RPC:          "SubmitJobContainer",
+		ClientID:     r.ClientId,
+		JobID:        id,
+		Partition:    r.Partition,
+		ScriptSHA256: audit.ScriptHash(script),
+	}, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not submit sbatch script")
 	}
+	metrics.Jobs.Observe(id, api.JobStatus_PENDING.String())
+	s.trackJob(id)
 
 	return &api.SubmitJobContainerResponse{
 		JobId: id,
@@ -105,7 +123,9 @@ func (s *Slurm) SubmitJobContainer(ctx context.Context, r *api.SubmitJobContaine
 
 // CancelJob cancels job.
 func (s *Slurm) CancelJob(ctx context.Context, req *api.CancelJobRequest) (*api.CancelJobResponse, error) {
-	if err := s.client.SCancel(req.JobId); err != nil {
+	err := s.client.SCancel(req.JobId)
+	s.audit.Log(ctx, audit.Record{RPC: "CancelJob", JobID: req.JobId}, err)
+	if err != nil {
 		return nil, errors.Wrapf(err, "could not cancel job %d", req.JobId)
 	}
 
@@ -113,12 +133,16 @@ func (s *Slurm) CancelJob(ctx context.Context, req *api.CancelJobRequest) (*api.
 }
 
 // JobInfo returns information about a job from 'scontrol show jobid'.
+// When job cache is enabled info is taken from the latest jobs snapshot.
 // Safe to call before job finished. After it could return an error.
 func (s *Slurm) JobInfo(ctx context.Context, req *api.JobInfoRequest) (*api.JobInfoResponse, error) {
-	info, err := s.client.SJobInfo(req.JobId)
+	info, err := s.jobInfo(req.JobId)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get job %d info", req.JobId)
 	}
+	if len(info) != 0 {
+		metrics.Jobs.Observe(req.JobId, info[0].State)
+	}
 
 	pInfo, err := mapSInfoToProtoInfo(info)
 	if err != nil {
@@ -148,9 +172,25 @@ func (s *Slurm) JobSteps(ctx context.Context, req *api.JobStepsRequest) (*api.Jo
 	return &api.JobStepsResponse{JobSteps: pSteps}, nil
 }
 
+// JobUsage returns job resources usage from 'sacct'.
+func (s *Slurm) JobUsage(ctx context.Context, req *api.JobUsageRequest) (*api.JobUsageResponse, error) {
+	usage, err := s.client.SJobUsage(req.JobId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get job %d usage", req.JobId)
+	}
+
+	cpu, mem := slurm.Efficiency(usage)
+	return &api.JobUsageResponse{
+		Steps:            toProtoUsage(usage),
+		CpuEfficiency:    cpu,
+		MemoryEfficiency: mem,
+	}, nil
+}
+
 // OpenFile opens requested file and return chunks with bytes.
 func (s *Slurm) OpenFile(r *api.OpenFileRequest, req api.WorkloadManager_OpenFileServer) error {
 	fd, err := s.client.Open(r.Path)
+	s.audit.Log(req.Context(), audit.Record{RPC: "OpenFile", Path: r.Path}, err)
 	if err != nil {
 		return errors.Wrapf(err, "could not open file at %s", r.Path)
 	}
@@ -180,22 +220,25 @@ func (s *Slurm) OpenFile(r *api.OpenFileRequest, req api.WorkloadManager_OpenFil
 // TailFile tails a file till close requested.
 // To start receiving file bytes client should send a request with file path and action start,
 // to stop client should send a request with action readToEndAndClose (file path is not required)
-// and after reaching end method will send EOF error.
+// and after reaching end method will send EOF error. Clients tailing the same file share
+// a single watcher, a client that can't keep up with the file is disconnected.
 func (s *Slurm) TailFile(req api.WorkloadManager_TailFileServer) error {
 	r, err := req.Recv()
 	if err != nil {
 		return errors.Wrap(err, "could not receive request")
 	}
 
-	fd, err := s.client.Tail(r.Path)
+	fd, err := s.tails.Subscribe(r.Path, 0)
+	s.audit.Log(req.Context(), audit.Record{RPC: "TailFile", Path: r.Path}, err)
 	if err != nil {
 		return errors.Wrapf(err, "could not tail file at %s", r.Path)
 	}
 	defer func(p string) {
+		_ = fd.Close()
 		log.Printf("Tail file at %s finished", p)
 	}(r.Path)
 
-	requestCh := make(chan *api.TailFileRequest)
+	requestCh := make(chan *api.TailFileRequest, 1)
 	go func() {
 		r, err := req.Recv()
 		if err != nil {
@@ -208,32 +251,27 @@ func (s *Slurm) TailFile(req api.WorkloadManager_TailFileServer) error {
 		requestCh <- r
 	}()
 
-	buff := make([]byte, 128)
-
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
+	buff := make([]byte, 32<<10)
 	for {
+		n, err := fd.Read(buff)
+		if n > 0 {
+			if err := req.Send(&api.Chunk{Content: buff[:n]}); err != nil {
+				return errors.Wrap(err, "could not send chunk")
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
 		select {
 		case <-req.Context().Done():
 			return req.Context().Err()
 		case r := <-requestCh:
 			if r.Action == api.TailAction_ReadToEndAndClose {
-				_ = fd.Close()
-			}
-		case <-ticker.C:
-			n, err := fd.Read(buff)
-			if err != nil && n == 0 {
-				return err
-			}
-
-			if n == 0 {
-				continue
-			}
-
-			if err := req.Send(&api.Chunk{Content: buff[:n]}); err != nil {
-				return errors.Wrap(err, "could not send chunk")
+				fd.StopAtEOF()
 			}
+		case <-fd.Ready():
 		}
 	}
 }
@@ -245,7 +283,7 @@ func (s *Slurm) Resources(_ context.Context, req *api.ResourcesRequest) (*api.Re
 		return nil, errors.Wrapf(err, "could not get resources for partition %s", req.Partition)
 	}
 
-	partitionResources := s.cfg[req.Partition]
+	partitionResources := s.cfg.Partitions[req.Partition]
 	response := &api.ResourcesResponse{
 		Nodes:      partitionResources.Nodes,
 		CpuPerNode: partitionResources.CPUPerNode,
@@ -294,7 +332,75 @@ func (s *Slurm) Partitions(context.Context, *api.PartitionsRequest) (*api.Partit
 	return &api.PartitionsResponse{Partition: names}, nil
 }
 
-// WorkloadInfo returns wlm info (name, version, red-box uid)
+// Nodes returns partition compute nodes from 'scontrol show node'.
+func (s *Slurm) Nodes(_ context.Context, req *api.NodesRequest) (*api.NodesResponse, error) {
+	nodes, err := s.client.Nodes(req.Partition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get nodes of partition %s", req.Partition)
+	}
+
+	pNodes := make([]*api.NodeInfo, len(nodes))
+	for i, n := range nodes {
+		gres := make([]*api.Feature, len(n.Gres))
+		for j, g := range n.Gres {
+			gres[j] = &api.Feature{
+				Name:     g.Name,
+				Version:  g.Version,
+				Quantity: g.Quantity,
+			}
+		}
+		pNodes[i] = &api.NodeInfo{
+			Name:        n.Name,
+			State:       toProtoNodeState(n.State),
+			RawState:    n.State,
+			Cpus:        n.CPUs,
+			AllocCpus:   n.AllocCPUs,
+			RealMemory:  n.RealMemory,
+			AllocMemory: n.AllocMemory,
+			Features:    n.Features,
+			Gres:        gres,
+			Reason:      n.Reason,
+			Partitions:  n.Partitions,
+		}
+	}
+
+	return &api.NodesResponse{Nodes: pNodes}, nil
+}
+
+// PartitionStatus returns partition state and numbers of its pending and running jobs.
+// When several partitions are requested their statuses are returned by partition name.
+func (s *Slurm) PartitionStatus(_ context.Context, req *api.PartitionStatusRequest) (*api.PartitionStatusResponse, error) {
+	if len(req.Partitions) != 0 {
+		statuses, err := s.client.PartitionsStatus(req.Partitions)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get status of partitions")
+		}
+		resp := &api.PartitionStatusResponse{
+			Partitions: make(map[string]*api.PartitionStatusResponse, len(statuses)),
+		}
+		for p, status := range statuses {
+			resp.Partitions[p] = partitionStatusResponse(status)
+		}
+		return resp, nil
+	}
+
+	status, err := s.client.PartitionStatus(req.Partition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get status of partition %s", req.Partition)
+	}
+	return partitionStatusResponse(status), nil
+}
+
+func partitionStatusResponse(status *slurm.PartitionStatus) *api.PartitionStatusResponse {
+	return &api.PartitionStatusResponse{
+		State:       status.State,
+		PendingJobs: status.PendingJobs,
+		RunningJobs: status.RunningJobs,
+	}
+}
+
+// WorkloadInfo returns wlm info (name, version, red-box uid), container
+// runtimes that can be used to run job containers and cluster licenses.
 func (s *Slurm) WorkloadInfo(context.Context, *api.WorkloadInfoRequest) (*api.WorkloadInfoResponse, error) {
 	const wlmName = "slurm"
 
@@ -303,13 +409,53 @@ func (s *Slurm) WorkloadInfo(context.Context, *api.WorkloadInfoRequest) (*api.Wo
 		return nil, errors.Wrap(err, "could not get slurm version")
 	}
 
+	s.runtimesOnce.Do(func() {
+		s.runtimes = detectRuntimes(exec.LookPath, srunHelp)
+	})
+
+	// licenses are optional, so workload info is still returned without them
+	var licenses []*api.Feature
+	sLicenses, err := s.client.Licenses()
+	if err != nil {
+		log.Printf("Could not get licenses: %v", err)
+	}
+	for _, l := range sLicenses {
+		licenses = append(licenses, &api.Feature{
+			Name:     l.Name,
+			Quantity: l.Quantity,
+		})
+	}
+
 	return &api.WorkloadInfoResponse{
-		Name:    wlmName,
-		Version: sVersion,
-		Uid:     s.uid,
+		Name:     wlmName,
+		Version:  sVersion,
+		Uid:      s.uid,
+		Runtimes: s.runtimes,
+		Licenses: licenses,
 	}, nil
 }
 
+func (s *Slurm) jobInfo(jobID int64) ([]*slurm.JobInfo, error) {
+	if s.jobs == nil {
+		return s.client.SJobInfo(jobID)
+	}
+	return s.jobs.jobInfo(jobID)
+}
+
+// activeJobs returns active jobs, from the job cache when it is enabled.
+func (s *Slurm) activeJobs() ([]*slurm.JobInfo, error) {
+	if s.jobs == nil {
+		return s.client.SQueue()
+	}
+	return s.jobs.activeJobs()
+}
+
+func (s *Slurm) trackJob(jobID int64) {
+	if s.jobs != nil {
+		s.jobs.track(jobID)
+	}
+}
+
 func toProtoSteps(ss []*slurm.JobStepInfo) ([]*api.JobStepInfo, error) {
 	pSteps := make([]*api.JobStepInfo, len(ss))
 
@@ -334,16 +480,11 @@ func toProtoSteps(ss []*slurm.JobStepInfo) ([]*api.JobStepInfo, error) {
 			finishedAt = pt
 		}
 
-		status, ok := api.JobStatus_value[s.State]
-		if !ok {
-			status = int32(api.JobStatus_UNKNOWN)
-		}
-
 		pSteps[i] = &api.JobStepInfo{
 			Id:        s.ID,
 			Name:      s.Name,
 			ExitCode:  int32(s.ExitCode),
-			Status:    api.JobStatus(status),
+			Status:    toProtoStatus(s.State),
 			StartTime: startedAt,
 			EndTime:   finishedAt,
 		}
@@ -352,6 +493,24 @@ func toProtoSteps(ss []*slurm.JobStepInfo) ([]*api.JobStepInfo, error) {
 	return pSteps, nil
 }
 
+func toProtoUsage(uu []*slurm.JobStepUsage) []*api.JobStepUsage {
+	pUsage := make([]*api.JobStepUsage, len(uu))
+	for i, u := range uu {
+		pUsage[i] = &api.JobStepUsage{
+			Id:             u.ID,
+			Elapsed:        ptypes.DurationProto(u.Elapsed),
+			CpuTime:        ptypes.DurationProto(u.CPUTime),
+			MaxRss:         u.MaxRSS,
+			AveRss:         u.AveRSS,
+			MaxDiskRead:    u.MaxDiskRead,
+			MaxDiskWrite:   u.MaxDiskWrite,
+			ConsumedEnergy: u.ConsumedEnergy,
+			AllocTres:      u.AllocTRES,
+		}
+	}
+	return pUsage
+}
+
 func mapSInfoToProtoInfo(si []*slurm.JobInfo) ([]*api.JobInfo, error) {
 	pInfs := make([]*api.JobInfo, len(si))
 	for i, inf := range si {
@@ -385,17 +544,12 @@ func mapSInfoToProtoInfo(si []*slurm.JobInfo) ([]*api.JobInfo, error) {
 			timeLimit = ptypes.DurationProto(*inf.TimeLimit)
 		}
 
-		status, ok := api.JobStatus_value[inf.State]
-		if !ok {
-			status = int32(api.JobStatus_UNKNOWN)
-		}
-
 		pi := api.JobInfo{
 			Id:         inf.ID,
 			UserId:     inf.UserID,
 			Name:       inf.Name,
 			ExitCode:   inf.ExitCode,
-			Status:     api.JobStatus(status),
+			Status:     toProtoStatus(inf.State),
 			SubmitTime: submitTime,
 			StartTime:  startTime,
 			RunTime:    runTime,
@@ -408,6 +562,7 @@ func mapSInfoToProtoInfo(si []*slurm.JobInfo) ([]*api.JobInfo, error) {
 			BatchHost:  inf.BatchHost,
 			NumNodes:   inf.NumNodes,
 			ArrayId:    inf.ArrayJobID,
+			ClientId:   inf.Comment,
 		}
 		pInfs[i] = &pi
 	}
@@ -415,96 +570,38 @@ func mapSInfoToProtoInfo(si []*slurm.JobInfo) ([]*api.JobInfo, error) {
 	return pInfs, nil
 }
 
-func buildSLURMScript(r *api.SubmitJobContainerRequest) string {
-	const (
-		verifyT = `srun singularity verify "%s" || exit`
-		rmT     = `srun rm "%s"`
-
-		timeT       = `#SBATCH --time=0:%d` //seconds
-		memT        = `#SBATCH --mem=%d`    //mbs
-		nodesT      = `#SBATCH --nodes=%d`
-		cpuPerTaskT = `#SBATCH --cpus-per-task=%d`
-	)
-
-	runT := buildRunCommand(r.Options)
-
-	pullT := `srun singularity pull --name "%s" "%s" || exit` // secure pull
-	if r.Options.AllowUnsigned {
-		pullT = `srun singularity pull -U --name "%s" "%s" || exit` // unsecured pull
-	}
-
-	lines := []string{"#!/bin/sh"}
-
-	if r.WallTime != 0 {
-		lines = append(lines, fmt.Sprintf(timeT, r.WallTime))
-	}
-
-	if r.MemPerNode != 0 {
-		lines = append(lines, fmt.Sprintf(memT, r.MemPerNode))
-	}
-
-	if r.Nodes != 0 {
-		lines = append(lines, fmt.Sprintf(nodesT, r.Nodes))
-	}
-
-	if r.CpuPerNode != 0 {
-		lines = append(lines, fmt.Sprintf(cpuPerTaskT, r.CpuPerNode))
+// toProtoStatus converts slurm job state into proto job status.
+func toProtoStatus(state string) api.JobStatus {
+	status, ok := api.JobStatus_value[state]
+	if !ok {
+		return api.JobStatus_UNKNOWN
 	}
-
-	// checks if sif is located somewhere on the host machine
-	if strings.HasPrefix(r.ImageName, localFilePrefix) {
-		image := strings.TrimPrefix(r.ImageName, localFilePrefix)
-		if !r.Options.AllowUnsigned {
-			lines = append(lines, fmt.Sprintf(verifyT, image))
-		}
-		lines = append(lines, fmt.Sprintf(runT, image))
-	} else {
-		id := uuid.New().String()
-		lines = append(lines, fmt.Sprintf(pullT, id, r.ImageName))
-		lines = append(lines, fmt.Sprintf(runT, id))
-		lines = append(lines, fmt.Sprintf(rmT, id))
-	}
-
-	return strings.Join(lines, "\n")
+	return api.JobStatus(status)
 }
 
-func buildRunCommand(opt *api.SingularityOptions) string {
-	run := "srun singularity run"
-	flags := []string{}
-
-	if opt.App != "" {
-		flags = append(flags, fmt.Sprintf(`--app="%s"`, opt.App))
-	}
-	if opt.HostName != "" {
-		flags = append(flags, fmt.Sprintf(`--hostname="%s"`, opt.HostName))
+// toProtoNodeState converts slurm node state, e.g. MIXED+DRAIN or IDLE*, into
+// proto node state. Drained and not responding nodes are reported as DRAIN
+// and DOWN respectively regardless of their base state.
+func toProtoNodeState(state string) api.NodeState {
+	if strings.Contains(state, "DRAIN") {
+		return api.NodeState_DRAIN
 	}
-
-	if len(opt.Binds) != 0 {
-		bind := strings.Join(opt.Binds, ",")
-		flags = append(flags, fmt.Sprintf(`--bind="%s"`, bind))
+	if strings.HasSuffix(state, "*") {
+		return api.NodeState_DOWN
 	}
 
-	if opt.ClearEnv {
-		flags = append(flags, "-c")
+	if i := strings.IndexByte(state, '+'); i != -1 {
+		state = state[:i]
 	}
-	if opt.FakeRoot {
-		flags = append(flags, "-f")
-	}
-	if opt.Ipc {
-		flags = append(flags, "-i")
-	}
-	if opt.Pid {
-		flags = append(flags, "-p")
-	}
-	if opt.NoPrivs {
-		flags = append(flags, "--no-privs")
-	}
-	if opt.Writable {
-		flags = append(flags, "-w")
-	}
-
-	if len(flags) != 0 {
-		run = fmt.Sprintf("%s %s", run, strings.Join(flags, " "))
+	switch state {
+	case "IDLE":
+		return api.NodeState_IDLE
+	case "MIXED":
+		return api.NodeState_MIXED
+	case "ALLOCATED", "COMPLETING":
+		return api.NodeState_ALLOCATED
+	case "DOWN", "FAIL", "FAILING":
+		return api.NodeState_DOWN
 	}
-	return run + " " + `"%s" || exit`
+	return api.NodeState_NODE_UNKNOWN
 }
@@ -103,21 +103,25 @@ func Test_mapSStepsToProtoSteps(t *testing.T) {
 	}
 }
 
-func Test_buildRunCommand(t *testing.T) {
-	f := func(o *api.SingularityOptions, expected string) {
-		require.EqualValues(t, expected, buildRunCommand(o))
+func Test_toProtoNodeState(t *testing.T) {
+	tests := []struct {
+		in   string
+		want api.NodeState
+	}{
+		{in: "IDLE", want: api.NodeState_IDLE},
+		{in: "MIXED", want: api.NodeState_MIXED},
+		{in: "ALLOCATED", want: api.NodeState_ALLOCATED},
+		{in: "COMPLETING", want: api.NodeState_ALLOCATED},
+		{in: "IDLE+DRAIN", want: api.NodeState_DRAIN},
+		{in: "MIXED+DRAIN", want: api.NodeState_DRAIN},
+		{in: "DOWN", want: api.NodeState_DOWN},
+		{in: "IDLE*", want: api.NodeState_DOWN},
+		{in: "DOWN+DRAIN", want: api.NodeState_DRAIN},
+		{in: "FUTURE", want: api.NodeState_NODE_UNKNOWN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, toProtoNodeState(tt.in))
+		})
 	}
-
-	f(&api.SingularityOptions{}, `srun singularity run "%s" || exit`)
-	f(&api.SingularityOptions{
-		ClearEnv: true,
-		FakeRoot: true,
-		Ipc:      true,
-		Pid:      true,
-		NoPrivs:  true,
-		Writable: true,
-		HostName: "test1",
-		App:      "main",
-		Binds:    []string{"b1", "b2"},
-	}, `srun singularity run --app="main" --hostname="test1" --bind="b1,b2" -c -f -i -p --no-privs -w "%s" || exit`)
 }
@@ -0,0 +1,123 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ResultOK is a result of a successful action.
+const ResultOK = "ok"
+
+type (
+	// Config configures audit log.
+	Config struct {
+		// Path is a path to audit log file. Empty path disables audit.
+		Path string `yaml:"path"`
+		// MaxSizeMB is a size in megabytes after which log file is rotated.
+		// Zero value means no rotation.
+		MaxSizeMB int64 `yaml:"max_size_mb"`
+		// MaxBackups is a number of rotated files to keep. It must be
+		// positive when rotation is enabled, so records are never dropped
+		// by rotation before at least one backup is made.
+		MaxBackups int `yaml:"max_backups"`
+	}
+
+	// Record is a single audit log entry.
+	Record struct {
+		Time         time.Time `json:"time"`
+		Caller       string    `json:"caller"`
+		ClientID     string    `json:"client_id,omitempty"`
+		RPC          string    `json:"rpc"`
+		JobID        int64     `json:"job_id,omitempty"`
+		Partition    string    `json:"partition,omitempty"`
+		Path         string    `json:"path,omitempty"`
+		Result       string    `json:"result"`
+		ScriptSHA256 string    `json:"script_sha256,omitempty"`
+	}
+
+	// Logger writes audit records as JSON lines. Nil Logger is valid
+	// and discards all records, which is used when audit is disabled.
+	Logger struct {
+		mu sync.Mutex
+		w  *rotatingFile
+	}
+)
+
+// NewLogger creates a new audit logger with respect to the passed config.
+// When config has no path set nil Logger is returned.
+func NewLogger(cfg Config) (*Logger, error) {
+	if cfg.Path == "" {
+		return nil, nil
+	}
+
+	w, err := openRotatingFile(cfg.Path, cfg.MaxSizeMB<<20, cfg.MaxBackups)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open audit log")
+	}
+	return &Logger{w: w}, nil
+}
+
+// Log writes a record to the audit log. Time and caller identity
+// are filled automatically, result is derived from the passed error.
+func (l *Logger) Log(ctx context.Context, r Record, err error) {
+	if l == nil {
+		return
+	}
+
+	r.Time = time.Now().UTC()
+	r.Caller = Caller(ctx)
+	r.Result = ResultOK
+	if err != nil {
+		r.Result = err.Error()
+	}
+
+	b, mErr := json.Marshal(r)
+	if mErr != nil {
+		log.Printf("Could not marshal audit record: %v", mErr)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, wErr := l.w.Write(append(b, '\n')); wErr != nil {
+		log.Printf("Could not write audit record: %v", wErr)
+	}
+}
+
+// Close closes underlying log file.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Close()
+}
+
+// ScriptHash returns hex encoded SHA-256 of the passed script.
+func ScriptHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestLogger_Log(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	l, err := NewLogger(Config{Path: path})
+	require.NoError(t, err)
+
+	l.Log(context.Background(), Record{
+		RPC:          "SubmitJob",
+		ClientID:     "client",
+		JobID:        42,
+		Partition:    "debug",
+		ScriptSHA256: ScriptHash("#!/bin/sh"),
+	}, nil)
+	l.Log(context.Background(), Record{RPC: "CancelJob", JobID: 42}, errors.New("failed"))
+	require.NoError(t, l.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []Record
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var r Record
+		require.NoError(t, json.Unmarshal(s.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, s.Err())
+	require.Len(t, records, 2)
+
+	require.Equal(t, "SubmitJob", records[0].RPC)
+	require.Equal(t, "client", records[0].ClientID)
+	require.EqualValues(t, 42, records[0].JobID)
+	require.Equal(t, "debug", records[0].Partition)
+	require.Equal(t, ResultOK, records[0].Result)
+	require.Equal(t, unknownCaller, records[0].Caller)
+	require.Equal(t, ScriptHash("#!/bin/sh"), records[0].ScriptSHA256)
+	require.False(t, records[0].Time.IsZero())
+
+	require.Equal(t, "CancelJob", records[1].RPC)
+	require.Equal(t, "failed", records[1].Result)
+}
+
+func TestLogger_Nil(t *testing.T) {
+	l, err := NewLogger(Config{})
+	require.NoError(t, err)
+	require.Nil(t, l)
+
+	l.Log(context.Background(), Record{RPC: "SubmitJob"}, nil)
+	require.NoError(t, l.Close())
+}
+
+func TestCaller(t *testing.T) {
+	require.Equal(t, unknownCaller, Caller(context.Background()))
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 42}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	require.Equal(t, unknownCaller, Caller(ctx))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{
+		Addr: addr,
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "vk"}}},
+		}},
+	})
+	require.Equal(t, "cn=vk addr=10.0.0.1:42", Caller(ctx))
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	rf, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, l := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := rf.Write([]byte(l))
+		require.NoError(t, err)
+	}
+	require.NoError(t, rf.Close())
+
+	expect := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for p, content := range expect {
+		b, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		require.Equal(t, content, string(b))
+	}
+
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestRotatingFile_rotateError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	rf, err := openRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	defer rf.Close()
+
+	// backup can't be replaced with a non-empty directory in its place
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0700))
+
+	_, err = rf.Write([]byte("first\n"))
+	require.NoError(t, err)
+	_, err = rf.Write([]byte("second\n"))
+	require.Error(t, err)
+	_, err = rf.Write([]byte("third\n"))
+	require.Error(t, err)
+
+	// records are kept in the current file until rotation succeeds
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\nthird\n", string(b))
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = rf.Write([]byte("fourth\n"))
+	require.NoError(t, err)
+	b, err = ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\nthird\n", string(b))
+	b, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "fourth\n", string(b))
+}
+
+func TestOpenRotatingFile_noBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = openRotatingFile(filepath.Join(dir, "audit.log"), 10, 0)
+	require.Error(t, err)
+
+	rf, err := openRotatingFile(filepath.Join(dir, "audit.log"), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, rf.Close())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// unknownCaller is used when caller identity cannot be determined.
+const unknownCaller = "unknown"
+
+// Listener wraps unix socket listener so that accepted connections
+// carry identity of the connected process, which can be fetched with Caller later.
+func Listener(ln net.Listener) net.Listener {
+	return &credListener{Listener: ln}
+}
+
+// Caller returns identity of the process that issued gRPC request, e.g. "uid=1000 pid=42".
+// Identity is known only for connections accepted by a listener returned from Listener and
+// for TLS connections, which callers are identified by client certificate, e.g. "cn=vk addr=10.0.0.1:42".
+func Caller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return unknownCaller
+	}
+
+	if a, ok := p.Addr.(*credAddr); ok {
+		return a.identity
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) != 0 {
+		return fmt.Sprintf("cn=%s addr=%s", info.State.PeerCertificates[0].Subject.CommonName, p.Addr)
+	}
+	return unknownCaller
+}
+
+type credListener struct {
+	net.Listener
+}
+
+// Accept waits for and returns the next connection with the peer identity attached.
+func (l *credListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := unknownCaller
+	if uc, ok := c.(*net.UnixConn); ok {
+		if cred, err := peerCred(uc); err == nil {
+			identity = cred
+		}
+	}
+
+	return &credConn{
+		Conn: c,
+		addr: &credAddr{Addr: c.RemoteAddr(), identity: identity},
+	}, nil
+}
+
+type credConn struct {
+	net.Conn
+	addr *credAddr
+}
+
+// RemoteAddr returns remote address with peer identity attached.
+func (c *credConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+type credAddr struct {
+	net.Addr
+	identity string
+}
+
+// String returns peer identity.
+func (a *credAddr) String() string {
+	return fmt.Sprintf("%s(%s)", a.Addr.String(), a.identity)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// rotatingFile is an append-only file that is rotated once its size
+// exceeds the limit. Rotated files are named <path>.1, <path>.2 and so on,
+// where <path>.1 is the most recent one. It is not safe for concurrent use.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	f    *os.File
+	size int64
+}
+
+// openRotatingFile opens file at path for appending. When maxSize is set, at least one
+// backup must be kept, as dropping the whole file on rotation would lose audit records.
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if maxSize > 0 && maxBackups <= 0 {
+		return nil, errors.New("at least one backup must be kept when rotation is enabled")
+	}
+
+	rf := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	f, size, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+	rf.f, rf.size = f, size
+	return rf, nil
+}
+
+// Write appends p to the file rotating it beforehand if needed. When rotation
+// fails p is still appended to the current file, so that no record is lost,
+// and rotation error is returned.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	var rotateErr error
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		rotateErr = rf.rotate()
+	}
+
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, errors.Wrap(rotateErr, "could not rotate file")
+}
+
+// Close closes current file.
+func (rf *rotatingFile) Close() error {
+	return rf.f.Close()
+}
+
+func openAppend(path string) (*os.File, int64, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, fi.Size(), nil
+}
+
+// rotate shifts backups and starts a new file. Current file is kept open until
+// the new one is opened, so on any error writes continue to the current file.
+func (rf *rotatingFile) rotate() error {
+	for i := rf.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(rf.backupName(i), rf.backupName(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(rf.path, rf.backupName(1)); err != nil {
+		return err
+	}
+
+	f, size, err := openAppend(rf.path)
+	if err != nil {
+		return err
+	}
+	if err := rf.f.Close(); err != nil {
+		log.Printf("Could not close rotated audit log: %v", err)
+	}
+	rf.f, rf.size = f, size
+	return nil
+}
+
+func (rf *rotatingFile) backupName(i int) string {
+	return fmt.Sprintf("%s.%d", rf.path, i)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServiceName is a name of the workload manager service used in health checks.
+const ServiceName = "api.WorkloadManager"
+
+// Pinger checks whether workload manager is reachable.
+type Pinger interface {
+	Ping() error
+}
+
+// Checker periodically checks workload manager availability
+// and reflects it in the gRPC health server.
+type Checker struct {
+	pinger   Pinger
+	srv      *health.Server
+	interval time.Duration
+
+	status healthpb.HealthCheckResponse_ServingStatus
+}
+
+// NewChecker creates a new Checker that will update srv status every interval.
+func NewChecker(p Pinger, srv *health.Server, interval time.Duration) *Checker {
+	return &Checker{
+		pinger:   p,
+		srv:      srv,
+		interval: interval,
+	}
+}
+
+// Run starts checking workload manager availability till ctx is done.
+// Until the first check is completed red-box is considered not serving.
+func (c *Checker) Run(ctx context.Context) {
+	c.srv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	c.srv.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	t := time.NewTicker(c.interval)
+	defer t.Stop()
+
+	c.check()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			c.check()
+		}
+	}
+}
+
+func (c *Checker) check() {
+	err := c.pinger.Ping()
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if status == c.status {
+		return
+	}
+
+	if err != nil {
+		log.Printf("Workload manager is unreachable: %v", err)
+	} else {
+		log.Printf("Workload manager is reachable")
+	}
+	c.status = status
+	// empty service name stands for the overall server health
+	c.srv.SetServingStatus("", status)
+	c.srv.SetServingStatus(ServiceName, status)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (p *fakePinger) Ping() error {
+	return p.err
+}
+
+func TestChecker(t *testing.T) {
+	p := &fakePinger{err: errors.New("down")}
+	srv := health.NewServer()
+	c := NewChecker(p, srv, 0)
+
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: ServiceName})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	c.check()
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status())
+
+	p.err = nil
+	c.check()
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, status())
+
+	p.err = errors.New("down again")
+	c.check()
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status())
+}
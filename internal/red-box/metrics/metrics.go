@@ -0,0 +1,202 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "redbox"
+
+// jobTTL is a time after which job that was not observed is considered
+// lost, e.g. cancelled outside of red-box, and is no longer tracked.
+const jobTTL = time.Hour
+
+var (
+	// RequestsTotal counts handled gRPC requests per method and status code.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Total number of handled gRPC requests.",
+	}, []string{"method", "code"})
+
+	// RequestDuration observes gRPC request latencies per method.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of handled gRPC requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// ActiveStreams shows number of currently open gRPC streams per method,
+	// e.g. active TailFile and OpenFile calls.
+	ActiveStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "active_streams",
+		Help:      "Number of currently open gRPC streams.",
+	}, []string{"method"})
+
+	// ExecDuration observes Slurm binaries execution time per command.
+	ExecDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "slurm",
+		Name:      "exec_duration_seconds",
+		Help:      "Execution time of Slurm binaries.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"command"})
+
+	// ExecFailures counts failed Slurm binaries executions per command.
+	ExecFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "slurm",
+		Name:      "exec_failures_total",
+		Help:      "Total number of failed Slurm binaries executions.",
+	}, []string{"command"})
+
+	// Jobs is a global job state tracker.
+	Jobs = NewJobTracker()
+)
+
+// MustRegister registers all red-box collectors in the passed registerer.
+func MustRegister(r prometheus.Registerer) {
+	r.MustRegister(
+		RequestsTotal,
+		RequestDuration,
+		ActiveStreams,
+		ExecDuration,
+		ExecFailures,
+		Jobs,
+	)
+}
+
+// Handler returns http handler that exposes metrics collected by the passed gatherer.
+func Handler(g prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(g, promhttp.HandlerOpts{})
+}
+
+// ObserveExec records single Slurm binary execution. Its signature
+// matches slurm.ExecHook so it can be passed to slurm client directly.
+func ObserveExec(bin string, took time.Duration, err error) {
+	ExecDuration.WithLabelValues(bin).Observe(took.Seconds())
+	if err != nil {
+		ExecFailures.WithLabelValues(bin).Inc()
+	}
+}
+
+// JobTracker keeps the last observed state of each job that is not finished yet
+// and exposes number of jobs in each state. Once job reaches a terminal state
+// it is no longer tracked and is accounted in finished jobs counter instead.
+// Jobs that are not observed for jobTTL are dropped.
+type JobTracker struct {
+	mu   sync.Mutex
+	jobs map[int64]trackedJob
+	ttl  time.Duration
+	now  func() time.Time
+
+	active   *prometheus.Desc
+	finished *prometheus.CounterVec
+}
+
+type trackedJob struct {
+	state    string
+	observed time.Time
+}
+
+// NewJobTracker returns a new empty JobTracker.
+func NewJobTracker() *JobTracker {
+	return &JobTracker{
+		jobs: make(map[int64]trackedJob),
+		ttl:  jobTTL,
+		now:  time.Now,
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "jobs"),
+			"Number of not finished jobs by state.",
+			[]string{"state"}, nil,
+		),
+		finished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "jobs_finished_total",
+			Help:      "Total number of finished jobs by final state.",
+		}, []string{"state"}),
+	}
+}
+
+// Observe records job state.
+func (t *JobTracker) Observe(jobID int64, state string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !IsTerminalState(state) {
+		t.jobs[jobID] = trackedJob{state: state, observed: t.now()}
+		return
+	}
+
+	if _, ok := t.jobs[jobID]; ok {
+		delete(t.jobs, jobID)
+		t.finished.WithLabelValues(state).Inc()
+	}
+}
+
+// Forget stops tracking job without accounting it as finished,
+// e.g. when job is no longer known to slurm.
+func (t *JobTracker) Forget(jobID int64) {
+	t.mu.Lock()
+	delete(t.jobs, jobID)
+	t.mu.Unlock()
+}
+
+// Describe implements prometheus.Collector.
+func (t *JobTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.active
+	t.finished.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (t *JobTracker) Collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	states := make(map[string]int)
+	expired := t.now().Add(-t.ttl)
+	for id, j := range t.jobs {
+		if j.observed.Before(expired) {
+			delete(t.jobs, id)
+			continue
+		}
+		states[j.state]++
+	}
+	t.mu.Unlock()
+
+	for s, n := range states {
+		ch <- prometheus.MustNewConstMetric(t.active, prometheus.GaugeValue, float64(n), s)
+	}
+	t.finished.Collect(ch)
+}
+
+// IsTerminalState checks whether Slurm job state is final.
+func IsTerminalState(state string) bool {
+	switch state {
+	case "COMPLETED", "CANCELLED", "FAILED", "TIMEOUT",
+		"BOOT_FAIL", "DEADLINE", "NODE_FAIL", "OUT_OF_MEMORY", "PREEMPTED":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobTracker(t *testing.T) {
+	tracker := NewJobTracker()
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(tracker))
+
+	tracker.Observe(1, "PENDING")
+	tracker.Observe(2, "PENDING")
+	tracker.Observe(2, "RUNNING")
+	tracker.Observe(3, "RUNNING")
+	tracker.Observe(3, "COMPLETED")
+	// not tracked job should not be accounted
+	tracker.Observe(4, "FAILED")
+
+	values := gather(t, reg)
+	require.Equal(t, map[string]float64{"PENDING": 1, "RUNNING": 1}, values["redbox_jobs"])
+	require.Equal(t, map[string]float64{"COMPLETED": 1}, values["redbox_jobs_finished_total"])
+}
+
+func TestJobTracker_expire(t *testing.T) {
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewJobTracker()
+	tracker.now = func() time.Time { return now }
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(tracker))
+
+	tracker.Observe(1, "PENDING")
+	tracker.Observe(2, "RUNNING")
+	tracker.Observe(3, "RUNNING")
+	now = now.Add(jobTTL / 2)
+	tracker.Observe(2, "RUNNING")
+	tracker.Forget(3)
+	now = now.Add(jobTTL/2 + time.Second)
+
+	// job 1 is lost, it is dropped but not accounted as finished
+	values := gather(t, reg)
+	require.Equal(t, map[string]float64{"RUNNING": 1}, values["redbox_jobs"])
+	require.Empty(t, values["redbox_jobs_finished_total"])
+
+	tracker.Observe(1, "COMPLETED")
+	values = gather(t, reg)
+	require.Empty(t, values["redbox_jobs_finished_total"])
+}
+
+func gather(t *testing.T, g prometheus.Gatherer) map[string]map[string]float64 {
+	mfs, err := g.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]map[string]float64)
+	for _, mf := range mfs {
+		values[mf.GetName()] = make(map[string]float64)
+		for _, m := range mf.GetMetric() {
+			state := m.GetLabel()[0].GetValue()
+			if m.GetGauge() != nil {
+				values[mf.GetName()][state] = m.GetGauge().GetValue()
+			} else {
+				values[mf.GetName()][state] = m.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sylabs/wlm-operator/internal/red-box/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is a gRPC metadata key that holds request ID. When client
+// sets it, the passed value is used, otherwise a new ID is generated.
+// Request ID is always sent back to client in response header.
+const RequestIDKey = "x-request-id"
+
+type requestIDCtxKey struct{}
+
+// RequestID returns request ID stored in the context, if any.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// ServerOptions returns gRPC server options that set up interceptor chain
+// which assigns request IDs, recovers from panics, logs requests and collects metrics.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(ChainUnary(
+			UnaryRequestID,
+			UnaryLogging,
+			UnaryMetrics,
+			UnaryRecovery,
+		)),
+		grpc.StreamInterceptor(ChainStream(
+			StreamRequestID,
+			StreamLogging,
+			StreamMetrics,
+			StreamRecovery,
+		)),
+	}
+}
+
+// ChainUnary creates a single unary interceptor out of many. Interceptors
+// are executed in the passed order, i.e. the first one is the outermost.
+func ChainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			chained = bindUnary(interceptors[i], info, chained)
+		}
+		return chained(ctx, req)
+	}
+}
+
+func bindUnary(i grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return i(ctx, req, info, h)
+	}
+}
+
+// ChainStream creates a single stream interceptor out of many. Interceptors
+// are executed in the passed order, i.e. the first one is the outermost.
+func ChainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			chained = bindStream(interceptors[i], info, chained)
+		}
+		return chained(srv, ss)
+	}
+}
+
+func bindStream(i grpc.StreamServerInterceptor, info *grpc.StreamServerInfo, h grpc.StreamHandler) grpc.StreamHandler {
+	return func(srv interface{}, ss grpc.ServerStream) error {
+		return i(srv, ss, info, h)
+	}
+}
+
+// UnaryRequestID assigns request ID to each unary call.
+func UnaryRequestID(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	ctx = withRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, RequestID(ctx)))
+	return handler(ctx, req)
+}
+
+// StreamRequestID assigns request ID to each stream.
+func StreamRequestID(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	ctx := withRequestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDKey, RequestID(ctx)))
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// UnaryLogging logs each unary call along with its duration and result.
+func UnaryLogging(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logRequest(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamLogging logs each stream along with its duration and result.
+func StreamLogging(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	start := time.Now()
+	err := handler(srv, ss)
+	logRequest(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+// UnaryMetrics collects request count and latency for each unary call.
+func UnaryMetrics(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamMetrics collects request count, latency and number of active streams.
+func StreamMetrics(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	active := metrics.ActiveStreams.WithLabelValues(info.FullMethod)
+	active.Inc()
+	defer active.Dec()
+
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRequest(info.FullMethod, start, err)
+	return err
+}
+
+// UnaryRecovery converts panics in unary handlers into Internal errors.
+func UnaryRecovery(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// StreamRecovery converts panics in stream handlers into Internal errors.
+func StreamRecovery(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) != 0 {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+func logRequest(ctx context.Context, method string, start time.Time, err error) {
+	if err != nil {
+		log.Printf("request_id=%s method=%s duration=%s code=%s error=%q",
+			RequestID(ctx), method, time.Since(start), status.Code(err), err)
+		return
+	}
+	log.Printf("request_id=%s method=%s duration=%s code=%s",
+		RequestID(ctx), method, time.Since(start), codes.OK)
+}
+
+func observeRequest(method string, start time.Time, err error) {
+	metrics.RequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	metrics.RequestsTotal.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+func recovered(ctx context.Context, method string, r interface{}) error {
+	log.Printf("request_id=%s method=%s panic=%q\n%s", RequestID(ctx), method, r, debug.Stack())
+	return status.Errorf(codes.Internal, "panic while handling %s: %v", method, r)
+}
+
+// serverStream overrides context of the wrapped grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns stream context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
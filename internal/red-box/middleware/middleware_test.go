@@ -0,0 +1,75 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestChainUnary(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{},
+			info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	chain := ChainUnary(interceptor("first"), interceptor("second"), interceptor("third"))
+	resp, err := chain(context.Background(), "req", &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			return req, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, "req", resp)
+	require.Equal(t, []string{"first", "second", "third", "handler"}, calls)
+}
+
+func TestUnaryRecovery(t *testing.T) {
+	_, err := UnaryRecovery(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/api.Test/Panic"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("oops")
+		})
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestStreamRecovery(t *testing.T) {
+	err := StreamRecovery(nil, &serverStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/api.Test/Panic"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			panic("oops")
+		})
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestWithRequestID(t *testing.T) {
+	ctx := withRequestID(context.Background())
+	require.NotEmpty(t, RequestID(ctx))
+
+	md := metadata.Pairs(RequestIDKey, "test-id")
+	ctx = withRequestID(metadata.NewIncomingContext(context.Background(), md))
+	require.Equal(t, "test-id", RequestID(ctx))
+}
@@ -16,6 +16,7 @@ package apis
 
 import (
 	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -24,7 +25,7 @@ var AddToSchemes runtime.SchemeBuilder
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
-	AddToSchemes = append(AddToSchemes, v1alpha1.SchemeBuilder.AddToScheme)
+	AddToSchemes = append(AddToSchemes, v1alpha1.SchemeBuilder.AddToScheme, v1beta1.SchemeBuilder.AddToScheme)
 }
 
 // AddToScheme adds all Resources to the Scheme
@@ -0,0 +1,153 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SlurmClusterSpec defines the desired state of SlurmCluster.
+// +k8s:openapi-gen=true
+type SlurmClusterSpec struct {
+	// Endpoint is a red-box address. Unix socket is specified as unix:///path/to/red-box.sock,
+	// any other value is treated as a TCP host:port address red-box serves with --listen flag.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// TLSSecret is a name of a secret in the SlurmCluster namespace that holds TLS credentials
+	// used to connect to red-box: ca.crt, tls.crt and tls.key for client authentication.
+	// It is required for TCP endpoint, unix socket is connected in plain text.
+	TLSSecret string `json:"tlsSecret,omitempty"`
+
+	// Partitions selects cluster partitions that are represented by virtual nodes.
+	// All partitions are selected by default.
+	Partitions PartitionSelector `json:"partitions,omitempty"`
+
+	// NodeLabels are additional labels set on each virtual node of the cluster.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are additional taints set on each virtual node of the cluster.
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+
+	// PodTemplate overrides virtual kubelet pod defaults.
+	PodTemplate VirtualKubeletTemplate `json:"podTemplate,omitempty"`
+
+	// PodTemplatePatch is a YAML or JSON strategic merge patch of virtual kubelet pod. It is applied
+	// after PodTemplate overrides and configurator config map patch, e.g. to change probes or volumes.
+	// Changing it replaces virtual kubelet pods one by one.
+	PodTemplatePatch string `json:"podTemplatePatch,omitempty"`
+}
+
+// PartitionSelector selects partitions by name. Both include and exclude
+// lists accept shell patterns, e.g. gpu-*. Exclude list takes precedence.
+// +k8s:openapi-gen=true
+type PartitionSelector struct {
+	// Include lists partitions to be represented by virtual nodes. Empty list includes all partitions.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude lists partitions not to be represented by virtual nodes.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// VirtualKubeletTemplate holds virtual kubelet pod overrides.
+// +k8s:openapi-gen=true
+type VirtualKubeletTemplate struct {
+	// Image is a virtual kubelet image. Configurator default is used when empty.
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy is a virtual kubelet image pull policy.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ServiceAccountName is a name of the service account virtual kubelet pods run with.
+	// Configurator service account is used when empty.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Resources are compute resources required by virtual kubelet container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector is a selector which must match a node's labels for virtual kubelet pods
+	// to be scheduled on that node.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are virtual kubelet pods tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Env lists additional environment variables set in virtual kubelet container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// SlurmClusterStatus defines the observed state of a SlurmCluster.
+// +k8s:openapi-gen=true
+type SlurmClusterStatus struct {
+	// Healthy is true when red-box is reachable and reports Slurm is available.
+	Healthy bool `json:"healthy"`
+
+	// Message describes the last cluster error, if any.
+	Message string `json:"message,omitempty"`
+
+	// Partitions lists partitions that are represented by virtual nodes.
+	Partitions []string `json:"partitions,omitempty"`
+
+	// Licenses lists cluster licenses, they are shared by all partitions.
+	Licenses []ClusterLicense `json:"licenses,omitempty"`
+
+	// LastProbeTime is the last time cluster health was checked.
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// ObservedGeneration is the most recent SlurmCluster generation observed by configurator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ClusterLicense is a Slurm cluster license.
+// +k8s:openapi-gen=true
+type ClusterLicense struct {
+	// Name is a license name.
+	Name string `json:"name"`
+
+	// Total is a number of licenses configured.
+	Total int64 `json:"total,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlurmCluster is the Schema for the slurmclusters API.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=sc
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint",description="red-box address"
+// +kubebuilder:printcolumn:name="Healthy",type="boolean",JSONPath=".status.healthy",description="whether cluster is reachable"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type SlurmCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmClusterSpec   `json:"spec,omitempty"`
+	Status SlurmClusterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlurmClusterList contains a list of SlurmCluster.
+type SlurmClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmCluster{}, &SlurmClusterList{})
+}
@@ -52,6 +52,12 @@ type SlurmJobStatus struct {
 
 	// Status reflects job status, e.g running, succeeded.
 	Status string `json:"status"`
+
+	// Usage reflects resources consumed by job. It is reported once job is finished.
+	Usage *JobUsage `json:"usage,omitempty"`
+
+	// Conditions reflect job conditions that prevent it from being processed.
+	Conditions []JobCondition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
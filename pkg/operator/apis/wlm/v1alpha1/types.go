@@ -14,7 +14,19 @@
 
 package v1alpha1
 
-import v1 "k8s.io/api/core/v1"
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobConditionType is a type of a job condition.
+type JobConditionType string
+
+const (
+	// JobUnschedulable means job companion pod cannot be created because
+	// job spec contradicts itself, e.g. SBATCH partition differs from node selector.
+	JobUnschedulable JobConditionType = "Unschedulable"
+)
 
 // JobResults is a schema for results collection.
 // +k8s:openapi-gen=true
@@ -26,3 +38,43 @@ type JobResults struct {
 	// From is a path to the results to be collected from a Slurm cluster.
 	From string `json:"from"`
 }
+
+// JobUsage is a schema for job resources usage reported by WLM accounting.
+// +k8s:openapi-gen=true
+type JobUsage struct {
+	// CPUTime is total CPU time consumed by job, in seconds.
+	CPUTime int64 `json:"cpuTime,omitempty"`
+	// Elapsed is job wall time, in seconds.
+	Elapsed int64 `json:"elapsed,omitempty"`
+	// MaxRSS is maximum resident set size among job steps, in bytes.
+	MaxRSS int64 `json:"maxRSS,omitempty"`
+	// AveRSS is maximum average resident set size among job steps, in bytes.
+	AveRSS int64 `json:"aveRSS,omitempty"`
+	// MaxDiskRead is maximum number of bytes read among job steps.
+	MaxDiskRead int64 `json:"maxDiskRead,omitempty"`
+	// MaxDiskWrite is maximum number of bytes written among job steps.
+	MaxDiskWrite int64 `json:"maxDiskWrite,omitempty"`
+	// ConsumedEnergy is energy consumed by job, in joules.
+	ConsumedEnergy int64 `json:"consumedEnergy,omitempty"`
+	// AllocTRES is a set of trackable resources allocated to job, e.g. cpu=2, mem=1G.
+	AllocTRES map[string]string `json:"allocTRES,omitempty"`
+	// CPUEfficiency is a ratio of consumed to allocated CPU time, in percent.
+	CPUEfficiency int32 `json:"cpuEfficiency,omitempty"`
+	// MemoryEfficiency is a ratio of maximum resident set size to allocated memory, in percent.
+	MemoryEfficiency int32 `json:"memoryEfficiency,omitempty"`
+}
+
+// JobCondition describes the state of a job at a certain point.
+// +k8s:openapi-gen=true
+type JobCondition struct {
+	// Type of job condition.
+	Type JobConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status v1.ConditionStatus `json:"status"`
+	// Reason is a one-word CamelCase reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about last transition.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,12 @@ type WlmJob struct {
 type WlmJobStatus struct {
 	// Status reflects job status, e.g running, succeeded.
 	Status string `json:"status"`
+
+	// Usage reflects resources consumed by job. It is reported once job is finished.
+	Usage *JobUsage `json:"usage,omitempty"`
+
+	// Conditions reflect job conditions that prevent it from being processed.
+	Conditions []JobCondition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -61,9 +68,41 @@ type WlmJobSpec struct {
 	// Image name to start as a job.
 	Image string `json:"image"`
 
-	// Options singularity run options.
+	// Runtime is a container runtime the image is run with, singularity by default.
+	// +kubebuilder:validation:Enum=singularity,apptainer,enroot,podman-hpc,charliecloud
+	Runtime ContainerRuntime `json:"runtime,omitempty"`
+
+	// Command overrides image entrypoint. When set, singularity and apptainer
+	// run the command with exec instead of image runscript.
+	Command []string `json:"command,omitempty"`
+
+	// Args are arguments passed to the command or to image runscript.
+	Args []string `json:"args,omitempty"`
+
+	// Env lists environment variables to set in container. Values may
+	// be taken only from ConfigMaps and Secrets of the job namespace.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom lists ConfigMaps and Secrets to populate container environment
+	// variables with. Values defined by Env take precedence.
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Options singularity run options. They are used by singularity and apptainer runtimes.
 	Options SingularityOptions `json:"options,omitempty"`
 
+	// Enroot options are used by enroot runtime.
+	Enroot *EnrootOptions `json:"enroot,omitempty"`
+
+	// PodmanHPC options are used by podman-hpc runtime.
+	PodmanHPC *PodmanHPCOptions `json:"podmanHPC,omitempty"`
+
+	// Charliecloud options are used by charliecloud runtime.
+	Charliecloud *CharliecloudOptions `json:"charliecloud,omitempty"`
+
+	// MPI describes task layout of an MPI job. When set, image is
+	// started as ntasks MPI ranks with srun --mpi.
+	MPI *MPIOptions `json:"mpi,omitempty"`
+
 	// Resources describes required resources for a job.
 	Resources WlmResources `json:"resources,omitempty"`
 
@@ -112,6 +151,100 @@ type SingularityOptions struct {
 	Binds []string `json:"binds,omitempty"`
 }
 
+// ContainerRuntime is a container runtime available on WLM cluster.
+type ContainerRuntime string
+
+// Supported container runtimes.
+const (
+	// RuntimeSingularity runs image with singularity.
+	RuntimeSingularity ContainerRuntime = "singularity"
+	// RuntimeApptainer runs image with apptainer.
+	RuntimeApptainer ContainerRuntime = "apptainer"
+	// RuntimeEnroot runs image with enroot using pyxis srun plugin.
+	RuntimeEnroot ContainerRuntime = "enroot"
+	// RuntimePodmanHPC runs image with podman-hpc.
+	RuntimePodmanHPC ContainerRuntime = "podman-hpc"
+	// RuntimeCharliecloud runs image with charliecloud.
+	RuntimeCharliecloud ContainerRuntime = "charliecloud"
+)
+
+// EnrootOptions are pyxis srun options. Image is passed to pyxis
+// as is, e.g. nvcr.io#nvidia/pytorch:23.05, or as a docker:// reference.
+// +k8s:openapi-gen=true
+type EnrootOptions struct {
+	// Command to run in container. Pyxis doesn't start image
+	// default command, so it's required.
+	Command []string `json:"command"`
+	// Mounts are bind mounts in the format src:dest[:flags],
+	// where flags are enroot mount flags, e.g. ro.
+	Mounts []string `json:"mounts,omitempty"`
+	// Workdir is a working directory inside container.
+	Workdir string `json:"workdir,omitempty"`
+	// MountHome mounts user home directory into container.
+	MountHome bool `json:"mountHome,omitempty"`
+	// RemapRoot makes user root inside container.
+	RemapRoot bool `json:"remapRoot,omitempty"`
+	// Writable makes container file system writable.
+	Writable bool `json:"writable,omitempty"`
+}
+
+// PodmanHPCOptions are podman-hpc run options.
+// +k8s:openapi-gen=true
+type PodmanHPCOptions struct {
+	// Volumes are bind mounts in the format src:dest[:opts].
+	Volumes []string `json:"volumes,omitempty"`
+	// Workdir is a working directory inside container.
+	Workdir string `json:"workdir,omitempty"`
+	// GPU enables GPU support in container.
+	GPU bool `json:"gpu,omitempty"`
+	// MPI enables MPI support in container.
+	MPI bool `json:"mpi,omitempty"`
+}
+
+// CharliecloudOptions are ch-run options. Image is pulled into
+// charliecloud builder storage unless it's a local image.
+// +k8s:openapi-gen=true
+type CharliecloudOptions struct {
+	// Command to run in container. Charliecloud doesn't
+	// start image default command, so it's required.
+	Command []string `json:"command"`
+	// Binds are bind mounts in the format src[:dest].
+	Binds []string `json:"binds,omitempty"`
+	// Writable makes container file system writable.
+	Writable bool `json:"writable,omitempty"`
+	// SetEnv sets environment variables from image metadata.
+	SetEnv bool `json:"setEnv,omitempty"`
+}
+
+// MPIType is a slurm MPI plugin that starts MPI ranks.
+type MPIType string
+
+// Supported MPI plugins.
+const (
+	// MPIPMIx starts ranks with PMIx.
+	MPIPMIx MPIType = "pmix"
+	// MPIPMI2 starts ranks with PMI2.
+	MPIPMI2 MPIType = "pmi2"
+	// MPINone starts ranks without MPI plugin, e.g. for MPI
+	// implementations that bootstrap themselves.
+	MPINone MPIType = "none"
+)
+
+// MPIOptions describes MPI job tasks. Image is run with the hybrid model,
+// where MPI library inside container is wired up by host slurm plugin.
+// +k8s:openapi-gen=true
+type MPIOptions struct {
+	// Ntasks is a number of MPI ranks.
+	// +kubebuilder:validation:Minimum=1
+	Ntasks int64 `json:"ntasks"`
+	// TasksPerNode is a maximum number of ranks on each node.
+	// +kubebuilder:validation:Minimum=1
+	TasksPerNode int64 `json:"tasksPerNode,omitempty"`
+	// Type is slurm MPI plugin, cluster MpiDefault is used when not set.
+	// +kubebuilder:validation:Enum=pmix,pmi2,none
+	Type MPIType `json:"type,omitempty"`
+}
+
 // WlmResources is a schema for wlm resources.
 // +k8s:openapi-gen=true
 type WlmResources struct {
@@ -0,0 +1,90 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WlmJobDefaultSpec defines defaults applied to SlurmJobs and WlmJobs on creation.
+// Only fields that are missing in a job are set.
+// +k8s:openapi-gen=true
+type WlmJobDefaultSpec struct {
+	// NamespaceSelector selects namespaces defaults are applied in.
+	// Defaults are applied in all namespaces when selector is not set.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// NodeSelector labels are added to job node selector unless job sets them.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Results is used for jobs that don't set up results collection. Jobs that set
+	// results path without a mount, or vice versa, get the missing part from defaults.
+	Results *JobResults `json:"results,omitempty"`
+
+	// Resources fill missing WlmJob resources. For SlurmJobs nodes, memory per node
+	// and wall time are added as SBATCH directives unless batch script requests them.
+	Resources WlmResources `json:"resources,omitempty"`
+
+	// Runtime is a container runtime of WlmJobs that don't set it.
+	// +kubebuilder:validation:Enum=singularity,apptainer,enroot,podman-hpc,charliecloud
+	Runtime ContainerRuntime `json:"runtime,omitempty"`
+
+	// Options are singularity options of WlmJobs.
+	Options *SingularityOptionsDefaults `json:"options,omitempty"`
+}
+
+// SingularityOptionsDefaults are default singularity options. Since unset boolean options cannot be
+// told apart from false ones, boolean options that are set are enforced, e.g. allowUnsigned=false
+// disallows unsigned images for every WlmJob in selected namespaces.
+// +k8s:openapi-gen=true
+type SingularityOptionsDefaults struct {
+	// AllowUnsigned enforces pulling and running unsigned images to be allowed or not.
+	AllowUnsigned *bool `json:"allowUnsigned,omitempty"`
+	// CleanEnv enforces cleaning environment before running container.
+	CleanEnv *bool `json:"cleanEnv,omitempty"`
+	// NoPrivs enforces dropping all privileges from root user in container.
+	NoPrivs *bool `json:"noPrivs,omitempty"`
+	// Binds are added to job binds unless job binds the same source.
+	Binds []string `json:"binds,omitempty"`
+}
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmJobDefault is the Schema for the wlm job defaults API. When several defaults select
+// a namespace, they are applied in order of their names and the first set value wins.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=wjd
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WlmJobDefault struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WlmJobDefaultSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmJobDefaultList contains a list of WlmJobDefault.
+type WlmJobDefaultList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WlmJobDefault `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WlmJobDefault{}, &WlmJobDefaultList{})
+}
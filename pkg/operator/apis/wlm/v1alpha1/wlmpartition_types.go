@@ -0,0 +1,113 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WlmPartitionSpec identifies a WLM partition mirrored by WlmPartition.
+// It is set by configurator and should not be changed.
+// +k8s:openapi-gen=true
+type WlmPartitionSpec struct {
+	// Cluster is a name of the cluster partition belongs to.
+	Cluster string `json:"cluster"`
+
+	// Partition is a WLM partition name.
+	Partition string `json:"partition"`
+
+	// Node is a name of the virtual node that represents partition.
+	Node string `json:"node"`
+}
+
+// WlmPartitionStatus reflects the last observed WLM partition state.
+// +k8s:openapi-gen=true
+type WlmPartitionStatus struct {
+	// State is a partition state, e.g. UP, DOWN or DRAIN.
+	State string `json:"state,omitempty"`
+
+	// MaxTime is a maximum job wall time in seconds, 0 means unlimited.
+	MaxTime int64 `json:"maxTime,omitempty"`
+
+	// MaxNodes is a maximum number of nodes a job may use.
+	MaxNodes int64 `json:"maxNodes,omitempty"`
+
+	// CPUPerNode is a number of CPUs available on each node.
+	CPUPerNode int64 `json:"cpuPerNode,omitempty"`
+
+	// MemPerNode is an amount of memory in megabytes available on each node.
+	MemPerNode int64 `json:"memPerNode,omitempty"`
+
+	// Features lists partition node features and generic resources.
+	Features []PartitionFeature `json:"features,omitempty"`
+
+	// PendingJobs is a number of jobs waiting in partition queue.
+	PendingJobs int64 `json:"pendingJobs"`
+
+	// RunningJobs is a number of jobs running in partition.
+	RunningJobs int64 `json:"runningJobs"`
+
+	// LastUpdateTime is the last time status was updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// PartitionFeature is a single partition feature.
+// +k8s:openapi-gen=true
+type PartitionFeature struct {
+	// Name is a feature name. Generic resources are named with gres/ prefix, e.g. gres/gpu.
+	Name string `json:"name"`
+
+	// Version is a feature version or a generic resource type.
+	Version string `json:"version,omitempty"`
+
+	// Quantity is a number of nodes having the feature, or a
+	// total amount of generic resource.
+	Quantity int64 `json:"quantity,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmPartition is a read-only mirror of a WLM partition.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=wp
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Partition",type="string",JSONPath=".spec.partition",description="WLM partition name"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="partition state"
+// +kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.maxNodes",description="maximum number of nodes per job"
+// +kubebuilder:printcolumn:name="Max Time",type="integer",JSONPath=".status.maxTime",description="maximum job wall time in seconds"
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pendingJobs",description="number of pending jobs"
+// +kubebuilder:printcolumn:name="Running",type="integer",JSONPath=".status.runningJobs",description="number of running jobs"
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.node",description="virtual node name"
+type WlmPartition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WlmPartitionSpec   `json:"spec,omitempty"`
+	Status WlmPartitionStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmPartitionList contains a list of WlmPartition.
+type WlmPartitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WlmPartition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WlmPartition{}, &WlmPartitionList{})
+}
@@ -19,9 +19,96 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CharliecloudOptions) DeepCopyInto(out *CharliecloudOptions) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Binds != nil {
+		in, out := &in.Binds, &out.Binds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CharliecloudOptions.
+func (in *CharliecloudOptions) DeepCopy() *CharliecloudOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CharliecloudOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLicense) DeepCopyInto(out *ClusterLicense) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLicense.
+func (in *ClusterLicense) DeepCopy() *ClusterLicense {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLicense)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrootOptions) DeepCopyInto(out *EnrootOptions) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrootOptions.
+func (in *EnrootOptions) DeepCopy() *EnrootOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrootOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCondition) DeepCopyInto(out *JobCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCondition.
+func (in *JobCondition) DeepCopy() *JobCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobResults) DeepCopyInto(out *JobResults) {
 	*out = *in
@@ -39,6 +126,108 @@ func (in *JobResults) DeepCopy() *JobResults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobUsage) DeepCopyInto(out *JobUsage) {
+	*out = *in
+	if in.AllocTRES != nil {
+		in, out := &in.AllocTRES, &out.AllocTRES
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobUsage.
+func (in *JobUsage) DeepCopy() *JobUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(JobUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIOptions) DeepCopyInto(out *MPIOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIOptions.
+func (in *MPIOptions) DeepCopy() *MPIOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionFeature) DeepCopyInto(out *PartitionFeature) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionFeature.
+func (in *PartitionFeature) DeepCopy() *PartitionFeature {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionFeature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionSelector) DeepCopyInto(out *PartitionSelector) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionSelector.
+func (in *PartitionSelector) DeepCopy() *PartitionSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodmanHPCOptions) DeepCopyInto(out *PodmanHPCOptions) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodmanHPCOptions.
+func (in *PodmanHPCOptions) DeepCopy() *PodmanHPCOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodmanHPCOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingularityOptions) DeepCopyInto(out *SingularityOptions) {
 	*out = *in
@@ -60,13 +249,172 @@ func (in *SingularityOptions) DeepCopy() *SingularityOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingularityOptionsDefaults) DeepCopyInto(out *SingularityOptionsDefaults) {
+	*out = *in
+	if in.AllowUnsigned != nil {
+		in, out := &in.AllowUnsigned, &out.AllowUnsigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CleanEnv != nil {
+		in, out := &in.CleanEnv, &out.CleanEnv
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NoPrivs != nil {
+		in, out := &in.NoPrivs, &out.NoPrivs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Binds != nil {
+		in, out := &in.Binds, &out.Binds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingularityOptionsDefaults.
+func (in *SingularityOptionsDefaults) DeepCopy() *SingularityOptionsDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(SingularityOptionsDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmCluster) DeepCopyInto(out *SlurmCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmCluster.
+func (in *SlurmCluster) DeepCopy() *SlurmCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmClusterList) DeepCopyInto(out *SlurmClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmClusterList.
+func (in *SlurmClusterList) DeepCopy() *SlurmClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmClusterSpec) DeepCopyInto(out *SlurmClusterSpec) {
+	*out = *in
+	in.Partitions.DeepCopyInto(&out.Partitions)
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmClusterSpec.
+func (in *SlurmClusterSpec) DeepCopy() *SlurmClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmClusterStatus) DeepCopyInto(out *SlurmClusterStatus) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Licenses != nil {
+		in, out := &in.Licenses, &out.Licenses
+		*out = make([]ClusterLicense, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmClusterStatus.
+func (in *SlurmClusterStatus) DeepCopy() *SlurmClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlurmJob) DeepCopyInto(out *SlurmJob) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -152,6 +500,18 @@ func (in *SlurmJobSpec) DeepCopy() *SlurmJobSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlurmJobStatus) DeepCopyInto(out *SlurmJobStatus) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(JobUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]JobCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -165,13 +525,51 @@ func (in *SlurmJobStatus) DeepCopy() *SlurmJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualKubeletTemplate) DeepCopyInto(out *VirtualKubeletTemplate) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualKubeletTemplate.
+func (in *VirtualKubeletTemplate) DeepCopy() *VirtualKubeletTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualKubeletTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WlmJob) DeepCopyInto(out *WlmJob) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -193,6 +591,105 @@ func (in *WlmJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJobDefault) DeepCopyInto(out *WlmJobDefault) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJobDefault.
+func (in *WlmJobDefault) DeepCopy() *WlmJobDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJobDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmJobDefault) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJobDefaultList) DeepCopyInto(out *WlmJobDefaultList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WlmJobDefault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJobDefaultList.
+func (in *WlmJobDefaultList) DeepCopy() *WlmJobDefaultList {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJobDefaultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmJobDefaultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJobDefaultSpec) DeepCopyInto(out *WlmJobDefaultSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(JobResults)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Resources = in.Resources
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = new(SingularityOptionsDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJobDefaultSpec.
+func (in *WlmJobDefaultSpec) DeepCopy() *WlmJobDefaultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJobDefaultSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WlmJobList) DeepCopyInto(out *WlmJobList) {
 	*out = *in
@@ -229,7 +726,51 @@ func (in *WlmJobList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WlmJobSpec) DeepCopyInto(out *WlmJobSpec) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Options.DeepCopyInto(&out.Options)
+	if in.Enroot != nil {
+		in, out := &in.Enroot, &out.Enroot
+		*out = new(EnrootOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodmanHPC != nil {
+		in, out := &in.PodmanHPC, &out.PodmanHPC
+		*out = new(PodmanHPCOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Charliecloud != nil {
+		in, out := &in.Charliecloud, &out.Charliecloud
+		*out = new(CharliecloudOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MPI != nil {
+		in, out := &in.MPI, &out.MPI
+		*out = new(MPIOptions)
+		**out = **in
+	}
 	out.Resources = in.Resources
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
@@ -259,6 +800,18 @@ func (in *WlmJobSpec) DeepCopy() *WlmJobSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WlmJobStatus) DeepCopyInto(out *WlmJobStatus) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(JobUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]JobCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -272,6 +825,105 @@ func (in *WlmJobStatus) DeepCopy() *WlmJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmPartition) DeepCopyInto(out *WlmPartition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmPartition.
+func (in *WlmPartition) DeepCopy() *WlmPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmPartition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmPartitionList) DeepCopyInto(out *WlmPartitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WlmPartition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmPartitionList.
+func (in *WlmPartitionList) DeepCopy() *WlmPartitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmPartitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmPartitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmPartitionSpec) DeepCopyInto(out *WlmPartitionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmPartitionSpec.
+func (in *WlmPartitionSpec) DeepCopy() *WlmPartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmPartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmPartitionStatus) DeepCopyInto(out *WlmPartitionStatus) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]PartitionFeature, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmPartitionStatus.
+func (in *WlmPartitionStatus) DeepCopy() *WlmPartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmPartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WlmResources) DeepCopyInto(out *WlmResources) {
 	*out = *in
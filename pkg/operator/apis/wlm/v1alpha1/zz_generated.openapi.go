@@ -27,69 +27,130 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults":         schema_operator_apis_wlm_v1alpha1_JobResults(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions": schema_operator_apis_wlm_v1alpha1_SingularityOptions(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJob":           schema_operator_apis_wlm_v1alpha1_SlurmJob(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec":       schema_operator_apis_wlm_v1alpha1_SlurmJobSpec(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus":     schema_operator_apis_wlm_v1alpha1_SlurmJobStatus(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJob":             schema_operator_apis_wlm_v1alpha1_WlmJob(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec":         schema_operator_apis_wlm_v1alpha1_WlmJobSpec(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus":       schema_operator_apis_wlm_v1alpha1_WlmJobStatus(ref),
-		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources":       schema_operator_apis_wlm_v1alpha1_WlmResources(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.CharliecloudOptions":        schema_operator_apis_wlm_v1alpha1_CharliecloudOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.ClusterLicense":             schema_operator_apis_wlm_v1alpha1_ClusterLicense(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.EnrootOptions":              schema_operator_apis_wlm_v1alpha1_EnrootOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobCondition":               schema_operator_apis_wlm_v1alpha1_JobCondition(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults":                 schema_operator_apis_wlm_v1alpha1_JobResults(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobUsage":                   schema_operator_apis_wlm_v1alpha1_JobUsage(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.MPIOptions":                 schema_operator_apis_wlm_v1alpha1_MPIOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionFeature":           schema_operator_apis_wlm_v1alpha1_PartitionFeature(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionSelector":          schema_operator_apis_wlm_v1alpha1_PartitionSelector(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PodmanHPCOptions":           schema_operator_apis_wlm_v1alpha1_PodmanHPCOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions":         schema_operator_apis_wlm_v1alpha1_SingularityOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptionsDefaults": schema_operator_apis_wlm_v1alpha1_SingularityOptionsDefaults(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmCluster":               schema_operator_apis_wlm_v1alpha1_SlurmCluster(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterSpec":           schema_operator_apis_wlm_v1alpha1_SlurmClusterSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterStatus":         schema_operator_apis_wlm_v1alpha1_SlurmClusterStatus(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJob":                   schema_operator_apis_wlm_v1alpha1_SlurmJob(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec":               schema_operator_apis_wlm_v1alpha1_SlurmJobSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus":             schema_operator_apis_wlm_v1alpha1_SlurmJobStatus(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.VirtualKubeletTemplate":     schema_operator_apis_wlm_v1alpha1_VirtualKubeletTemplate(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJob":                     schema_operator_apis_wlm_v1alpha1_WlmJob(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobDefault":              schema_operator_apis_wlm_v1alpha1_WlmJobDefault(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobDefaultSpec":          schema_operator_apis_wlm_v1alpha1_WlmJobDefaultSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec":                 schema_operator_apis_wlm_v1alpha1_WlmJobSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus":               schema_operator_apis_wlm_v1alpha1_WlmJobStatus(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartition":               schema_operator_apis_wlm_v1alpha1_WlmPartition(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionSpec":           schema_operator_apis_wlm_v1alpha1_WlmPartitionSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionStatus":         schema_operator_apis_wlm_v1alpha1_WlmPartitionStatus(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources":               schema_operator_apis_wlm_v1alpha1_WlmResources(ref),
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_JobResults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_CharliecloudOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "JobResults is a schema for results collection.",
+				Description: "CharliecloudOptions are ch-run options. Image is pulled into charliecloud builder storage unless it's a local image.",
 				Properties: map[string]spec.Schema{
-					"mount": {
+					"command": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Mount is a directory where job results will be stored. After results collection all job generated files can be found in Mount/<SlurmJob.Name> directory.",
-							Ref:         ref("k8s.io/api/core/v1.Volume"),
+							Description: "Command to run in container. Charliecloud doesn't start image default command, so it's required.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"from": {
+					"binds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "From is a path to the results to be collected from a Slurm cluster.",
-							Type:        []string{"string"},
+							Description: "Binds are bind mounts in the format src[:dest].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"writable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Writable makes container file system writable.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"setEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SetEnv sets environment variables from image metadata.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"mount", "from"},
+				Required: []string{"command"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/api/core/v1.Volume"},
+		Dependencies: []string{},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_SingularityOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_ClusterLicense(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SingularityOptions singularity run options.",
+				Description: "ClusterLicense is a Slurm cluster license.",
 				Properties: map[string]spec.Schema{
-					"app": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Set an application to run inside a container.",
+							Description: "Name is a license name.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"allowUnsigned": {
+					"total": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Allow to pull and run unsigned images.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Total is a number of licenses configured.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"binds": {
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_EnrootOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EnrootOptions are pyxis srun options. Image is passed to pyxis as is, e.g. nvcr.io#nvidia/pytorch:23.05, or as a docker:// reference.",
+				Properties: map[string]spec.Schema{
+					"command": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Binds a user-bind path specification. Spec has the format src[:dest[:opts]], where src and dest are outside and inside paths.  If dest is not given, it is set equal to src. Mount options ('opts') may be specified as 'ro' (read-only) or 'rw' (read/write, which is the default). Multiple bind paths can be given by a comma separated list.",
+							Description: "Command to run in container. Pyxis doesn't start image default command, so it's required.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -101,121 +162,191 @@ func schema_operator_apis_wlm_v1alpha1_SingularityOptions(ref common.ReferenceCa
 							},
 						},
 					},
-					"cleanEnv": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Clean environment before running container.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"fakeRoot": {
+					"mounts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Run container in new user namespace as uid 0.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Mounts are bind mounts in the format src:dest[:flags], where flags are enroot mount flags, e.g. ro.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"hostName": {
+					"workdir": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Set container hostname.",
+							Description: "Workdir is a working directory inside container.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"ipc": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Run container in a new IPC namespace.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"pid": {
+					"mountHome": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Run container in a new PID namespace.",
+							Description: "MountHome mounts user home directory into container.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"noPrivs": {
+					"remapRoot": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Drop all privileges from root user in container.",
+							Description: "RemapRoot makes user root inside container.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 					"writable": {
 						SchemaProps: spec.SchemaProps{
-							Description: "By default all Singularity containers are available as read only. This option makes the file system accessible as read/write.",
+							Description: "Writable makes container file system writable.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"command"},
 			},
 		},
 		Dependencies: []string{},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_SlurmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_JobCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SlurmJob is the Schema for the slurmjobs API.",
+				Description: "JobCondition describes the state of a job at a certain point.",
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Description: "Type of job condition.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Description: "Status of the condition, one of True, False, Unknown.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"reason": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Reason is a one-word CamelCase reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"spec": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec"),
+							Description: "Message is a human readable message indicating details about last transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"status": {
+					"lastTransitionTime": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus"),
+							Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"type", "status"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_SlurmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_JobResults(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SlurmJobSpec defines the desired state of SlurmJob",
+				Description: "JobResults is a schema for results collection.",
 				Properties: map[string]spec.Schema{
-					"batch": {
+					"mount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Batch is a script that will be submitted to a Slurm cluster as a batch job.",
+							Description: "Mount is a directory where job results will be stored. After results collection all job generated files can be found in Mount/<SlurmJob.Name> directory.",
+							Ref:         ref("k8s.io/api/core/v1.Volume"),
+						},
+					},
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is a path to the results to be collected from a Slurm cluster.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"nodeSelector": {
+				},
+				Required: []string{"mount", "from"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Volume"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_JobUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobUsage is a schema for job resources usage reported by WLM accounting.",
+				Properties: map[string]spec.Schema{
+					"cpuTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector is a selector which must be true for the SlurmJob to fit on a node. Selector which must match a node's labels for the SlurmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
+							Description: "CPUTime is total CPU time consumed by job, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"elapsed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Elapsed is job wall time, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRSS is maximum resident set size among job steps, in bytes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"aveRSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AveRSS is maximum average resident set size among job steps, in bytes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxDiskRead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDiskRead is maximum number of bytes read among job steps.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxDiskWrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDiskWrite is maximum number of bytes written among job steps.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"consumedEnergy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsumedEnergy is energy consumed by job, in joules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"allocTRES": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllocTRES is a set of trackable resources allocated to job, e.g. cpu=2, mem=1G.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Schema: &spec.Schema{
@@ -227,115 +358,973 @@ func schema_operator_apis_wlm_v1alpha1_SlurmJobSpec(ref common.ReferenceCallback
 							},
 						},
 					},
-					"results": {
+					"cpuEfficiency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Results may be specified for an optional results collection step. When specified, after job is completed all results will be downloaded from Slurm cluster with respect to this configuration.",
-							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults"),
+							Description: "CPUEfficiency is a ratio of consumed to allocated CPU time, in percent.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"memoryEfficiency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryEfficiency is a ratio of maximum resident set size to allocated memory, in percent.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
-				Required: []string{"batch"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults"},
+		Dependencies: []string{},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_SlurmJobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_MPIOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "SlurmJobStatus defines the observed state of a SlurmJob.",
+				Description: "MPIOptions describes MPI job tasks. Image is run with the hybrid model, where MPI library inside container is wired up by host slurm plugin.",
 				Properties: map[string]spec.Schema{
-					"status": {
+					"ntasks": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status reflects job status, e.g running, succeeded.",
+							Description: "Ntasks is a number of MPI ranks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tasksPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TasksPerNode is a maximum number of ranks on each node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is slurm MPI plugin, cluster MpiDefault is used when not set.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"status"},
+				Required: []string{"ntasks"},
 			},
 		},
 		Dependencies: []string{},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_WlmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_PartitionFeature(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WlmJob is the Schema for the wlm jobs API.",
+				Description: "PartitionFeature is a single partition feature.",
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Description: "Name is a feature name. Generic resources are named with gres/ prefix, e.g. gres/gpu.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Description: "Version is a feature version or a generic resource type.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
-						},
-					},
-					"spec": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec"),
-						},
-					},
-					"status": {
+					"quantity": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus"),
+							Description: "Quantity is a number of nodes having the feature, or a total amount of generic resource.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
 				},
+				Required: []string{"name"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+		Dependencies: []string{},
 	}
 }
 
-func schema_operator_apis_wlm_v1alpha1_WlmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_operator_apis_wlm_v1alpha1_PartitionSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WlmJobSpec defines the desired state of WlmJob.",
+				Description: "PartitionSelector selects partitions by name. Both include and exclude lists accept shell patterns, e.g. gpu-*. Exclude list takes precedence.",
 				Properties: map[string]spec.Schema{
-					"image": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Image name to start as a job.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"options": {
+					"include": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Options singularity run options.",
-							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions"),
-						},
-					},
-					"resources": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Resources describes required resources for a job.",
-							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources"),
-						},
-					},
-					"nodeSelector": {
-						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector is a selector which must be true for the WlmJob to fit on a node. Selector which must match a node's labels for the WlmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
+							Description: "Include lists partitions to be represented by virtual nodes. Empty list includes all partitions.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude lists partitions not to be represented by virtual nodes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_PodmanHPCOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodmanHPCOptions are podman-hpc run options.",
+				Properties: map[string]spec.Schema{
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes are bind mounts in the format src:dest[:opts].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"workdir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workdir is a working directory inside container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"gpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GPU enables GPU support in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"mpi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MPI enables MPI support in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SingularityOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SingularityOptions singularity run options.",
+				Properties: map[string]spec.Schema{
+					"allowUnsigned": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Allow to pull and run unsigned images.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cleanEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clean environment before running container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fakeRoot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in new user namespace as uid 0.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ipc": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in a new IPC namespace.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in a new PID namespace.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"noPrivs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Drop all privileges from root user in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"writable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "By default all Singularity containers are available as read only. This option makes the file system accessible as read/write.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"app": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set an application to run inside a container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set container hostname.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"binds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binds a user-bind path specification. Spec has the format src[:dest[:opts]], where src and dest are outside and inside paths.  If dest is not given, it is set equal to src. Mount options ('opts') may be specified as 'ro' (read-only) or 'rw' (read/write, which is the default). Multiple bind paths can be given by a comma separated list.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SingularityOptionsDefaults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SingularityOptionsDefaults are default singularity options. Since unset boolean options cannot be told apart from false ones, boolean options that are set are enforced, e.g. allowUnsigned=false disallows unsigned images for every WlmJob in selected namespaces.",
+				Properties: map[string]spec.Schema{
+					"allowUnsigned": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowUnsigned enforces pulling and running unsigned images to be allowed or not.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cleanEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CleanEnv enforces cleaning environment before running container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"noPrivs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NoPrivs enforces dropping all privileges from root user in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"binds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binds are added to job binds unless job binds the same source.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmCluster is the Schema for the slurmclusters API.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmClusterSpec defines the desired state of SlurmCluster.",
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint is a red-box address. Unix socket is specified as unix:///path/to/red-box.sock, any other value is treated as a TCP host:port address red-box serves with --listen flag.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tlsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSSecret is a name of a secret in the SlurmCluster namespace that holds TLS credentials used to connect to red-box: ca.crt, tls.crt and tls.key for client authentication. It is required for TCP endpoint, unix socket is connected in plain text.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"partitions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Partitions selects cluster partitions that are represented by virtual nodes. All partitions are selected by default.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionSelector"),
+						},
+					},
+					"nodeLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeLabels are additional labels set on each virtual node of the cluster.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"nodeTaints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeTaints are additional taints set on each virtual node of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Taint"),
+									},
+								},
+							},
+						},
+					},
+					"podTemplate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodTemplate overrides virtual kubelet pod defaults.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.VirtualKubeletTemplate"),
+						},
+					},
+					"podTemplatePatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodTemplatePatch is a YAML or JSON strategic merge patch of virtual kubelet pod. It is applied after PodTemplate overrides and configurator config map patch, e.g. to change probes or volumes. Changing it replaces virtual kubelet pods one by one.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"endpoint"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionSelector", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.VirtualKubeletTemplate", "k8s.io/api/core/v1.Taint"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmClusterStatus defines the observed state of a SlurmCluster.",
+				Properties: map[string]spec.Schema{
+					"healthy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Healthy is true when red-box is reachable and reports Slurm is available.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes the last cluster error, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"partitions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Partitions lists partitions that are represented by virtual nodes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"licenses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Licenses lists cluster licenses, they are shared by all partitions.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.ClusterLicense"),
+									},
+								},
+							},
+						},
+					},
+					"lastProbeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastProbeTime is the last time cluster health was checked.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent SlurmCluster generation observed by configurator.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"healthy"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.ClusterLicense", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmJob is the Schema for the slurmjobs API.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SlurmJobStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmJobSpec defines the desired state of SlurmJob",
+				Properties: map[string]spec.Schema{
+					"batch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Batch is a script that will be submitted to a Slurm cluster as a batch job.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the SlurmJob to fit on a node. Selector which must match a node's labels for the SlurmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results may be specified for an optional results collection step. When specified, after job is completed all results will be downloaded from Slurm cluster with respect to this configuration.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults"),
+						},
+					},
+				},
+				Required: []string{"batch"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_SlurmJobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmJobStatus defines the observed state of a SlurmJob.",
+				Properties: map[string]spec.Schema{
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status reflects job status, e.g running, succeeded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage reflects resources consumed by job. It is reported once job is finished.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobUsage"),
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions reflect job conditions that prevent it from being processed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobCondition"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobCondition", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobUsage"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_VirtualKubeletTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualKubeletTemplate holds virtual kubelet pod overrides.",
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is a virtual kubelet image. Configurator default is used when empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy is a virtual kubelet image pull policy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountName is a name of the service account virtual kubelet pods run with. Configurator service account is used when empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources are compute resources required by virtual kubelet container.",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must match a node's labels for virtual kubelet pods to be scheduled on that node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations are virtual kubelet pods tolerations.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env lists additional environment variables set in virtual kubelet container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJob is the Schema for the wlm jobs API.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmJobDefault(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJobDefault is the Schema for the wlm job defaults API. When several defaults select a namespace, they are applied in order of their names and the first set value wins.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobDefaultSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmJobDefaultSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmJobDefaultSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJobDefaultSpec defines defaults applied to SlurmJobs and WlmJobs on creation. Only fields that are missing in a job are set.",
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects namespaces defaults are applied in. Defaults are applied in all namespaces when selector is not set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector labels are added to job node selector unless job sets them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results is used for jobs that don't set up results collection. Jobs that set results path without a mount, or vice versa, get the missing part from defaults.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources fill missing WlmJob resources. For SlurmJobs nodes, memory per node and wall time are added as SBATCH directives unless batch script requests them.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources"),
+						},
+					},
+					"runtime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runtime is a container runtime of WlmJobs that don't set it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"options": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Options are singularity options of WlmJobs.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptionsDefaults"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptionsDefaults", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJobSpec defines the desired state of WlmJob.",
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image name to start as a job.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runtime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runtime is a container runtime the image is run with, singularity by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command overrides image entrypoint. When set, singularity and apptainer run the command with exec instead of image runscript.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are arguments passed to the command or to image runscript.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env lists environment variables to set in container. Values may be taken only from ConfigMaps and Secrets of the job namespace.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvFrom lists ConfigMaps and Secrets to populate container environment variables with. Values defined by Env take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"options": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Options singularity run options. They are used by singularity and apptainer runtimes.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions"),
+						},
+					},
+					"enroot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enroot options are used by enroot runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.EnrootOptions"),
+						},
+					},
+					"podmanHPC": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodmanHPC options are used by podman-hpc runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PodmanHPCOptions"),
+						},
+					},
+					"charliecloud": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Charliecloud options are used by charliecloud runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.CharliecloudOptions"),
+						},
+					},
+					"mpi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MPI describes task layout of an MPI job. When set, image is started as ntasks MPI ranks with srun --mpi.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.MPIOptions"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources describes required resources for a job.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the WlmJob to fit on a node. Selector which must match a node's labels for the WlmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Type:   []string{"string"},
@@ -356,7 +1345,7 @@ func schema_operator_apis_wlm_v1alpha1_WlmJobSpec(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources"},
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.CharliecloudOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.EnrootOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobResults", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.MPIOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PodmanHPCOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.SingularityOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmResources", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar"},
 	}
 }
 
@@ -373,14 +1362,195 @@ func schema_operator_apis_wlm_v1alpha1_WlmJobStatus(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage reflects resources consumed by job. It is reported once job is finished.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobUsage"),
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions reflect job conditions that prevent it from being processed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobCondition"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"status"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobCondition", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.JobUsage"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmPartition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmPartition is a read-only mirror of a WLM partition.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionSpec", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.WlmPartitionStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1alpha1_WlmPartitionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmPartitionSpec identifies a WLM partition mirrored by WlmPartition. It is set by configurator and should not be changed.",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is a name of the cluster partition belongs to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"partition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Partition is a WLM partition name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node is a name of the virtual node that represents partition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "partition", "node"},
+			},
+		},
 		Dependencies: []string{},
 	}
 }
 
+func schema_operator_apis_wlm_v1alpha1_WlmPartitionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmPartitionStatus reflects the last observed WLM partition state.",
+				Properties: map[string]spec.Schema{
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is a partition state, e.g. UP, DOWN or DRAIN.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxTime is a maximum job wall time in seconds, 0 means unlimited.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxNodes is a maximum number of nodes a job may use.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cpuPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUPerNode is a number of CPUs available on each node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemPerNode is an amount of memory in megabytes available on each node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"features": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Features lists partition node features and generic resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionFeature"),
+									},
+								},
+							},
+						},
+					},
+					"pendingJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingJobs is a number of jobs waiting in partition queue.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"runningJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RunningJobs is a number of jobs running in partition.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the last time status was updated.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"pendingJobs", "runningJobs"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1.PartitionFeature", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_operator_apis_wlm_v1alpha1_WlmResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
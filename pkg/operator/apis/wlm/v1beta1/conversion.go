@@ -0,0 +1,430 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// v1alpha1 is the storage version, so v1beta1 objects are converted to and from it.
+// Memory in v1alpha1 is in megabytes and time is in seconds, so quantities and durations
+// are rounded up to whole megabytes and seconds respectively. Original WlmJob resources
+// are kept in an annotation of v1alpha1 object, so that they are not changed by round trip.
+
+const (
+	megabyte = 1 << 20
+
+	// resourcesAnnotation keeps v1beta1 memory and wall time of WlmJob stored as v1alpha1.
+	resourcesAnnotation = "wlm.sylabs.io/v1beta1-resources"
+)
+
+// ConvertTo converts SlurmJob to the v1alpha1 version.
+func (in *SlurmJob) ConvertTo(out *v1alpha1.SlurmJob) {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = v1alpha1.SlurmJobSpec{
+		Batch:        in.Spec.Batch,
+		NodeSelector: copyMap(in.Spec.NodeSelector),
+		Results:      resultsTo(in.Spec.Results),
+	}
+	out.Status = v1alpha1.SlurmJobStatus{
+		Status:     string(in.Status.Phase),
+		Usage:      usageTo(in.Status.Usage),
+		Conditions: conditionsTo(in.Status.Conditions),
+	}
+}
+
+// ConvertFrom converts SlurmJob from the v1alpha1 version.
+func (out *SlurmJob) ConvertFrom(in *v1alpha1.SlurmJob) {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = SlurmJobSpec{
+		Batch:        in.Spec.Batch,
+		NodeSelector: copyMap(in.Spec.NodeSelector),
+		Results:      resultsFrom(in.Spec.Results),
+	}
+	out.Status = JobStatus{
+		Phase:      JobPhase(in.Status.Status),
+		Usage:      usageFrom(in.Status.Usage),
+		Conditions: conditionsFrom(in.Status.Conditions),
+	}
+}
+
+// ConvertTo converts WlmJob to the v1alpha1 version.
+func (in *WlmJob) ConvertTo(out *v1alpha1.WlmJob) {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = v1alpha1.WlmJobSpec{
+		Image:        in.Spec.Image,
+		Runtime:      v1alpha1.ContainerRuntime(in.Spec.Runtime),
+		Command:      copySlice(in.Spec.Command),
+		Args:         copySlice(in.Spec.Args),
+		Env:          copyEnv(in.Spec.Env),
+		EnvFrom:      copyEnvFrom(in.Spec.EnvFrom),
+		Options:      v1alpha1.SingularityOptions(in.Spec.Options),
+		Enroot:       enrootTo(in.Spec.Enroot),
+		PodmanHPC:    podmanHPCTo(in.Spec.PodmanHPC),
+		Charliecloud: charliecloudTo(in.Spec.Charliecloud),
+		MPI:          mpiTo(in.Spec.MPI),
+		Resources: v1alpha1.WlmResources{
+			Nodes:      in.Spec.Resources.Nodes,
+			CPUPerNode: in.Spec.Resources.CPUPerNode,
+			MemPerNode: ceilDiv(quantityValue(in.Spec.Resources.MemPerNode), megabyte),
+			WallTime:   seconds(in.Spec.Resources.WallTime),
+		},
+		NodeSelector: copyMap(in.Spec.NodeSelector),
+		Results:      resultsTo(in.Spec.Results),
+	}
+	out.Spec.Options.Binds = copySlice(in.Spec.Options.Binds)
+	out.Annotations = resourcesAnnotationTo(out.Annotations, in.Spec.Resources)
+	out.Status = v1alpha1.WlmJobStatus{
+		Status:     string(in.Status.Phase),
+		Usage:      usageTo(in.Status.Usage),
+		Conditions: conditionsTo(in.Status.Conditions),
+	}
+}
+
+// ConvertFrom converts WlmJob from the v1alpha1 version.
+func (out *WlmJob) ConvertFrom(in *v1alpha1.WlmJob) {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = WlmJobSpec{
+		Image:        in.Spec.Image,
+		Runtime:      ContainerRuntime(in.Spec.Runtime),
+		Command:      copySlice(in.Spec.Command),
+		Args:         copySlice(in.Spec.Args),
+		Env:          copyEnv(in.Spec.Env),
+		EnvFrom:      copyEnvFrom(in.Spec.EnvFrom),
+		Options:      SingularityOptions(in.Spec.Options),
+		Enroot:       enrootFrom(in.Spec.Enroot),
+		PodmanHPC:    podmanHPCFrom(in.Spec.PodmanHPC),
+		Charliecloud: charliecloudFrom(in.Spec.Charliecloud),
+		MPI:          mpiFrom(in.Spec.MPI),
+		Resources: WlmResources{
+			Nodes:      in.Spec.Resources.Nodes,
+			CPUPerNode: in.Spec.Resources.CPUPerNode,
+			MemPerNode: binaryQuantity(in.Spec.Resources.MemPerNode * megabyte),
+			WallTime:   duration(in.Spec.Resources.WallTime),
+		},
+		NodeSelector: copyMap(in.Spec.NodeSelector),
+		Results:      resultsFrom(in.Spec.Results),
+	}
+	out.Spec.Options.Binds = copySlice(in.Spec.Options.Binds)
+	out.Annotations = resourcesAnnotationFrom(out.Annotations, &out.Spec.Resources, in.Spec.Resources)
+	out.Status = JobStatus{
+		Phase:      JobPhase(in.Status.Status),
+		Usage:      usageFrom(in.Status.Usage),
+		Conditions: conditionsFrom(in.Status.Conditions),
+	}
+}
+
+// resourcesAnnotationTo returns annotations with the original memory and wall time
+// of v1beta1 resources added, unless they are converted to v1alpha1 and back as is.
+func resourcesAnnotationTo(annotations map[string]string, in WlmResources) map[string]string {
+	delete(annotations, resourcesAnnotation)
+	var orig WlmResources
+	mem := binaryQuantity(ceilDiv(quantityValue(in.MemPerNode), megabyte) * megabyte)
+	if in.MemPerNode != nil && (mem == nil || mem.String() != in.MemPerNode.String()) {
+		orig.MemPerNode = in.MemPerNode
+	}
+	if d := duration(seconds(in.WallTime)); in.WallTime != nil && (d == nil || *d != *in.WallTime) {
+		orig.WallTime = in.WallTime
+	}
+	if orig.MemPerNode == nil && orig.WallTime == nil {
+		if len(annotations) == 0 {
+			return nil
+		}
+		return annotations
+	}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[resourcesAnnotation] = string(data)
+	return annotations
+}
+
+// resourcesAnnotationFrom restores memory and wall time of v1beta1 resources from the annotation
+// and returns annotations without it. Values are restored only if they still match v1alpha1
+// resources, i.e. v1alpha1 object has not changed them since the annotation was set.
+func resourcesAnnotationFrom(annotations map[string]string, out *WlmResources, in v1alpha1.WlmResources) map[string]string {
+	data, ok := annotations[resourcesAnnotation]
+	if !ok {
+		return annotations
+	}
+	delete(annotations, resourcesAnnotation)
+
+	var orig WlmResources
+	if err := json.Unmarshal([]byte(data), &orig); err == nil {
+		if orig.MemPerNode != nil && ceilDiv(quantityValue(orig.MemPerNode), megabyte) == in.MemPerNode {
+			out.MemPerNode = orig.MemPerNode
+		}
+		if orig.WallTime != nil && seconds(orig.WallTime) == in.WallTime {
+			out.WallTime = orig.WallTime
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+func enrootTo(in *EnrootOptions) *v1alpha1.EnrootOptions {
+	if in == nil {
+		return nil
+	}
+	out := v1alpha1.EnrootOptions(*in)
+	out.Command = copySlice(in.Command)
+	out.Mounts = copySlice(in.Mounts)
+	return &out
+}
+
+func enrootFrom(in *v1alpha1.EnrootOptions) *EnrootOptions {
+	if in == nil {
+		return nil
+	}
+	out := EnrootOptions(*in)
+	out.Command = copySlice(in.Command)
+	out.Mounts = copySlice(in.Mounts)
+	return &out
+}
+
+func podmanHPCTo(in *PodmanHPCOptions) *v1alpha1.PodmanHPCOptions {
+	if in == nil {
+		return nil
+	}
+	out := v1alpha1.PodmanHPCOptions(*in)
+	out.Volumes = copySlice(in.Volumes)
+	return &out
+}
+
+func podmanHPCFrom(in *v1alpha1.PodmanHPCOptions) *PodmanHPCOptions {
+	if in == nil {
+		return nil
+	}
+	out := PodmanHPCOptions(*in)
+	out.Volumes = copySlice(in.Volumes)
+	return &out
+}
+
+func charliecloudTo(in *CharliecloudOptions) *v1alpha1.CharliecloudOptions {
+	if in == nil {
+		return nil
+	}
+	out := v1alpha1.CharliecloudOptions(*in)
+	out.Command = copySlice(in.Command)
+	out.Binds = copySlice(in.Binds)
+	return &out
+}
+
+func charliecloudFrom(in *v1alpha1.CharliecloudOptions) *CharliecloudOptions {
+	if in == nil {
+		return nil
+	}
+	out := CharliecloudOptions(*in)
+	out.Command = copySlice(in.Command)
+	out.Binds = copySlice(in.Binds)
+	return &out
+}
+
+func mpiTo(in *MPIOptions) *v1alpha1.MPIOptions {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha1.MPIOptions{
+		Ntasks:       in.Ntasks,
+		TasksPerNode: in.TasksPerNode,
+		Type:         v1alpha1.MPIType(in.Type),
+	}
+}
+
+func mpiFrom(in *v1alpha1.MPIOptions) *MPIOptions {
+	if in == nil {
+		return nil
+	}
+	return &MPIOptions{
+		Ntasks:       in.Ntasks,
+		TasksPerNode: in.TasksPerNode,
+		Type:         MPIType(in.Type),
+	}
+}
+
+func resultsTo(in *JobResults) *v1alpha1.JobResults {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha1.JobResults{Mount: *in.Mount.DeepCopy(), From: in.From}
+}
+
+func resultsFrom(in *v1alpha1.JobResults) *JobResults {
+	if in == nil {
+		return nil
+	}
+	return &JobResults{Mount: *in.Mount.DeepCopy(), From: in.From}
+}
+
+func usageTo(in *JobUsage) *v1alpha1.JobUsage {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha1.JobUsage{
+		CPUTime:          seconds(in.CPUTime),
+		Elapsed:          seconds(in.Elapsed),
+		MaxRSS:           quantityValue(in.MaxRSS),
+		AveRSS:           quantityValue(in.AveRSS),
+		MaxDiskRead:      quantityValue(in.MaxDiskRead),
+		MaxDiskWrite:     quantityValue(in.MaxDiskWrite),
+		ConsumedEnergy:   in.ConsumedEnergy,
+		AllocTRES:        copyMap(in.AllocTRES),
+		CPUEfficiency:    in.CPUEfficiency,
+		MemoryEfficiency: in.MemoryEfficiency,
+	}
+}
+
+func usageFrom(in *v1alpha1.JobUsage) *JobUsage {
+	if in == nil {
+		return nil
+	}
+	return &JobUsage{
+		CPUTime:          duration(in.CPUTime),
+		Elapsed:          duration(in.Elapsed),
+		MaxRSS:           binaryQuantity(in.MaxRSS),
+		AveRSS:           binaryQuantity(in.AveRSS),
+		MaxDiskRead:      binaryQuantity(in.MaxDiskRead),
+		MaxDiskWrite:     binaryQuantity(in.MaxDiskWrite),
+		ConsumedEnergy:   in.ConsumedEnergy,
+		AllocTRES:        copyMap(in.AllocTRES),
+		CPUEfficiency:    in.CPUEfficiency,
+		MemoryEfficiency: in.MemoryEfficiency,
+	}
+}
+
+func conditionsTo(in []JobCondition) []v1alpha1.JobCondition {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1alpha1.JobCondition, len(in))
+	for i, c := range in {
+		out[i] = v1alpha1.JobCondition{
+			Type:               v1alpha1.JobConditionType(c.Type),
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		}
+	}
+	return out
+}
+
+func conditionsFrom(in []v1alpha1.JobCondition) []JobCondition {
+	if in == nil {
+		return nil
+	}
+	out := make([]JobCondition, len(in))
+	for i, c := range in {
+		out[i] = JobCondition{
+			Type:               JobConditionType(c.Type),
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		}
+	}
+	return out
+}
+
+// seconds returns duration in whole seconds rounded up, nil duration results in 0.
+func seconds(d *metav1.Duration) int64 {
+	if d == nil {
+		return 0
+	}
+	return ceilDiv(int64(d.Duration), int64(time.Second))
+}
+
+// duration returns seconds as duration, zero results in nil.
+func duration(secs int64) *metav1.Duration {
+	if secs == 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: time.Duration(secs) * time.Second}
+}
+
+// quantityValue returns quantity value rounded up, nil quantity results in 0.
+func quantityValue(q *resource.Quantity) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.Value()
+}
+
+// binaryQuantity returns value as quantity in binary SI format, zero results in nil.
+func binaryQuantity(v int64) *resource.Quantity {
+	if v == 0 {
+		return nil
+	}
+	return resource.NewQuantity(v, resource.BinarySI)
+}
+
+func ceilDiv(a, b int64) int64 {
+	if a <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
+
+func copyMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func copySlice(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append([]string{}, in...)
+}
+
+func copyEnv(in []corev1.EnvVar) []corev1.EnvVar {
+	if in == nil {
+		return nil
+	}
+	out := make([]corev1.EnvVar, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func copyEnvFrom(in []corev1.EnvFromSource) []corev1.EnvFromSource {
+	if in == nil {
+		return nil
+	}
+	out := make([]corev1.EnvFromSource, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWlmJob_Convert(t *testing.T) {
+	mem := resource.MustParse("1Gi")
+	tt := []struct {
+		name      string
+		in        WlmJobSpec
+		expectHub v1alpha1.WlmResources
+		expectOut WlmResources
+	}{
+		{
+			name: "exact",
+			in: WlmJobSpec{Resources: WlmResources{
+				Nodes:      2,
+				CPUPerNode: 4,
+				MemPerNode: &mem,
+				WallTime:   &metav1.Duration{Duration: time.Hour},
+			}},
+			expectHub: v1alpha1.WlmResources{Nodes: 2, CPUPerNode: 4, MemPerNode: 1024, WallTime: 3600},
+			expectOut: WlmResources{
+				Nodes:      2,
+				CPUPerNode: 4,
+				MemPerNode: resource.NewQuantity(1<<30, resource.BinarySI),
+				WallTime:   &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		{
+			name: "rounded up",
+			in: WlmJobSpec{Resources: WlmResources{
+				MemPerNode: resource.NewQuantity(1e9, resource.DecimalSI),
+				WallTime:   &metav1.Duration{Duration: 1500 * time.Millisecond},
+			}},
+			expectHub: v1alpha1.WlmResources{MemPerNode: 954, WallTime: 2},
+			expectOut: WlmResources{
+				MemPerNode: resource.NewQuantity(1e9, resource.DecimalSI),
+				WallTime:   &metav1.Duration{Duration: 1500 * time.Millisecond},
+			},
+		},
+		{
+			name:      "empty",
+			in:        WlmJobSpec{},
+			expectHub: v1alpha1.WlmResources{},
+			expectOut: WlmResources{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &WlmJob{Spec: tc.in}
+			var hub v1alpha1.WlmJob
+			in.ConvertTo(&hub)
+			require.Equal(t, tc.expectHub, hub.Spec.Resources)
+
+			var out WlmJob
+			out.ConvertFrom(&hub)
+			requireResources(t, tc.expectOut, out.Spec.Resources)
+			require.Empty(t, out.Annotations)
+		})
+	}
+}
+
+func TestWlmJob_ConvertRoundTrip(t *testing.T) {
+	mem := resource.MustParse("2G")
+	in := &WlmJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cow", Annotations: map[string]string{"team": "hpc"}},
+		Spec: WlmJobSpec{
+			Image: "library://sylabsed/examples/lolcow",
+			Resources: WlmResources{
+				Nodes:      1,
+				MemPerNode: &mem,
+				WallTime:   &metav1.Duration{Duration: 90 * time.Minute},
+			},
+		},
+	}
+
+	var hub v1alpha1.WlmJob
+	in.ConvertTo(&hub)
+	require.Equal(t, v1alpha1.WlmResources{Nodes: 1, MemPerNode: 1908, WallTime: 5400}, hub.Spec.Resources)
+
+	var out WlmJob
+	out.ConvertFrom(&hub)
+	requireResources(t, in.Spec.Resources, out.Spec.Resources)
+	require.Equal(t, in.Annotations, out.Annotations)
+
+	// resources changed in v1alpha1 are not overwritten by the original ones
+	hub.Spec.Resources.MemPerNode = 4096
+	out = WlmJob{}
+	out.ConvertFrom(&hub)
+	requireResources(t, WlmResources{
+		Nodes:      1,
+		MemPerNode: resource.NewQuantity(4096<<20, resource.BinarySI),
+		WallTime:   in.Spec.Resources.WallTime,
+	}, out.Spec.Resources)
+}
+
+// requireResources compares quantities by their string representation,
+// since quantities parsed from different strings are not deeply equal.
+func requireResources(t *testing.T, expected, actual WlmResources) {
+	if expected.MemPerNode != nil && actual.MemPerNode != nil {
+		require.Equal(t, expected.MemPerNode.String(), actual.MemPerNode.String())
+		expected.MemPerNode, actual.MemPerNode = nil, nil
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestWlmJob_ConvertRuntime(t *testing.T) {
+	in := &WlmJob{Spec: WlmJobSpec{
+		Image:        "docker://alpine:3.9",
+		Runtime:      RuntimeCharliecloud,
+		Enroot:       &EnrootOptions{Command: []string{"true"}, Mounts: []string{"/data:/data"}, RemapRoot: true},
+		PodmanHPC:    &PodmanHPCOptions{Volumes: []string{"/scratch:/scratch"}, GPU: true},
+		Charliecloud: &CharliecloudOptions{Command: []string{"cat", "/etc/os-release"}, SetEnv: true},
+		MPI:          &MPIOptions{Ntasks: 8, TasksPerNode: 4, Type: MPIPMIx},
+		Command:      []string{"/bin/sh", "-c"},
+		Args:         []string{"echo $GREETING"},
+		Env: []corev1.EnvVar{
+			{Name: "GREETING", Value: "hello"},
+			{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+					Key:                  "token",
+				},
+			}},
+		},
+		EnvFrom: []corev1.EnvFromSource{
+			{Prefix: "APP_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app"}}},
+		},
+	}}
+	var hub v1alpha1.WlmJob
+	in.ConvertTo(&hub)
+	require.Equal(t, v1alpha1.RuntimeCharliecloud, hub.Spec.Runtime)
+	require.Equal(t, &v1alpha1.EnrootOptions{Command: []string{"true"}, Mounts: []string{"/data:/data"}, RemapRoot: true}, hub.Spec.Enroot)
+	require.Equal(t, &v1alpha1.PodmanHPCOptions{Volumes: []string{"/scratch:/scratch"}, GPU: true}, hub.Spec.PodmanHPC)
+	require.Equal(t, &v1alpha1.CharliecloudOptions{Command: []string{"cat", "/etc/os-release"}, SetEnv: true}, hub.Spec.Charliecloud)
+	require.Equal(t, &v1alpha1.MPIOptions{Ntasks: 8, TasksPerNode: 4, Type: v1alpha1.MPIPMIx}, hub.Spec.MPI)
+
+	var out WlmJob
+	out.ConvertFrom(&hub)
+	require.Equal(t, in.Spec, out.Spec)
+}
+
+func TestSlurmJob_Convert(t *testing.T) {
+	now := metav1.NewTime(time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC))
+	in := &SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cow", Namespace: "default"},
+		Spec: SlurmJobSpec{
+			Batch:        "#!/bin/sh\nsrun hostname",
+			NodeSelector: map[string]string{"wlm.sylabs.io/partition": "debug"},
+		},
+		Status: JobStatus{
+			Phase: JobSucceeded,
+			Usage: &JobUsage{
+				CPUTime: &metav1.Duration{Duration: 90 * time.Second},
+				Elapsed: &metav1.Duration{Duration: time.Minute},
+				MaxRSS:  resource.NewQuantity(42<<20, resource.BinarySI),
+			},
+			Conditions: []JobCondition{
+				{Type: JobUnschedulable, Status: "False", Reason: "Scheduled", LastTransitionTime: now},
+			},
+		},
+	}
+
+	var hub v1alpha1.SlurmJob
+	in.ConvertTo(&hub)
+	require.Equal(t, "Succeeded", hub.Status.Status)
+	require.Equal(t, int64(90), hub.Status.Usage.CPUTime)
+	require.Equal(t, int64(42<<20), hub.Status.Usage.MaxRSS)
+
+	var out SlurmJob
+	out.ConvertFrom(&hub)
+	require.Equal(t, in, &out)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SlurmJobSpec defines the desired state of SlurmJob
+// +k8s:openapi-gen=true
+type SlurmJobSpec struct {
+	// Batch is a script that will be submitted to a Slurm cluster as a batch job.
+	// +kubebuilder:validation:MinLength=1
+	Batch string `json:"batch"`
+
+	// NodeSelector is a selector which must be true for the SlurmJob to fit on a node.
+	// Selector which must match a node's labels for the SlurmJob to be scheduled on that node.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Results may be specified for an optional results collection step.
+	// When specified, after job is completed all results will be downloaded from Slurm
+	// cluster with respect to this configuration.
+	Results *JobResults `json:"results,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlurmJob is the Schema for the slurmjobs API.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=sj
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="phase of the job"
+type SlurmJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmJobSpec `json:"spec,omitempty"`
+	Status JobStatus    `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlurmJobList contains a list of SlurmJob.
+type SlurmJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlurmJob{}, &SlurmJobList{})
+}
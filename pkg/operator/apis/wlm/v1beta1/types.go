@@ -0,0 +1,110 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobPhase is a phase of a job, it follows phase of the job companion pod.
+type JobPhase string
+
+const (
+	// JobPending means job is submitted but is not running yet.
+	JobPending JobPhase = "Pending"
+	// JobRunning means job is running.
+	JobRunning JobPhase = "Running"
+	// JobSucceeded means job finished successfully.
+	JobSucceeded JobPhase = "Succeeded"
+	// JobFailed means job finished with an error or was cancelled.
+	JobFailed JobPhase = "Failed"
+	// JobUnknown means job state cannot be obtained.
+	JobUnknown JobPhase = "Unknown"
+)
+
+// JobConditionType is a type of a job condition.
+type JobConditionType string
+
+const (
+	// JobUnschedulable means job companion pod cannot be created because
+	// job spec contradicts itself, e.g. SBATCH partition differs from node selector.
+	JobUnschedulable JobConditionType = "Unschedulable"
+)
+
+// JobResults is a schema for results collection.
+// +k8s:openapi-gen=true
+type JobResults struct {
+	// Mount is a directory where job results will be stored.
+	// After results collection all job generated files can be found in Mount/<job name> directory.
+	Mount v1.Volume `json:"mount"`
+
+	// From is a path to the results to be collected from a WLM cluster.
+	From string `json:"from"`
+}
+
+// JobUsage is a schema for job resources usage reported by WLM accounting.
+// +k8s:openapi-gen=true
+type JobUsage struct {
+	// CPUTime is total CPU time consumed by job.
+	CPUTime *metav1.Duration `json:"cpuTime,omitempty"`
+	// Elapsed is job wall time.
+	Elapsed *metav1.Duration `json:"elapsed,omitempty"`
+	// MaxRSS is maximum resident set size among job steps.
+	MaxRSS *resource.Quantity `json:"maxRSS,omitempty"`
+	// AveRSS is maximum average resident set size among job steps.
+	AveRSS *resource.Quantity `json:"aveRSS,omitempty"`
+	// MaxDiskRead is maximum amount of data read among job steps.
+	MaxDiskRead *resource.Quantity `json:"maxDiskRead,omitempty"`
+	// MaxDiskWrite is maximum amount of data written among job steps.
+	MaxDiskWrite *resource.Quantity `json:"maxDiskWrite,omitempty"`
+	// ConsumedEnergy is energy consumed by job, in joules.
+	ConsumedEnergy int64 `json:"consumedEnergy,omitempty"`
+	// AllocTRES is a set of trackable resources allocated to job, e.g. cpu=2, mem=1G.
+	AllocTRES map[string]string `json:"allocTRES,omitempty"`
+	// CPUEfficiency is a ratio of consumed to allocated CPU time, in percent.
+	CPUEfficiency int32 `json:"cpuEfficiency,omitempty"`
+	// MemoryEfficiency is a ratio of maximum resident set size to allocated memory, in percent.
+	MemoryEfficiency int32 `json:"memoryEfficiency,omitempty"`
+}
+
+// JobCondition describes the state of a job at a certain point.
+// +k8s:openapi-gen=true
+type JobCondition struct {
+	// Type of job condition.
+	Type JobConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status v1.ConditionStatus `json:"status"`
+	// Reason is a one-word CamelCase reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about last transition.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// JobStatus defines the observed state of a job.
+// +k8s:openapi-gen=true
+type JobStatus struct {
+	// Phase reflects job phase, e.g Running, Succeeded.
+	Phase JobPhase `json:"phase,omitempty"`
+
+	// Usage reflects resources consumed by job. It is reported once job is finished.
+	Usage *JobUsage `json:"usage,omitempty"`
+
+	// Conditions reflect job conditions that prevent it from being processed.
+	Conditions []JobCondition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WlmJobSpec defines the desired state of WlmJob.
+// +k8s:openapi-gen=true
+type WlmJobSpec struct {
+	// Image name to start as a job.
+	Image string `json:"image"`
+
+	// Runtime is a container runtime the image is run with, singularity by default.
+	// +kubebuilder:validation:Enum=singularity,apptainer,enroot,podman-hpc,charliecloud
+	Runtime ContainerRuntime `json:"runtime,omitempty"`
+
+	// Command overrides image entrypoint. When set, singularity and apptainer
+	// run the command with exec instead of image runscript.
+	Command []string `json:"command,omitempty"`
+
+	// Args are arguments passed to the command or to image runscript.
+	Args []string `json:"args,omitempty"`
+
+	// Env lists environment variables to set in container. Values may
+	// be taken only from ConfigMaps and Secrets of the job namespace.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom lists ConfigMaps and Secrets to populate container environment
+	// variables with. Values defined by Env take precedence.
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Options singularity run options. They are used by singularity and apptainer runtimes.
+	Options SingularityOptions `json:"options,omitempty"`
+
+	// Enroot options are used by enroot runtime.
+	Enroot *EnrootOptions `json:"enroot,omitempty"`
+
+	// PodmanHPC options are used by podman-hpc runtime.
+	PodmanHPC *PodmanHPCOptions `json:"podmanHPC,omitempty"`
+
+	// Charliecloud options are used by charliecloud runtime.
+	Charliecloud *CharliecloudOptions `json:"charliecloud,omitempty"`
+
+	// MPI describes task layout of an MPI job. When set, image is
+	// started as ntasks MPI ranks with srun --mpi.
+	MPI *MPIOptions `json:"mpi,omitempty"`
+
+	// Resources describes required resources for a job.
+	Resources WlmResources `json:"resources,omitempty"`
+
+	// NodeSelector is a selector which must be true for the WlmJob to fit on a node.
+	// Selector which must match a node's labels for the WlmJob to be scheduled on that node.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Results may be specified for an optional results collection step.
+	// When specified, after job is completed all results will be downloaded from WLM
+	// cluster with respect to this configuration.
+	Results *JobResults `json:"results,omitempty"`
+}
+
+// SingularityOptions singularity run options.
+// +k8s:openapi-gen=true
+type SingularityOptions struct {
+	// Allow to pull and run unsigned images.
+	AllowUnsigned bool `json:"allowUnsigned,omitempty"`
+	// Clean environment before running container.
+	CleanEnv bool `json:"cleanEnv,omitempty"`
+	// Run container in new user namespace as uid 0.
+	FakeRoot bool `json:"fakeRoot,omitempty"`
+	// Run container in a new IPC namespace.
+	IPC bool `json:"ipc,omitempty"`
+	// Run container in a new PID namespace.
+	PID bool `json:"pid,omitempty"`
+	// Drop all privileges from root user in container.
+	NoPrivs bool `json:"noPrivs,omitempty"`
+	// By default all Singularity containers are
+	// available as read only. This option makes
+	// the file system accessible as read/write.
+	Writable bool `json:"writable,omitempty"`
+	// Set an application to run inside a container.
+	App string `json:"app,omitempty"`
+	// Set container hostname.
+	HostName string `json:"hostName,omitempty"`
+	// Binds a user-bind path specification. Spec has
+	// the format src[:dest[:opts]], where src and
+	// dest are outside and inside paths.  If dest
+	// is not given, it is set equal to src.
+	// Mount options ('opts') may be specified as
+	// 'ro' (read-only) or 'rw' (read/write, which
+	// is the default). Multiple bind paths can be
+	// given by a comma separated list.
+	Binds []string `json:"binds,omitempty"`
+}
+
+// ContainerRuntime is a container runtime available on WLM cluster.
+type ContainerRuntime string
+
+// Supported container runtimes.
+const (
+	// RuntimeSingularity runs image with singularity.
+	RuntimeSingularity ContainerRuntime = "singularity"
+	// RuntimeApptainer runs image with apptainer.
+	RuntimeApptainer ContainerRuntime = "apptainer"
+	// RuntimeEnroot runs image with enroot using pyxis srun plugin.
+	RuntimeEnroot ContainerRuntime = "enroot"
+	// RuntimePodmanHPC runs image with podman-hpc.
+	RuntimePodmanHPC ContainerRuntime = "podman-hpc"
+	// RuntimeCharliecloud runs image with charliecloud.
+	RuntimeCharliecloud ContainerRuntime = "charliecloud"
+)
+
+// EnrootOptions are pyxis srun options. Image is passed to pyxis
+// as is, e.g. nvcr.io#nvidia/pytorch:23.05, or as a docker:// reference.
+// +k8s:openapi-gen=true
+type EnrootOptions struct {
+	// Command to run in container. Pyxis doesn't start image
+	// default command, so it's required.
+	Command []string `json:"command"`
+	// Mounts are bind mounts in the format src:dest[:flags],
+	// where flags are enroot mount flags, e.g. ro.
+	Mounts []string `json:"mounts,omitempty"`
+	// Workdir is a working directory inside container.
+	Workdir string `json:"workdir,omitempty"`
+	// MountHome mounts user home directory into container.
+	MountHome bool `json:"mountHome,omitempty"`
+	// RemapRoot makes user root inside container.
+	RemapRoot bool `json:"remapRoot,omitempty"`
+	// Writable makes container file system writable.
+	Writable bool `json:"writable,omitempty"`
+}
+
+// PodmanHPCOptions are podman-hpc run options.
+// +k8s:openapi-gen=true
+type PodmanHPCOptions struct {
+	// Volumes are bind mounts in the format src:dest[:opts].
+	Volumes []string `json:"volumes,omitempty"`
+	// Workdir is a working directory inside container.
+	Workdir string `json:"workdir,omitempty"`
+	// GPU enables GPU support in container.
+	GPU bool `json:"gpu,omitempty"`
+	// MPI enables MPI support in container.
+	MPI bool `json:"mpi,omitempty"`
+}
+
+// CharliecloudOptions are ch-run options. Image is pulled into
+// charliecloud builder storage unless it's a local image.
+// +k8s:openapi-gen=true
+type CharliecloudOptions struct {
+	// Command to run in container. Charliecloud doesn't
+	// start image default command, so it's required.
+	Command []string `json:"command"`
+	// Binds are bind mounts in the format src[:dest].
+	Binds []string `json:"binds,omitempty"`
+	// Writable makes container file system writable.
+	Writable bool `json:"writable,omitempty"`
+	// SetEnv sets environment variables from image metadata.
+	SetEnv bool `json:"setEnv,omitempty"`
+}
+
+// MPIType is a slurm MPI plugin that starts MPI ranks.
+type MPIType string
+
+// Supported MPI plugins.
+const (
+	// MPIPMIx starts ranks with PMIx.
+	MPIPMIx MPIType = "pmix"
+	// MPIPMI2 starts ranks with PMI2.
+	MPIPMI2 MPIType = "pmi2"
+	// MPINone starts ranks without MPI plugin, e.g. for MPI
+	// implementations that bootstrap themselves.
+	MPINone MPIType = "none"
+)
+
+// MPIOptions describes MPI job tasks. Image is run with the hybrid model,
+// where MPI library inside container is wired up by host slurm plugin.
+// +k8s:openapi-gen=true
+type MPIOptions struct {
+	// Ntasks is a number of MPI ranks.
+	// +kubebuilder:validation:Minimum=1
+	Ntasks int64 `json:"ntasks"`
+	// TasksPerNode is a maximum number of ranks on each node.
+	// +kubebuilder:validation:Minimum=1
+	TasksPerNode int64 `json:"tasksPerNode,omitempty"`
+	// Type is slurm MPI plugin, cluster MpiDefault is used when not set.
+	// +kubebuilder:validation:Enum=pmix,pmi2,none
+	Type MPIType `json:"type,omitempty"`
+}
+
+// WlmResources is a schema for wlm resources.
+// +k8s:openapi-gen=true
+type WlmResources struct {
+	// Nodes is a number of nodes required for a job.
+	Nodes int64 `json:"nodes,omitempty"`
+	// CPUPerNode is a number of cpus required on each node.
+	CPUPerNode int64 `json:"cpuPerNode,omitempty"`
+	// MemPerNode is an amount of memory required on each node, e.g. 512Mi.
+	MemPerNode *resource.Quantity `json:"memPerNode,omitempty"`
+	// WallTime is a job time limit, e.g. 1h30m.
+	WallTime *metav1.Duration `json:"wallTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmJob is the Schema for the wlm jobs API.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=wj
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="phase of the job"
+type WlmJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WlmJobSpec `json:"spec,omitempty"`
+	Status JobStatus  `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WlmJobList contains a list of WlmJob.
+type WlmJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WlmJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WlmJob{}, &WlmJobList{})
+}
@@ -0,0 +1,500 @@
+// +build !ignore_autogenerated
+
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CharliecloudOptions) DeepCopyInto(out *CharliecloudOptions) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Binds != nil {
+		in, out := &in.Binds, &out.Binds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CharliecloudOptions.
+func (in *CharliecloudOptions) DeepCopy() *CharliecloudOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CharliecloudOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrootOptions) DeepCopyInto(out *EnrootOptions) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrootOptions.
+func (in *EnrootOptions) DeepCopy() *EnrootOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrootOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCondition) DeepCopyInto(out *JobCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCondition.
+func (in *JobCondition) DeepCopy() *JobCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobResults) DeepCopyInto(out *JobResults) {
+	*out = *in
+	in.Mount.DeepCopyInto(&out.Mount)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobResults.
+func (in *JobResults) DeepCopy() *JobResults {
+	if in == nil {
+		return nil
+	}
+	out := new(JobResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(JobUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]JobCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobUsage) DeepCopyInto(out *JobUsage) {
+	*out = *in
+	if in.CPUTime != nil {
+		in, out := &in.CPUTime, &out.CPUTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Elapsed != nil {
+		in, out := &in.Elapsed, &out.Elapsed
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRSS != nil {
+		in, out := &in.MaxRSS, &out.MaxRSS
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AveRSS != nil {
+		in, out := &in.AveRSS, &out.AveRSS
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxDiskRead != nil {
+		in, out := &in.MaxDiskRead, &out.MaxDiskRead
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxDiskWrite != nil {
+		in, out := &in.MaxDiskWrite, &out.MaxDiskWrite
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AllocTRES != nil {
+		in, out := &in.AllocTRES, &out.AllocTRES
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobUsage.
+func (in *JobUsage) DeepCopy() *JobUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(JobUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPIOptions) DeepCopyInto(out *MPIOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPIOptions.
+func (in *MPIOptions) DeepCopy() *MPIOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MPIOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodmanHPCOptions) DeepCopyInto(out *PodmanHPCOptions) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodmanHPCOptions.
+func (in *PodmanHPCOptions) DeepCopy() *PodmanHPCOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodmanHPCOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingularityOptions) DeepCopyInto(out *SingularityOptions) {
+	*out = *in
+	if in.Binds != nil {
+		in, out := &in.Binds, &out.Binds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingularityOptions.
+func (in *SingularityOptions) DeepCopy() *SingularityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SingularityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJob) DeepCopyInto(out *SlurmJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJob.
+func (in *SlurmJob) DeepCopy() *SlurmJob {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobList) DeepCopyInto(out *SlurmJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobList.
+func (in *SlurmJobList) DeepCopy() *SlurmJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobSpec) DeepCopyInto(out *SlurmJobSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(JobResults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobSpec.
+func (in *SlurmJobSpec) DeepCopy() *SlurmJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJob) DeepCopyInto(out *WlmJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJob.
+func (in *WlmJob) DeepCopy() *WlmJob {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJobList) DeepCopyInto(out *WlmJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WlmJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJobList.
+func (in *WlmJobList) DeepCopy() *WlmJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WlmJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmJobSpec) DeepCopyInto(out *WlmJobSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Options.DeepCopyInto(&out.Options)
+	if in.Enroot != nil {
+		in, out := &in.Enroot, &out.Enroot
+		*out = new(EnrootOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodmanHPC != nil {
+		in, out := &in.PodmanHPC, &out.PodmanHPC
+		*out = new(PodmanHPCOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Charliecloud != nil {
+		in, out := &in.Charliecloud, &out.Charliecloud
+		*out = new(CharliecloudOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MPI != nil {
+		in, out := &in.MPI, &out.MPI
+		*out = new(MPIOptions)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(JobResults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmJobSpec.
+func (in *WlmJobSpec) DeepCopy() *WlmJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WlmResources) DeepCopyInto(out *WlmResources) {
+	*out = *in
+	if in.MemPerNode != nil {
+		in, out := &in.MemPerNode, &out.MemPerNode
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WallTime != nil {
+		in, out := &in.WallTime, &out.WallTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WlmResources.
+func (in *WlmResources) DeepCopy() *WlmResources {
+	if in == nil {
+		return nil
+	}
+	out := new(WlmResources)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,853 @@
+// +build !ignore_autogenerated
+
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by openapi-gen. DO NOT EDIT.
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1beta1
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.CharliecloudOptions": schema_operator_apis_wlm_v1beta1_CharliecloudOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.EnrootOptions":       schema_operator_apis_wlm_v1beta1_EnrootOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobCondition":        schema_operator_apis_wlm_v1beta1_JobCondition(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobResults":          schema_operator_apis_wlm_v1beta1_JobResults(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobStatus":           schema_operator_apis_wlm_v1beta1_JobStatus(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobUsage":            schema_operator_apis_wlm_v1beta1_JobUsage(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.MPIOptions":          schema_operator_apis_wlm_v1beta1_MPIOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.PodmanHPCOptions":    schema_operator_apis_wlm_v1beta1_PodmanHPCOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SingularityOptions":  schema_operator_apis_wlm_v1beta1_SingularityOptions(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SlurmJob":            schema_operator_apis_wlm_v1beta1_SlurmJob(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SlurmJobSpec":        schema_operator_apis_wlm_v1beta1_SlurmJobSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmJob":              schema_operator_apis_wlm_v1beta1_WlmJob(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmJobSpec":          schema_operator_apis_wlm_v1beta1_WlmJobSpec(ref),
+		"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmResources":        schema_operator_apis_wlm_v1beta1_WlmResources(ref),
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_CharliecloudOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CharliecloudOptions are ch-run options. Image is pulled into charliecloud builder storage unless it's a local image.",
+				Properties: map[string]spec.Schema{
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command to run in container. Charliecloud doesn't start image default command, so it's required.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"binds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binds are bind mounts in the format src[:dest].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"writable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Writable makes container file system writable.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"setEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SetEnv sets environment variables from image metadata.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_EnrootOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EnrootOptions are pyxis srun options. Image is passed to pyxis as is, e.g. nvcr.io#nvidia/pytorch:23.05, or as a docker:// reference.",
+				Properties: map[string]spec.Schema{
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command to run in container. Pyxis doesn't start image default command, so it's required.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"mounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mounts are bind mounts in the format src:dest[:flags], where flags are enroot mount flags, e.g. ro.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"workdir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workdir is a working directory inside container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountHome": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountHome mounts user home directory into container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"remapRoot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemapRoot makes user root inside container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"writable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Writable makes container file system writable.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_JobCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobCondition describes the state of a job at a certain point.",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of job condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False, Unknown.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a one-word CamelCase reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message indicating details about last transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_JobResults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobResults is a schema for results collection.",
+				Properties: map[string]spec.Schema{
+					"mount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mount is a directory where job results will be stored. After results collection all job generated files can be found in Mount/<job name> directory.",
+							Ref:         ref("k8s.io/api/core/v1.Volume"),
+						},
+					},
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is a path to the results to be collected from a WLM cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"mount", "from"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Volume"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_JobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobStatus defines the observed state of a job.",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase reflects job phase, e.g Running, Succeeded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage reflects resources consumed by job. It is reported once job is finished.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobUsage"),
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions reflect job conditions that prevent it from being processed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobCondition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobCondition", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobUsage"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_JobUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobUsage is a schema for job resources usage reported by WLM accounting.",
+				Properties: map[string]spec.Schema{
+					"cpuTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUTime is total CPU time consumed by job.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"elapsed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Elapsed is job wall time.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxRSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRSS is maximum resident set size among job steps.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"aveRSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AveRSS is maximum average resident set size among job steps.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxDiskRead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDiskRead is maximum amount of data read among job steps.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxDiskWrite": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDiskWrite is maximum amount of data written among job steps.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"consumedEnergy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsumedEnergy is energy consumed by job, in joules.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"allocTRES": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllocTRES is a set of trackable resources allocated to job, e.g. cpu=2, mem=1G.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"cpuEfficiency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUEfficiency is a ratio of consumed to allocated CPU time, in percent.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"memoryEfficiency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryEfficiency is a ratio of maximum resident set size to allocated memory, in percent.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_MPIOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MPIOptions describes MPI job tasks. Image is run with the hybrid model, where MPI library inside container is wired up by host slurm plugin.",
+				Properties: map[string]spec.Schema{
+					"ntasks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ntasks is a number of MPI ranks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tasksPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TasksPerNode is a maximum number of ranks on each node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is slurm MPI plugin, cluster MpiDefault is used when not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"ntasks"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_PodmanHPCOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodmanHPCOptions are podman-hpc run options.",
+				Properties: map[string]spec.Schema{
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes are bind mounts in the format src:dest[:opts].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"workdir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workdir is a working directory inside container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"gpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GPU enables GPU support in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"mpi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MPI enables MPI support in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_SingularityOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SingularityOptions singularity run options.",
+				Properties: map[string]spec.Schema{
+					"allowUnsigned": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Allow to pull and run unsigned images.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cleanEnv": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clean environment before running container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fakeRoot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in new user namespace as uid 0.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ipc": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in a new IPC namespace.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Run container in a new PID namespace.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"noPrivs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Drop all privileges from root user in container.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"writable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "By default all Singularity containers are available as read only. This option makes the file system accessible as read/write.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"app": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set an application to run inside a container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set container hostname.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"binds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binds a user-bind path specification. Spec has the format src[:dest[:opts]], where src and dest are outside and inside paths.  If dest is not given, it is set equal to src. Mount options ('opts') may be specified as 'ro' (read-only) or 'rw' (read/write, which is the default). Multiple bind paths can be given by a comma separated list.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_SlurmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmJob is the Schema for the slurmjobs API.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SlurmJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobStatus", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SlurmJobSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_SlurmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SlurmJobSpec defines the desired state of SlurmJob",
+				Properties: map[string]spec.Schema{
+					"batch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Batch is a script that will be submitted to a Slurm cluster as a batch job.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the SlurmJob to fit on a node. Selector which must match a node's labels for the SlurmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results may be specified for an optional results collection step. When specified, after job is completed all results will be downloaded from Slurm cluster with respect to this configuration.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobResults"),
+						},
+					},
+				},
+				Required: []string{"batch"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobResults"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_WlmJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJob is the Schema for the wlm jobs API.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobStatus", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmJobSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_WlmJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmJobSpec defines the desired state of WlmJob.",
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image name to start as a job.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runtime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runtime is a container runtime the image is run with, singularity by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command overrides image entrypoint. When set, singularity and apptainer run the command with exec instead of image runscript.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are arguments passed to the command or to image runscript.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env lists environment variables to set in container. Values may be taken only from ConfigMaps and Secrets of the job namespace.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvFrom lists ConfigMaps and Secrets to populate container environment variables with. Values defined by Env take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"options": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Options singularity run options. They are used by singularity and apptainer runtimes.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SingularityOptions"),
+						},
+					},
+					"enroot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enroot options are used by enroot runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.EnrootOptions"),
+						},
+					},
+					"podmanHPC": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodmanHPC options are used by podman-hpc runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.PodmanHPCOptions"),
+						},
+					},
+					"charliecloud": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Charliecloud options are used by charliecloud runtime.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.CharliecloudOptions"),
+						},
+					},
+					"mpi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MPI describes task layout of an MPI job. When set, image is started as ntasks MPI ranks with srun --mpi.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.MPIOptions"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources describes required resources for a job.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmResources"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the WlmJob to fit on a node. Selector which must match a node's labels for the WlmJob to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results may be specified for an optional results collection step. When specified, after job is completed all results will be downloaded from WLM cluster with respect to this configuration.",
+							Ref:         ref("github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobResults"),
+						},
+					},
+				},
+				Required: []string{"image"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.CharliecloudOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.EnrootOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.JobResults", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.MPIOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.PodmanHPCOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.SingularityOptions", "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1.WlmResources", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar"},
+	}
+}
+
+func schema_operator_apis_wlm_v1beta1_WlmResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WlmResources is a schema for wlm resources.",
+				Properties: map[string]spec.Schema{
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is a number of nodes required for a job.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cpuPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUPerNode is a number of cpus required on each node.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemPerNode is an amount of memory required on each node, e.g. 512Mi.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"wallTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WallTime is a job time limit, e.g. 1h30m.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
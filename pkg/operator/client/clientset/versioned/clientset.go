@@ -18,6 +18,7 @@ package versioned
 
 import (
 	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1"
+	wlmv1beta1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -26,8 +27,9 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	WlmV1alpha1() wlmv1alpha1.WlmV1alpha1Interface
+	WlmV1beta1() wlmv1beta1.WlmV1beta1Interface
 	// Deprecated: please explicitly pick a version if possible.
-	Wlm() wlmv1alpha1.WlmV1alpha1Interface
+	Wlm() wlmv1beta1.WlmV1beta1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -35,6 +37,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	wlmV1alpha1 *wlmv1alpha1.WlmV1alpha1Client
+	wlmV1beta1  *wlmv1beta1.WlmV1beta1Client
 }
 
 // WlmV1alpha1 retrieves the WlmV1alpha1Client
@@ -42,10 +45,15 @@ func (c *Clientset) WlmV1alpha1() wlmv1alpha1.WlmV1alpha1Interface {
 	return c.wlmV1alpha1
 }
 
+// WlmV1beta1 retrieves the WlmV1beta1Client
+func (c *Clientset) WlmV1beta1() wlmv1beta1.WlmV1beta1Interface {
+	return c.wlmV1beta1
+}
+
 // Deprecated: Wlm retrieves the default version of WlmClient.
 // Please explicitly pick a version.
-func (c *Clientset) Wlm() wlmv1alpha1.WlmV1alpha1Interface {
-	return c.wlmV1alpha1
+func (c *Clientset) Wlm() wlmv1beta1.WlmV1beta1Interface {
+	return c.wlmV1beta1
 }
 
 // Discovery retrieves the DiscoveryClient
@@ -68,6 +76,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.wlmV1beta1, err = wlmv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -81,6 +93,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.wlmV1alpha1 = wlmv1alpha1.NewForConfigOrDie(c)
+	cs.wlmV1beta1 = wlmv1beta1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -90,6 +103,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.wlmV1alpha1 = wlmv1alpha1.New(c)
+	cs.wlmV1beta1 = wlmv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -20,6 +20,8 @@ import (
 	clientset "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned"
 	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1"
 	fakewlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1alpha1/fake"
+	wlmv1beta1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1beta1"
+	fakewlmv1beta1 "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/typed/wlm/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -74,7 +76,12 @@ func (c *Clientset) WlmV1alpha1() wlmv1alpha1.WlmV1alpha1Interface {
 	return &fakewlmv1alpha1.FakeWlmV1alpha1{Fake: &c.Fake}
 }
 
-// Wlm retrieves the WlmV1alpha1Client
-func (c *Clientset) Wlm() wlmv1alpha1.WlmV1alpha1Interface {
-	return &fakewlmv1alpha1.FakeWlmV1alpha1{Fake: &c.Fake}
+// WlmV1beta1 retrieves the WlmV1beta1Client
+func (c *Clientset) WlmV1beta1() wlmv1beta1.WlmV1beta1Interface {
+	return &fakewlmv1beta1.FakeWlmV1beta1{Fake: &c.Fake}
+}
+
+// Wlm retrieves the WlmV1beta1Client
+func (c *Clientset) Wlm() wlmv1beta1.WlmV1beta1Interface {
+	return &fakewlmv1beta1.FakeWlmV1beta1{Fake: &c.Fake}
 }
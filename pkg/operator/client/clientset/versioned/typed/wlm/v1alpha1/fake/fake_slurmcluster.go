@@ -0,0 +1,138 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSlurmClusters implements SlurmClusterInterface
+type FakeSlurmClusters struct {
+	Fake *FakeWlmV1alpha1
+	ns   string
+}
+
+var slurmclustersResource = schema.GroupVersionResource{Group: "wlm.sylabs.io", Version: "v1alpha1", Resource: "slurmclusters"}
+
+var slurmclustersKind = schema.GroupVersionKind{Group: "wlm.sylabs.io", Version: "v1alpha1", Kind: "SlurmCluster"}
+
+// Get takes name of the slurmCluster, and returns the corresponding slurmCluster object, and an error if there is any.
+func (c *FakeSlurmClusters) Get(name string, options v1.GetOptions) (result *v1alpha1.SlurmCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(slurmclustersResource, c.ns, name), &v1alpha1.SlurmCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SlurmCluster), err
+}
+
+// List takes label and field selectors, and returns the list of SlurmClusters that match those selectors.
+func (c *FakeSlurmClusters) List(opts v1.ListOptions) (result *v1alpha1.SlurmClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(slurmclustersResource, slurmclustersKind, c.ns, opts), &v1alpha1.SlurmClusterList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.SlurmClusterList{ListMeta: obj.(*v1alpha1.SlurmClusterList).ListMeta}
+	for _, item := range obj.(*v1alpha1.SlurmClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested slurmClusters.
+func (c *FakeSlurmClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(slurmclustersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a slurmCluster and creates it.  Returns the server's representation of the slurmCluster, and an error, if there is any.
+func (c *FakeSlurmClusters) Create(slurmCluster *v1alpha1.SlurmCluster) (result *v1alpha1.SlurmCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(slurmclustersResource, c.ns, slurmCluster), &v1alpha1.SlurmCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SlurmCluster), err
+}
+
+// Update takes the representation of a slurmCluster and updates it. Returns the server's representation of the slurmCluster, and an error, if there is any.
+func (c *FakeSlurmClusters) Update(slurmCluster *v1alpha1.SlurmCluster) (result *v1alpha1.SlurmCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(slurmclustersResource, c.ns, slurmCluster), &v1alpha1.SlurmCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SlurmCluster), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSlurmClusters) UpdateStatus(slurmCluster *v1alpha1.SlurmCluster) (*v1alpha1.SlurmCluster, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(slurmclustersResource, "status", c.ns, slurmCluster), &v1alpha1.SlurmCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SlurmCluster), err
+}
+
+// Delete takes name of the slurmCluster and deletes it. Returns an error if one occurs.
+func (c *FakeSlurmClusters) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(slurmclustersResource, c.ns, name), &v1alpha1.SlurmCluster{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSlurmClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(slurmclustersResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.SlurmClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched slurmCluster.
+func (c *FakeSlurmClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.SlurmCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(slurmclustersResource, c.ns, name, pt, data, subresources...), &v1alpha1.SlurmCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SlurmCluster), err
+}
@@ -26,6 +26,10 @@ type FakeWlmV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeWlmV1alpha1) SlurmClusters(namespace string) v1alpha1.SlurmClusterInterface {
+	return &FakeSlurmClusters{c, namespace}
+}
+
 func (c *FakeWlmV1alpha1) SlurmJobs(namespace string) v1alpha1.SlurmJobInterface {
 	return &FakeSlurmJobs{c, namespace}
 }
@@ -34,6 +38,14 @@ func (c *FakeWlmV1alpha1) WlmJobs(namespace string) v1alpha1.WlmJobInterface {
 	return &FakeWlmJobs{c, namespace}
 }
 
+func (c *FakeWlmV1alpha1) WlmJobDefaults() v1alpha1.WlmJobDefaultInterface {
+	return &FakeWlmJobDefaults{c}
+}
+
+func (c *FakeWlmV1alpha1) WlmPartitions(namespace string) v1alpha1.WlmPartitionInterface {
+	return &FakeWlmPartitions{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeWlmV1alpha1) RESTClient() rest.Interface {
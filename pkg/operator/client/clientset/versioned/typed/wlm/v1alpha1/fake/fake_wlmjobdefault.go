@@ -0,0 +1,118 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWlmJobDefaults implements WlmJobDefaultInterface
+type FakeWlmJobDefaults struct {
+	Fake *FakeWlmV1alpha1
+}
+
+var wlmjobdefaultsResource = schema.GroupVersionResource{Group: "wlm.sylabs.io", Version: "v1alpha1", Resource: "wlmjobdefaults"}
+
+var wlmjobdefaultsKind = schema.GroupVersionKind{Group: "wlm.sylabs.io", Version: "v1alpha1", Kind: "WlmJobDefault"}
+
+// Get takes name of the wlmJobDefault, and returns the corresponding wlmJobDefault object, and an error if there is any.
+func (c *FakeWlmJobDefaults) Get(name string, options v1.GetOptions) (result *v1alpha1.WlmJobDefault, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(wlmjobdefaultsResource, name), &v1alpha1.WlmJobDefault{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmJobDefault), err
+}
+
+// List takes label and field selectors, and returns the list of WlmJobDefaults that match those selectors.
+func (c *FakeWlmJobDefaults) List(opts v1.ListOptions) (result *v1alpha1.WlmJobDefaultList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(wlmjobdefaultsResource, wlmjobdefaultsKind, opts), &v1alpha1.WlmJobDefaultList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.WlmJobDefaultList{ListMeta: obj.(*v1alpha1.WlmJobDefaultList).ListMeta}
+	for _, item := range obj.(*v1alpha1.WlmJobDefaultList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested wlmJobDefaults.
+func (c *FakeWlmJobDefaults) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(wlmjobdefaultsResource, opts))
+}
+
+// Create takes the representation of a wlmJobDefault and creates it.  Returns the server's representation of the wlmJobDefault, and an error, if there is any.
+func (c *FakeWlmJobDefaults) Create(wlmJobDefault *v1alpha1.WlmJobDefault) (result *v1alpha1.WlmJobDefault, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(wlmjobdefaultsResource, wlmJobDefault), &v1alpha1.WlmJobDefault{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmJobDefault), err
+}
+
+// Update takes the representation of a wlmJobDefault and updates it. Returns the server's representation of the wlmJobDefault, and an error, if there is any.
+func (c *FakeWlmJobDefaults) Update(wlmJobDefault *v1alpha1.WlmJobDefault) (result *v1alpha1.WlmJobDefault, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(wlmjobdefaultsResource, wlmJobDefault), &v1alpha1.WlmJobDefault{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmJobDefault), err
+}
+
+// Delete takes name of the wlmJobDefault and deletes it. Returns an error if one occurs.
+func (c *FakeWlmJobDefaults) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(wlmjobdefaultsResource, name), &v1alpha1.WlmJobDefault{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWlmJobDefaults) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(wlmjobdefaultsResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.WlmJobDefaultList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched wlmJobDefault.
+func (c *FakeWlmJobDefaults) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.WlmJobDefault, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(wlmjobdefaultsResource, name, pt, data, subresources...), &v1alpha1.WlmJobDefault{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmJobDefault), err
+}
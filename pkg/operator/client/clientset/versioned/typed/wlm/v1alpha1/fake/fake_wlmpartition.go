@@ -0,0 +1,138 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWlmPartitions implements WlmPartitionInterface
+type FakeWlmPartitions struct {
+	Fake *FakeWlmV1alpha1
+	ns   string
+}
+
+var wlmpartitionsResource = schema.GroupVersionResource{Group: "wlm.sylabs.io", Version: "v1alpha1", Resource: "wlmpartitions"}
+
+var wlmpartitionsKind = schema.GroupVersionKind{Group: "wlm.sylabs.io", Version: "v1alpha1", Kind: "WlmPartition"}
+
+// Get takes name of the wlmPartition, and returns the corresponding wlmPartition object, and an error if there is any.
+func (c *FakeWlmPartitions) Get(name string, options v1.GetOptions) (result *v1alpha1.WlmPartition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(wlmpartitionsResource, c.ns, name), &v1alpha1.WlmPartition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmPartition), err
+}
+
+// List takes label and field selectors, and returns the list of WlmPartitions that match those selectors.
+func (c *FakeWlmPartitions) List(opts v1.ListOptions) (result *v1alpha1.WlmPartitionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(wlmpartitionsResource, wlmpartitionsKind, c.ns, opts), &v1alpha1.WlmPartitionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.WlmPartitionList{ListMeta: obj.(*v1alpha1.WlmPartitionList).ListMeta}
+	for _, item := range obj.(*v1alpha1.WlmPartitionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested wlmPartitions.
+func (c *FakeWlmPartitions) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(wlmpartitionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a wlmPartition and creates it.  Returns the server's representation of the wlmPartition, and an error, if there is any.
+func (c *FakeWlmPartitions) Create(wlmPartition *v1alpha1.WlmPartition) (result *v1alpha1.WlmPartition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(wlmpartitionsResource, c.ns, wlmPartition), &v1alpha1.WlmPartition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmPartition), err
+}
+
+// Update takes the representation of a wlmPartition and updates it. Returns the server's representation of the wlmPartition, and an error, if there is any.
+func (c *FakeWlmPartitions) Update(wlmPartition *v1alpha1.WlmPartition) (result *v1alpha1.WlmPartition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(wlmpartitionsResource, c.ns, wlmPartition), &v1alpha1.WlmPartition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmPartition), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeWlmPartitions) UpdateStatus(wlmPartition *v1alpha1.WlmPartition) (*v1alpha1.WlmPartition, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(wlmpartitionsResource, "status", c.ns, wlmPartition), &v1alpha1.WlmPartition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmPartition), err
+}
+
+// Delete takes name of the wlmPartition and deletes it. Returns an error if one occurs.
+func (c *FakeWlmPartitions) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(wlmpartitionsResource, c.ns, name), &v1alpha1.WlmPartition{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWlmPartitions) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(wlmpartitionsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.WlmPartitionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched wlmPartition.
+func (c *FakeWlmPartitions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.WlmPartition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(wlmpartitionsResource, c.ns, name, pt, data, subresources...), &v1alpha1.WlmPartition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WlmPartition), err
+}
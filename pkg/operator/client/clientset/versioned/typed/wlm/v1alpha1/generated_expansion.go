@@ -16,6 +16,12 @@
 
 package v1alpha1
 
+type SlurmClusterExpansion interface{}
+
 type SlurmJobExpansion interface{}
 
 type WlmJobExpansion interface{}
+
+type WlmJobDefaultExpansion interface{}
+
+type WlmPartitionExpansion interface{}
@@ -0,0 +1,189 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	scheme "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// SlurmClustersGetter has a method to return a SlurmClusterInterface.
+// A group's client should implement this interface.
+type SlurmClustersGetter interface {
+	SlurmClusters(namespace string) SlurmClusterInterface
+}
+
+// SlurmClusterInterface has methods to work with SlurmCluster resources.
+type SlurmClusterInterface interface {
+	Create(*v1alpha1.SlurmCluster) (*v1alpha1.SlurmCluster, error)
+	Update(*v1alpha1.SlurmCluster) (*v1alpha1.SlurmCluster, error)
+	UpdateStatus(*v1alpha1.SlurmCluster) (*v1alpha1.SlurmCluster, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.SlurmCluster, error)
+	List(opts v1.ListOptions) (*v1alpha1.SlurmClusterList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.SlurmCluster, err error)
+	SlurmClusterExpansion
+}
+
+// slurmClusters implements SlurmClusterInterface
+type slurmClusters struct {
+	client rest.Interface
+	ns     string
+}
+
+// newSlurmClusters returns a SlurmClusters
+func newSlurmClusters(c *WlmV1alpha1Client, namespace string) *slurmClusters {
+	return &slurmClusters{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the slurmCluster, and returns the corresponding slurmCluster object, and an error if there is any.
+func (c *slurmClusters) Get(name string, options v1.GetOptions) (result *v1alpha1.SlurmCluster, err error) {
+	result = &v1alpha1.SlurmCluster{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of SlurmClusters that match those selectors.
+func (c *slurmClusters) List(opts v1.ListOptions) (result *v1alpha1.SlurmClusterList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.SlurmClusterList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested slurmClusters.
+func (c *slurmClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a slurmCluster and creates it.  Returns the server's representation of the slurmCluster, and an error, if there is any.
+func (c *slurmClusters) Create(slurmCluster *v1alpha1.SlurmCluster) (result *v1alpha1.SlurmCluster, err error) {
+	result = &v1alpha1.SlurmCluster{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		Body(slurmCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a slurmCluster and updates it. Returns the server's representation of the slurmCluster, and an error, if there is any.
+func (c *slurmClusters) Update(slurmCluster *v1alpha1.SlurmCluster) (result *v1alpha1.SlurmCluster, err error) {
+	result = &v1alpha1.SlurmCluster{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		Name(slurmCluster.Name).
+		Body(slurmCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *slurmClusters) UpdateStatus(slurmCluster *v1alpha1.SlurmCluster) (result *v1alpha1.SlurmCluster, err error) {
+	result = &v1alpha1.SlurmCluster{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		Name(slurmCluster.Name).
+		SubResource("status").
+		Body(slurmCluster).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the slurmCluster and deletes it. Returns an error if one occurs.
+func (c *slurmClusters) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *slurmClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched slurmCluster.
+func (c *slurmClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.SlurmCluster, err error) {
+	result = &v1alpha1.SlurmCluster{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("slurmclusters").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -25,8 +25,11 @@ import (
 
 type WlmV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	SlurmClustersGetter
 	SlurmJobsGetter
 	WlmJobsGetter
+	WlmJobDefaultsGetter
+	WlmPartitionsGetter
 }
 
 // WlmV1alpha1Client is used to interact with features provided by the wlm.sylabs.io group.
@@ -34,6 +37,10 @@ type WlmV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *WlmV1alpha1Client) SlurmClusters(namespace string) SlurmClusterInterface {
+	return newSlurmClusters(c, namespace)
+}
+
 func (c *WlmV1alpha1Client) SlurmJobs(namespace string) SlurmJobInterface {
 	return newSlurmJobs(c, namespace)
 }
@@ -42,6 +49,14 @@ func (c *WlmV1alpha1Client) WlmJobs(namespace string) WlmJobInterface {
 	return newWlmJobs(c, namespace)
 }
 
+func (c *WlmV1alpha1Client) WlmJobDefaults() WlmJobDefaultInterface {
+	return newWlmJobDefaults(c)
+}
+
+func (c *WlmV1alpha1Client) WlmPartitions(namespace string) WlmPartitionInterface {
+	return newWlmPartitions(c, namespace)
+}
+
 // NewForConfig creates a new WlmV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*WlmV1alpha1Client, error) {
 	config := *c
@@ -0,0 +1,162 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	scheme "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// WlmJobDefaultsGetter has a method to return a WlmJobDefaultInterface.
+// A group's client should implement this interface.
+type WlmJobDefaultsGetter interface {
+	WlmJobDefaults() WlmJobDefaultInterface
+}
+
+// WlmJobDefaultInterface has methods to work with WlmJobDefault resources.
+type WlmJobDefaultInterface interface {
+	Create(*v1alpha1.WlmJobDefault) (*v1alpha1.WlmJobDefault, error)
+	Update(*v1alpha1.WlmJobDefault) (*v1alpha1.WlmJobDefault, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.WlmJobDefault, error)
+	List(opts v1.ListOptions) (*v1alpha1.WlmJobDefaultList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.WlmJobDefault, err error)
+	WlmJobDefaultExpansion
+}
+
+// wlmJobDefaults implements WlmJobDefaultInterface
+type wlmJobDefaults struct {
+	client rest.Interface
+}
+
+// newWlmJobDefaults returns a WlmJobDefaults
+func newWlmJobDefaults(c *WlmV1alpha1Client) *wlmJobDefaults {
+	return &wlmJobDefaults{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the wlmJobDefault, and returns the corresponding wlmJobDefault object, and an error if there is any.
+func (c *wlmJobDefaults) Get(name string, options v1.GetOptions) (result *v1alpha1.WlmJobDefault, err error) {
+	result = &v1alpha1.WlmJobDefault{}
+	err = c.client.Get().
+		Resource("wlmjobdefaults").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of WlmJobDefaults that match those selectors.
+func (c *wlmJobDefaults) List(opts v1.ListOptions) (result *v1alpha1.WlmJobDefaultList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.WlmJobDefaultList{}
+	err = c.client.Get().
+		Resource("wlmjobdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested wlmJobDefaults.
+func (c *wlmJobDefaults) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("wlmjobdefaults").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a wlmJobDefault and creates it.  Returns the server's representation of the wlmJobDefault, and an error, if there is any.
+func (c *wlmJobDefaults) Create(wlmJobDefault *v1alpha1.WlmJobDefault) (result *v1alpha1.WlmJobDefault, err error) {
+	result = &v1alpha1.WlmJobDefault{}
+	err = c.client.Post().
+		Resource("wlmjobdefaults").
+		Body(wlmJobDefault).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a wlmJobDefault and updates it. Returns the server's representation of the wlmJobDefault, and an error, if there is any.
+func (c *wlmJobDefaults) Update(wlmJobDefault *v1alpha1.WlmJobDefault) (result *v1alpha1.WlmJobDefault, err error) {
+	result = &v1alpha1.WlmJobDefault{}
+	err = c.client.Put().
+		Resource("wlmjobdefaults").
+		Name(wlmJobDefault.Name).
+		Body(wlmJobDefault).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the wlmJobDefault and deletes it. Returns an error if one occurs.
+func (c *wlmJobDefaults) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("wlmjobdefaults").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *wlmJobDefaults) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("wlmjobdefaults").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched wlmJobDefault.
+func (c *wlmJobDefaults) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.WlmJobDefault, err error) {
+	result = &v1alpha1.WlmJobDefault{}
+	err = c.client.Patch(pt).
+		Resource("wlmjobdefaults").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	v1beta1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -51,10 +52,22 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=wlm.sylabs.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("slurmclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1alpha1().SlurmClusters().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("slurmjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1alpha1().SlurmJobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("wlmjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1alpha1().WlmJobs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("wlmjobdefaults"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1alpha1().WlmJobDefaults().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("wlmpartitions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1alpha1().WlmPartitions().Informer()}, nil
+
+		// Group=wlm.sylabs.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("slurmjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1beta1().SlurmJobs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("wlmjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Wlm().V1beta1().WlmJobs().Informer()}, nil
 
 	}
 
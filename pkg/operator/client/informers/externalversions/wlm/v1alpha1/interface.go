@@ -22,10 +22,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// SlurmClusters returns a SlurmClusterInformer.
+	SlurmClusters() SlurmClusterInformer
 	// SlurmJobs returns a SlurmJobInformer.
 	SlurmJobs() SlurmJobInformer
 	// WlmJobs returns a WlmJobInformer.
 	WlmJobs() WlmJobInformer
+	// WlmJobDefaults returns a WlmJobDefaultInformer.
+	WlmJobDefaults() WlmJobDefaultInformer
+	// WlmPartitions returns a WlmPartitionInformer.
+	WlmPartitions() WlmPartitionInformer
 }
 
 type version struct {
@@ -39,6 +45,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// SlurmClusters returns a SlurmClusterInformer.
+func (v *version) SlurmClusters() SlurmClusterInformer {
+	return &slurmClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SlurmJobs returns a SlurmJobInformer.
 func (v *version) SlurmJobs() SlurmJobInformer {
 	return &slurmJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -48,3 +59,13 @@ func (v *version) SlurmJobs() SlurmJobInformer {
 func (v *version) WlmJobs() WlmJobInformer {
 	return &wlmJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// WlmJobDefaults returns a WlmJobDefaultInformer.
+func (v *version) WlmJobDefaults() WlmJobDefaultInformer {
+	return &wlmJobDefaultInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WlmPartitions returns a WlmPartitionInformer.
+func (v *version) WlmPartitions() WlmPartitionInformer {
+	return &wlmPartitionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	time "time"
+
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	versioned "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned"
+	internalinterfaces "github.com/sylabs/wlm-operator/pkg/operator/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/client/listers/wlm/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// WlmJobDefaultInformer provides access to a shared informer and lister for
+// WlmJobDefaults.
+type WlmJobDefaultInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.WlmJobDefaultLister
+}
+
+type wlmJobDefaultInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewWlmJobDefaultInformer constructs a new informer for WlmJobDefault type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewWlmJobDefaultInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredWlmJobDefaultInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredWlmJobDefaultInformer constructs a new informer for WlmJobDefault type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredWlmJobDefaultInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WlmV1alpha1().WlmJobDefaults().List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WlmV1alpha1().WlmJobDefaults().Watch(options)
+			},
+		},
+		&wlmv1alpha1.WlmJobDefault{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *wlmJobDefaultInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredWlmJobDefaultInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *wlmJobDefaultInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&wlmv1alpha1.WlmJobDefault{}, f.defaultInformer)
+}
+
+func (f *wlmJobDefaultInformer) Lister() v1alpha1.WlmJobDefaultLister {
+	return v1alpha1.NewWlmJobDefaultLister(f.Informer().GetIndexer())
+}
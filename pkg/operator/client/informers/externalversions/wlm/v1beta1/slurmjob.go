@@ -0,0 +1,87 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	time "time"
+
+	wlmv1beta1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1"
+	versioned "github.com/sylabs/wlm-operator/pkg/operator/client/clientset/versioned"
+	internalinterfaces "github.com/sylabs/wlm-operator/pkg/operator/client/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/sylabs/wlm-operator/pkg/operator/client/listers/wlm/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// SlurmJobInformer provides access to a shared informer and lister for
+// SlurmJobs.
+type SlurmJobInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.SlurmJobLister
+}
+
+type slurmJobInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewSlurmJobInformer constructs a new informer for SlurmJob type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewSlurmJobInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredSlurmJobInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredSlurmJobInformer constructs a new informer for SlurmJob type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredSlurmJobInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WlmV1beta1().SlurmJobs(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.WlmV1beta1().SlurmJobs(namespace).Watch(options)
+			},
+		},
+		&wlmv1beta1.SlurmJob{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *slurmJobInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredSlurmJobInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *slurmJobInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&wlmv1beta1.SlurmJob{}, f.defaultInformer)
+}
+
+func (f *slurmJobInformer) Lister() v1beta1.SlurmJobLister {
+	return v1beta1.NewSlurmJobLister(f.Informer().GetIndexer())
+}
@@ -16,6 +16,14 @@
 
 package v1alpha1
 
+// SlurmClusterListerExpansion allows custom methods to be added to
+// SlurmClusterLister.
+type SlurmClusterListerExpansion interface{}
+
+// SlurmClusterNamespaceListerExpansion allows custom methods to be added to
+// SlurmClusterNamespaceLister.
+type SlurmClusterNamespaceListerExpansion interface{}
+
 // SlurmJobListerExpansion allows custom methods to be added to
 // SlurmJobLister.
 type SlurmJobListerExpansion interface{}
@@ -31,3 +39,15 @@ type WlmJobListerExpansion interface{}
 // WlmJobNamespaceListerExpansion allows custom methods to be added to
 // WlmJobNamespaceLister.
 type WlmJobNamespaceListerExpansion interface{}
+
+// WlmJobDefaultListerExpansion allows custom methods to be added to
+// WlmJobDefaultLister.
+type WlmJobDefaultListerExpansion interface{}
+
+// WlmPartitionListerExpansion allows custom methods to be added to
+// WlmPartitionLister.
+type WlmPartitionListerExpansion interface{}
+
+// WlmPartitionNamespaceListerExpansion allows custom methods to be added to
+// WlmPartitionNamespaceLister.
+type WlmPartitionNamespaceListerExpansion interface{}
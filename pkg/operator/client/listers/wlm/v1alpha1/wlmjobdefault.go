@@ -0,0 +1,63 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WlmJobDefaultLister helps list WlmJobDefaults.
+type WlmJobDefaultLister interface {
+	// List lists all WlmJobDefaults in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.WlmJobDefault, err error)
+	// Get retrieves the WlmJobDefault from the index for a given name.
+	Get(name string) (*v1alpha1.WlmJobDefault, error)
+	WlmJobDefaultListerExpansion
+}
+
+// wlmJobDefaultLister implements the WlmJobDefaultLister interface.
+type wlmJobDefaultLister struct {
+	indexer cache.Indexer
+}
+
+// NewWlmJobDefaultLister returns a new WlmJobDefaultLister.
+func NewWlmJobDefaultLister(indexer cache.Indexer) WlmJobDefaultLister {
+	return &wlmJobDefaultLister{indexer: indexer}
+}
+
+// List lists all WlmJobDefaults in the indexer.
+func (s *wlmJobDefaultLister) List(selector labels.Selector) (ret []*v1alpha1.WlmJobDefault, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.WlmJobDefault))
+	})
+	return ret, err
+}
+
+// Get retrieves the WlmJobDefault from the index for a given name.
+func (s *wlmJobDefaultLister) Get(name string) (*v1alpha1.WlmJobDefault, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("wlmjobdefault"), name)
+	}
+	return obj.(*v1alpha1.WlmJobDefault), nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by main. DO NOT EDIT.
+
+package v1beta1
+
+// SlurmJobListerExpansion allows custom methods to be added to
+// SlurmJobLister.
+type SlurmJobListerExpansion interface{}
+
+// SlurmJobNamespaceListerExpansion allows custom methods to be added to
+// SlurmJobNamespaceLister.
+type SlurmJobNamespaceListerExpansion interface{}
+
+// WlmJobListerExpansion allows custom methods to be added to
+// WlmJobLister.
+type WlmJobListerExpansion interface{}
+
+// WlmJobNamespaceListerExpansion allows custom methods to be added to
+// WlmJobNamespaceLister.
+type WlmJobNamespaceListerExpansion interface{}
@@ -22,6 +22,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// PartitionLabel is a label of virtual node that holds name of the partition it serves.
+	PartitionLabel = "wlm.sylabs.io/partition"
+	// ClusterLabel is a label of virtual node that holds name of the cluster it belongs to.
+	ClusterLabel = "wlm.sylabs.io/cluster"
+)
+
 var (
 	// ErrAffinityIsNotRequired signalise that affinity for requested resources is not required.
 	ErrAffinityIsNotRequired = errors.New("affinity selectors is not required")
@@ -46,6 +53,9 @@ type Resources struct {
 	MemPerNode int64
 	CPUPerNode int64
 	WallTime   time.Duration
+	// Features holds alternative sets of node features, as returned
+	// by ParseConstraint. Job fits a node having all features of any set.
+	Features [][]string
 }
 
 // AffinityForResources returns k8s affinity for requested resources
@@ -81,14 +91,35 @@ func AffinityForResources(r Resources) (*corev1.Affinity, error) {
 		})
 	}
 
-	if len(nodeMatch) == 0 {
+	if len(nodeMatch) == 0 && len(r.Features) == 0 {
 		return nil, ErrAffinityIsNotRequired
 	}
 
+	// node selector terms are ORed, so each features set
+	// results in a separate term along with resources requirements
+	terms := []corev1.NodeSelectorTerm{{MatchExpressions: nodeMatch}}
+	if len(r.Features) != 0 {
+		terms = make([]corev1.NodeSelectorTerm, 0, len(r.Features))
+		for _, features := range r.Features {
+			match := append([]corev1.NodeSelectorRequirement{}, nodeMatch...)
+			for _, f := range features {
+				key, err := FeatureLabel(f)
+				if err != nil {
+					return nil, err
+				}
+				match = append(match, corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpExists,
+				})
+			}
+			terms = append(terms, corev1.NodeSelectorTerm{MatchExpressions: match})
+		}
+	}
+
 	return &corev1.Affinity{
 		NodeAffinity: &corev1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: nodeMatch}},
+				NodeSelectorTerms: terms,
 			},
 		},
 	}, nil
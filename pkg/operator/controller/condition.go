@@ -0,0 +1,47 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetCondition returns conditions with the passed one added or replacing condition of the
+// same type. Transition time is preserved if condition status hasn't changed.
+func SetCondition(conditions []wlmv1alpha1.JobCondition, c wlmv1alpha1.JobCondition) []wlmv1alpha1.JobCondition {
+	res := make([]wlmv1alpha1.JobCondition, 0, len(conditions)+1)
+	c.LastTransitionTime = metav1.Now()
+	for _, cur := range conditions {
+		if cur.Type != c.Type {
+			res = append(res, cur)
+			continue
+		}
+		if cur.Status == c.Status {
+			c.LastTransitionTime = cur.LastTransitionTime
+		}
+	}
+	return append(res, c)
+}
+
+// HasCondition checks if conditions include condition of the given type.
+func HasCondition(conditions []wlmv1alpha1.JobCondition, t wlmv1alpha1.JobConditionType) bool {
+	for _, c := range conditions {
+		if c.Type == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// FeatureLabelPrefix is a prefix of virtual node labels that reflect
+// features available on a partition, e.g. feature.wlm.sylabs.io/haswell.
+const FeatureLabelPrefix = "feature.wlm.sylabs.io/"
+
+// maxConstraintTerms limits number of alternatives a constraint expression
+// may expand to, so that a pathological expression won't blow pod spec up.
+const maxConstraintTerms = 64
+
+// FeatureLabel returns label key for a feature. An error is returned
+// if feature name cannot be used as a label name.
+func FeatureLabel(feature string) (string, error) {
+	key := FeatureLabelPrefix + feature
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return "", errors.Errorf("invalid feature name %q: %s", feature, strings.Join(errs, "; "))
+	}
+	return key, nil
+}
+
+// ParseConstraint parses Slurm constraint expression, e.g. "(intel|amd)&ib",
+// into disjunctive normal form: a job fits a node if the node has all features
+// of at least one of the returned sets. Both AND operators (& and ,), OR operator (|),
+// parentheses and square brackets are supported. Feature counts (e.g. rack1*2) cannot
+// be expressed with labels and are ignored. Empty constraint results in nil.
+func ParseConstraint(expr string) ([][]string, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	p := &constraintParser{in: expr}
+	terms, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid constraint %q", expr)
+	}
+	if p.pos != len(p.in) {
+		return nil, errors.Errorf("invalid constraint %q: unexpected %q at %d", expr, p.in[p.pos], p.pos)
+	}
+	for i := range terms {
+		sort.Strings(terms[i])
+	}
+	return terms, nil
+}
+
+type constraintParser struct {
+	in  string
+	pos int
+}
+
+func (p *constraintParser) peek() byte {
+	for p.pos < len(p.in) && p.in[p.pos] == ' ' {
+		p.pos++
+	}
+	if p.pos == len(p.in) {
+		return 0
+	}
+	return p.in[p.pos]
+}
+
+// parseOr parses alternatives separated with |.
+func (p *constraintParser) parseOr() ([][]string, error) {
+	terms, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == '|' {
+		p.pos++
+		alt, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, alt...)
+		if len(terms) > maxConstraintTerms {
+			return nil, errors.Errorf("expression expands to more than %d alternatives", maxConstraintTerms)
+		}
+	}
+	return terms, nil
+}
+
+// parseAnd parses operands separated with & or , and combines their alternatives.
+func (p *constraintParser) parseAnd() ([][]string, error) {
+	terms, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for c := p.peek(); c == '&' || c == ','; c = p.peek() {
+		p.pos++
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if len(terms)*len(operand) > maxConstraintTerms {
+			return nil, errors.Errorf("expression expands to more than %d alternatives", maxConstraintTerms)
+		}
+		var product [][]string
+		for _, t := range terms {
+			for _, o := range operand {
+				product = append(product, union(t, o))
+			}
+		}
+		terms = product
+	}
+	return terms, nil
+}
+
+// parseOperand parses a single feature or a grouped expression along with optional count.
+func (p *constraintParser) parseOperand() ([][]string, error) {
+	var terms [][]string
+	switch c := p.peek(); c {
+	case '(', '[':
+		closing := byte(')')
+		if c == '[' {
+			closing = ']'
+		}
+		p.pos++
+		var err error
+		terms, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != closing {
+			return nil, errors.Errorf("missing %q at %d", closing, p.pos)
+		}
+		p.pos++
+	default:
+		start := p.pos
+		for p.pos < len(p.in) && isFeatureChar(p.in[p.pos]) {
+			p.pos++
+		}
+		if start == p.pos {
+			return nil, errors.Errorf("feature name expected at %d", p.pos)
+		}
+		terms = [][]string{{p.in[start:p.pos]}}
+	}
+
+	if p.peek() == '*' {
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.in) && p.in[p.pos] >= '0' && p.in[p.pos] <= '9' {
+			p.pos++
+		}
+		if start == p.pos {
+			return nil, errors.Errorf("count expected at %d", p.pos)
+		}
+	}
+	return terms, nil
+}
+
+func isFeatureChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.'
+}
+
+// union returns features present in any of the passed sets without duplicates.
+func union(a, b []string) []string {
+	res := make([]string, 0, len(a)+len(b))
+	seen := make(map[string]bool, len(a)+len(b))
+	for _, f := range append(append([]string{}, a...), b...) {
+		if !seen[f] {
+			seen[f] = true
+			res = append(res, f)
+		}
+	}
+	return res
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tt := []struct {
+		name        string
+		in          string
+		expected    [][]string
+		expectError bool
+	}{
+		{
+			name:     "empty",
+			in:       "",
+			expected: nil,
+		},
+		{
+			name:     "single feature",
+			in:       "haswell",
+			expected: [][]string{{"haswell"}},
+		},
+		{
+			name:     "and",
+			in:       "intel&ib,avx2",
+			expected: [][]string{{"avx2", "ib", "intel"}},
+		},
+		{
+			name:     "or",
+			in:       "intel|amd",
+			expected: [][]string{{"intel"}, {"amd"}},
+		},
+		{
+			name:     "grouped",
+			in:       "(intel|amd)&ib",
+			expected: [][]string{{"ib", "intel"}, {"amd", "ib"}},
+		},
+		{
+			name:     "brackets with counts",
+			in:       "[rack1*2|rack2*4]&ib",
+			expected: [][]string{{"ib", "rack1"}, {"ib", "rack2"}},
+		},
+		{
+			name:     "duplicates",
+			in:       "(ib|intel)&ib",
+			expected: [][]string{{"ib"}, {"ib", "intel"}},
+		},
+		{
+			name:        "unbalanced",
+			in:          "(intel|amd",
+			expectError: true,
+		},
+		{
+			name:        "dangling operator",
+			in:          "intel&",
+			expectError: true,
+		},
+		{
+			name:        "invalid character",
+			in:          "intel;amd",
+			expectError: true,
+		},
+		{
+			name:        "too many alternatives",
+			in:          "(a|b)&(c|d)&(e|f)&(g|h)&(i|j)&(k|l)&(m|n)",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseConstraint(tc.in)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
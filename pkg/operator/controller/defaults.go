@@ -0,0 +1,174 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceDefaults returns merged specs of job defaults that select the namespace.
+// If no defaults select the namespace, nil is returned.
+func NamespaceDefaults(ctx context.Context, reader client.Reader, namespace string) (*wlmv1alpha1.WlmJobDefaultSpec, error) {
+	var defaults wlmv1alpha1.WlmJobDefaultList
+	if err := reader.List(ctx, &client.ListOptions{}, &defaults); err != nil {
+		return nil, errors.Wrap(err, "could not list job defaults")
+	}
+	if len(defaults.Items) == 0 {
+		return nil, nil
+	}
+
+	var ns corev1.Namespace
+	if err := reader.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return nil, errors.Wrap(err, "could not get namespace")
+	}
+	return MatchingDefaults(defaults.Items, &ns)
+}
+
+// MatchingDefaults merges specs of job defaults that select the namespace into a single spec.
+// Defaults are processed in order of their names and the first set value wins. If no
+// defaults select the namespace, nil is returned.
+func MatchingDefaults(defaults []wlmv1alpha1.WlmJobDefault, ns *corev1.Namespace) (*wlmv1alpha1.WlmJobDefaultSpec, error) {
+	sorted := make([]wlmv1alpha1.WlmJobDefault, len(defaults))
+	copy(sorted, defaults)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var merged *wlmv1alpha1.WlmJobDefaultSpec
+	for _, d := range sorted {
+		if d.Spec.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(d.Spec.NamespaceSelector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid namespace selector of %s", d.Name)
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+
+		if merged == nil {
+			merged = &wlmv1alpha1.WlmJobDefaultSpec{}
+		}
+		mergeDefaults(merged, &d.Spec)
+	}
+	return merged, nil
+}
+
+// mergeDefaults sets fields of dst that are not set yet from src.
+func mergeDefaults(dst, src *wlmv1alpha1.WlmJobDefaultSpec) {
+	dst.NodeSelector = DefaultNodeSelector(dst.NodeSelector, src.NodeSelector)
+	dst.Results = DefaultResults(dst.Results, src.Results)
+	if dst.Runtime == "" {
+		dst.Runtime = src.Runtime
+	}
+
+	r := &dst.Resources
+	r.Nodes = firstNonZero(r.Nodes, src.Resources.Nodes)
+	r.CPUPerNode = firstNonZero(r.CPUPerNode, src.Resources.CPUPerNode)
+	r.MemPerNode = firstNonZero(r.MemPerNode, src.Resources.MemPerNode)
+	r.WallTime = firstNonZero(r.WallTime, src.Resources.WallTime)
+
+	if src.Options == nil {
+		return
+	}
+	if dst.Options == nil {
+		dst.Options = &wlmv1alpha1.SingularityOptionsDefaults{}
+	}
+	o := dst.Options
+	if o.AllowUnsigned == nil {
+		o.AllowUnsigned = src.Options.AllowUnsigned
+	}
+	if o.CleanEnv == nil {
+		o.CleanEnv = src.Options.CleanEnv
+	}
+	if o.NoPrivs == nil {
+		o.NoPrivs = src.Options.NoPrivs
+	}
+	o.Binds = DefaultBinds(o.Binds, src.Options.Binds)
+}
+
+// DefaultNodeSelector returns node selector with default labels
+// added unless the selector already has them.
+func DefaultNodeSelector(selector, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return selector
+	}
+	res := make(map[string]string, len(selector)+len(defaults))
+	for k, v := range defaults {
+		res[k] = v
+	}
+	for k, v := range selector {
+		res[k] = v
+	}
+	return res
+}
+
+// DefaultResults returns results collection with missing mount or path taken from defaults.
+func DefaultResults(results, defaults *wlmv1alpha1.JobResults) *wlmv1alpha1.JobResults {
+	if defaults == nil {
+		return results
+	}
+	if results == nil {
+		return defaults.DeepCopy()
+	}
+
+	res := results.DeepCopy()
+	if reflect.DeepEqual(res.Mount, corev1.Volume{}) {
+		defaults.Mount.DeepCopyInto(&res.Mount)
+	}
+	if res.From == "" {
+		res.From = defaults.From
+	}
+	return res
+}
+
+// DefaultBinds returns binds with default binds added unless
+// the same source path is already bound.
+func DefaultBinds(binds, defaults []string) []string {
+	bound := make(map[string]bool)
+	for _, spec := range binds {
+		for _, b := range strings.Split(spec, ",") {
+			bound[strings.SplitN(b, ":", 2)[0]] = true
+		}
+	}
+
+	res := append([]string(nil), binds...)
+	for _, d := range defaults {
+		src := strings.SplitN(d, ":", 2)[0]
+		if !bound[src] {
+			bound[src] = true
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+func firstNonZero(a, b int64) int64 {
+	if a != 0 {
+		return a
+	}
+	return b
+}
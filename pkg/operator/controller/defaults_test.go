@@ -0,0 +1,130 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchingDefaults(t *testing.T) {
+	allow, deny := true, false
+	defaults := []wlmv1alpha1.WlmJobDefault{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-research"},
+			Spec: wlmv1alpha1.WlmJobDefaultSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "research"}},
+				NodeSelector:      map[string]string{"wlm.sylabs.io/cluster": "hpc", "site": "b"},
+				Resources:         wlmv1alpha1.WlmResources{Nodes: 2, WallTime: 60},
+				Runtime:           wlmv1alpha1.RuntimeApptainer,
+				Options:           &wlmv1alpha1.SingularityOptionsDefaults{AllowUnsigned: &allow, Binds: []string{"/data", "/scratch:/tmp"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-all"},
+			Spec: wlmv1alpha1.WlmJobDefaultSpec{
+				NodeSelector: map[string]string{"site": "a"},
+				Resources:    wlmv1alpha1.WlmResources{Nodes: 1},
+				Options:      &wlmv1alpha1.SingularityOptionsDefaults{AllowUnsigned: &deny, Binds: []string{"/scratch"}},
+			},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		ns       *corev1.Namespace
+		expected *wlmv1alpha1.WlmJobDefaultSpec
+	}{
+		{
+			name: "selected by all",
+			ns:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "research"}}},
+			expected: &wlmv1alpha1.WlmJobDefaultSpec{
+				NodeSelector: map[string]string{"wlm.sylabs.io/cluster": "hpc", "site": "a"},
+				Resources:    wlmv1alpha1.WlmResources{Nodes: 1, WallTime: 60},
+				Runtime:      wlmv1alpha1.RuntimeApptainer,
+				Options:      &wlmv1alpha1.SingularityOptionsDefaults{AllowUnsigned: &deny, Binds: []string{"/scratch", "/data"}},
+			},
+		},
+		{
+			name: "selected by one",
+			ns:   &corev1.Namespace{},
+			expected: &wlmv1alpha1.WlmJobDefaultSpec{
+				NodeSelector: map[string]string{"site": "a"},
+				Resources:    wlmv1alpha1.WlmResources{Nodes: 1},
+				Options:      &wlmv1alpha1.SingularityOptionsDefaults{AllowUnsigned: &deny, Binds: []string{"/scratch"}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := MatchingDefaults(defaults, tc.ns)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+
+	actual, err := MatchingDefaults(defaults[:1], &corev1.Namespace{})
+	require.NoError(t, err)
+	require.Nil(t, actual)
+}
+
+func TestDefaultResults(t *testing.T) {
+	mount := corev1.Volume{
+		Name:         "data",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/results"}},
+	}
+	defaults := &wlmv1alpha1.JobResults{Mount: mount, From: "out"}
+
+	tt := []struct {
+		name     string
+		results  *wlmv1alpha1.JobResults
+		defaults *wlmv1alpha1.JobResults
+		expected *wlmv1alpha1.JobResults
+	}{
+		{
+			name:     "no defaults",
+			results:  &wlmv1alpha1.JobResults{From: "cow.out"},
+			expected: &wlmv1alpha1.JobResults{From: "cow.out"},
+		},
+		{
+			name:     "no results",
+			defaults: defaults,
+			expected: defaults,
+		},
+		{
+			name:     "missing mount",
+			results:  &wlmv1alpha1.JobResults{From: "cow.out"},
+			defaults: defaults,
+			expected: &wlmv1alpha1.JobResults{Mount: mount, From: "cow.out"},
+		},
+		{
+			name:     "missing path",
+			results:  &wlmv1alpha1.JobResults{Mount: corev1.Volume{Name: "other"}},
+			defaults: defaults,
+			expected: &wlmv1alpha1.JobResults{Mount: corev1.Volume{Name: "other"}, From: "out"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, DefaultResults(tc.results, tc.defaults))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// JobIDAnnotation is a job pod annotation that holds ID of the WLM job
+	// submitted for the pod. It is set by configurator once job is submitted.
+	JobIDAnnotation = "wlm.sylabs.io/job-id"
+
+	// CancelAnnotation is a SlurmJob or WlmJob annotation that requests WLM job
+	// to be cancelled. It is copied to the job pod, and configurator cancels
+	// WLM job of the pod. Once job is cancelled pod annotation is set to CancelDone.
+	CancelAnnotation = "wlm.sylabs.io/cancel"
+	// CancelDone is a value of pod cancel annotation set once WLM job is cancelled.
+	CancelDone = "done"
+)
+
+// ClientID returns client ID the WLM job of the pod is submitted with. Virtual kubelet
+// submits jobs with pod UID as client ID, so that job ID can be found by the pod.
+func ClientID(pod *corev1.Pod) string {
+	return string(pod.UID)
+}
+
+// RequestCancel adds cancel annotation to the job pod if the job owning the pod requests
+// its WLM job to be cancelled. It returns true if pod annotations are changed.
+func RequestCancel(job metav1.Object, pod *corev1.Pod) bool {
+	value, ok := job.GetAnnotations()[CancelAnnotation]
+	if !ok {
+		return false
+	}
+	if _, ok := pod.Annotations[CancelAnnotation]; ok {
+		return false
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[CancelAnnotation] = value
+	return true
+}
+
+// JobIDFromPod returns WLM job ID stored in pod annotations. If job
+// is not submitted yet 0 is returned.
+func JobIDFromPod(pod *corev1.Pod) (int64, error) {
+	raw, ok := pod.Annotations[JobIDAnnotation]
+	if !ok {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.Errorf("invalid job id %q", raw)
+	}
+	return id, nil
+}
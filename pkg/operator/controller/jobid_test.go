@@ -0,0 +1,101 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobIDFromPod(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        int64
+		expectError bool
+	}{
+		{
+			name: "not submitted",
+		},
+		{
+			name:        "submitted",
+			annotations: map[string]string{JobIDAnnotation: "42"},
+			want:        42,
+		},
+		{
+			name:        "invalid",
+			annotations: map[string]string{JobIDAnnotation: "42a"},
+			expectError: true,
+		},
+		{
+			name:        "negative",
+			annotations: map[string]string{JobIDAnnotation: "-1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			id, err := JobIDFromPod(pod)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestRequestCancel(t *testing.T) {
+	tests := []struct {
+		name           string
+		job            map[string]string
+		pod            map[string]string
+		expectChanged  bool
+		expectAnnotate map[string]string
+	}{
+		{
+			name:           "not requested",
+			pod:            map[string]string{JobIDAnnotation: "42"},
+			expectAnnotate: map[string]string{JobIDAnnotation: "42"},
+		},
+		{
+			name:           "requested",
+			job:            map[string]string{CancelAnnotation: "true"},
+			expectChanged:  true,
+			expectAnnotate: map[string]string{CancelAnnotation: "true"},
+		},
+		{
+			name:           "already cancelled",
+			job:            map[string]string{CancelAnnotation: "true"},
+			pod:            map[string]string{CancelAnnotation: CancelDone},
+			expectAnnotate: map[string]string{CancelAnnotation: CancelDone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &metav1.ObjectMeta{Annotations: tt.job}
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.pod}}
+			require.Equal(t, tt.expectChanged, RequestCancel(job, pod))
+			require.Equal(t, tt.expectAnnotate, pod.Annotations)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurmjob
+
+import (
+	"time"
+
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/slurm/sbatch"
+)
+
+// Default sets missing slurm job fields from defaults. Default resources
+// are added to the batch script as SBATCH directives unless it already
+// requests them.
+func Default(sj *wlmv1alpha1.SlurmJob, d *wlmv1alpha1.WlmJobDefaultSpec) error {
+	sj.Spec.NodeSelector = controller.DefaultNodeSelector(sj.Spec.NodeSelector, d.NodeSelector)
+	sj.Spec.Results = controller.DefaultResults(sj.Spec.Results, d.Results)
+
+	opts, err := parseBatch(sj.Spec.Batch)
+	if err != nil {
+		return err
+	}
+
+	var add sbatch.Options
+	if opts.Nodes == 0 {
+		add.Nodes = d.Resources.Nodes
+	}
+	if opts.Time == 0 {
+		add.Time = time.Duration(d.Resources.WallTime) * time.Second
+	}
+	if opts.Mem == 0 && opts.MemPerCPU == 0 {
+		add.Mem = d.Resources.MemPerNode
+	}
+	sj.Spec.Batch = sbatch.Insert(sj.Spec.Batch, &add)
+	return nil
+}
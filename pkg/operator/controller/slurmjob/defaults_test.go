@@ -0,0 +1,79 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurmjob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func TestDefault(t *testing.T) {
+	defaults := &v1alpha1.WlmJobDefaultSpec{
+		NodeSelector: map[string]string{"wlm.sylabs.io/cluster": "hpc"},
+		Resources:    v1alpha1.WlmResources{Nodes: 2, CPUPerNode: 4, MemPerNode: 1024, WallTime: 3600},
+	}
+
+	tt := []struct {
+		name        string
+		batch       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "all defaults",
+			batch:    "#!/bin/sh\nsrun hostname\n",
+			expected: "#!/bin/sh\n#SBATCH --time=01:00:00\n#SBATCH --nodes=2\n#SBATCH --mem=1024M\nsrun hostname\n",
+		},
+		{
+			name:     "requested resources",
+			batch:    "#!/bin/sh\n#SBATCH -N 1 -t 5\n#SBATCH --mem-per-cpu=100\nsrun hostname\n",
+			expected: "#!/bin/sh\n#SBATCH -N 1 -t 5\n#SBATCH --mem-per-cpu=100\nsrun hostname\n",
+		},
+		{
+			name:     "all memory",
+			batch:    "#!/bin/sh\n#SBATCH --mem=0\nsrun hostname\n",
+			expected: "#!/bin/sh\n#SBATCH --time=01:00:00\n#SBATCH --nodes=2\n#SBATCH --mem=0\nsrun hostname\n",
+		},
+		{
+			name:        "invalid batch",
+			batch:       "#!/bin/sh\n#SBATCH --nodes=two\nsrun hostname\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sj := &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch:        tc.batch,
+					NodeSelector: map[string]string{"wlm.sylabs.io/containers": "singularity"},
+				},
+			}
+			err := Default(sj, defaults)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, sj.Spec.Batch)
+			require.Equal(t, map[string]string{
+				"wlm.sylabs.io/cluster":    "hpc",
+				"wlm.sylabs.io/containers": "singularity",
+			}, sj.Spec.NodeSelector)
+		})
+	}
+}
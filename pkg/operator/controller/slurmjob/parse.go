@@ -15,118 +15,66 @@
 package slurmjob
 
 import (
-	"bufio"
-	"strconv"
-	"strings"
-
 	"github.com/sylabs/wlm-operator/pkg/operator/controller"
-
-	"github.com/pkg/errors"
-	"github.com/sylabs/wlm-operator/pkg/slurm"
+	"github.com/sylabs/wlm-operator/pkg/slurm/sbatch"
 )
 
 // extractBatchResources extracts resources that should be satisfied for a slurm
-// job to run. More particularly, the following SBATCH directives are parsed:
-// nodes, time, mem, ntasks and/or (n)tasks-per-node.
-// A zero value is returned if corresponding value is not provided.
+// job to run from its SBATCH directives. A zero value is returned if corresponding
+// value is not provided.
 func extractBatchResources(script string) (*controller.Resources, error) {
-	const sbatchHeader = "#SBATCH"
-
-	var err error
-	var res controller.Resources
-
-	s := bufio.NewScanner(strings.NewReader(script))
-	for s.Scan() {
-		line := s.Text()
-		// skip empty lines and shebang
-		if line == "" || strings.HasPrefix(line, "#!") {
-			continue
-		}
-		// #SBATCH headers go first, so stop whenever they are finished
-		if !strings.HasPrefix(line, sbatchHeader) {
-			break
-		}
+	opts, err := parseBatch(script)
+	if err != nil {
+		return nil, err
+	}
+	return batchResources(opts)
+}
 
-		params := strings.Fields(strings.TrimPrefix(line, sbatchHeader))
-		for j := 0; j < len(params); j++ {
-			param := params[j]
-			var value string
-			// handle both '--param=value' and
-			// '--param value' cases
-			i := strings.IndexByte(param, '=')
-			if i != -1 {
-				value = param[i+1:]
-				param = param[:i]
-			} else if i < len(params)-1 {
-				value = params[j+1]
-				j++
-			}
-			res, err = applySbatchParam(res, param, value)
-			if err != nil {
-				return nil, err
-			}
-		}
+// batchResources returns resources required by sbatch options
+// including node features requested with constraint.
+func batchResources(opts *sbatch.Options) (*controller.Resources, error) {
+	res := resourcesFromOptions(opts)
+	features, err := controller.ParseConstraint(opts.Constraint)
+	if err != nil {
+		return nil, &specError{reason: reasonInvalidConstraint, err: err}
 	}
+	res.Features = features
 	return &res, nil
 }
 
-func applySbatchParam(res controller.Resources, param, value string) (controller.Resources, error) {
-	const (
-		timeLimit        = "--time"
-		timeLimitShort   = "-t"
-		nodes            = "--nodes"
-		nodesShort       = "-N"
-		mem              = "--mem"
-		tasksPerNode     = "--ntasks-per-node"
-		cpusPerTask      = "--cpus-per-task"
-		cpusPerTaskShort = "-c"
-	)
+// resourcesFromOptions converts sbatch options into resources required on each node.
+// Cpus per node is a product of cpus per task and tasks per node. When tasks per node
+// is not set, it is derived from total tasks count if nodes count is known. Memory per cpu
+// is converted into memory per node when cpus per node is known. Job requesting all memory
+// of each node fits any node, so it doesn't require any particular amount of memory.
+func resourcesFromOptions(opts *sbatch.Options) controller.Resources {
+	res := controller.Resources{
+		Nodes: opts.Nodes,
+	}
+	if opts.Mem > 0 {
+		res.MemPerNode = opts.Mem
+	}
+	if opts.Time > 0 {
+		res.WallTime = opts.Time
+	}
+
+	tasksPerNode := opts.NTasksPerNode
+	if tasksPerNode == 0 && opts.NTasks != 0 && opts.Nodes != 0 {
+		tasksPerNode = (opts.NTasks + opts.Nodes - 1) / opts.Nodes
+	}
+	if tasksPerNode != 0 || opts.CPUsPerTask != 0 {
+		res.CPUPerNode = max(tasksPerNode, 1) * max(opts.CPUsPerTask, 1)
+	}
+
+	if opts.Mem == 0 && opts.MemPerCPU != 0 {
+		res.MemPerNode = opts.MemPerCPU * max(res.CPUPerNode, 1)
+	}
+	return res
+}
 
-	switch param {
-	case timeLimit, timeLimitShort:
-		duration, err := slurm.ParseDuration(value)
-		if err != nil && err != slurm.ErrDurationIsUnlimited {
-			return controller.Resources{}, errors.Wrapf(err, "could not parse time limit")
-		}
-		if duration != nil {
-			res.WallTime = *duration
-		}
-	case nodes, nodesShort:
-		i := strings.IndexByte(value, '-')
-		// we use min nodes value only
-		if i != -1 {
-			value = value[:i]
-		}
-		nodes, err := strconv.ParseInt(value, 10, 0)
-		if err != nil {
-			return controller.Resources{}, errors.Wrapf(err, "could not parse amount of nodes")
-		}
-		res.Nodes = nodes
-	case mem:
-		// suffixes are not supported yet
-		mem, err := strconv.ParseInt(value, 10, 0)
-		if err != nil {
-			return controller.Resources{}, errors.Wrapf(err, "could not parse memory")
-		}
-		res.MemPerNode = mem
-	case cpusPerTask, cpusPerTaskShort:
-		cpus, err := strconv.ParseInt(value, 10, 0)
-		if err != nil {
-			return controller.Resources{}, errors.Wrapf(err, "could not parse cpus per node")
-		}
-		if res.CPUPerNode == 0 {
-			res.CPUPerNode = 1
-		}
-		res.CPUPerNode *= cpus
-	case tasksPerNode:
-		tasks, err := strconv.ParseInt(value, 10, 0)
-		if err != nil {
-			return controller.Resources{}, errors.Wrapf(err, "could not parse tasks per node")
-		}
-		if res.CPUPerNode == 0 {
-			res.CPUPerNode = 1
-		}
-		res.CPUPerNode *= tasks
+func max(a, b int64) int64 {
+	if a > b {
+		return a
 	}
-	return res, nil
+	return b
 }
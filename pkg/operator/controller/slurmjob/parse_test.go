@@ -222,6 +222,58 @@ srun rm lolcow_latest.sif
 				Nodes:      3,
 			},
 		},
+		{
+			name: "memory suffix",
+			script: `
+#!/bin/sh
+#SBATCH --mem=4G
+srun hostname
+`,
+			expectResources: &controller.Resources{
+				MemPerNode: 4096,
+			},
+		},
+		{
+			name: "comments between directives",
+			script: `
+#!/bin/sh
+# job resources
+#SBATCH --nodes=2
+## SBATCH --nodes=4
+#SBATCH --time=10 # minutes
+srun hostname
+#SBATCH --nodes=8
+`,
+			expectResources: &controller.Resources{
+				Nodes:    2,
+				WallTime: 10 * time.Minute,
+			},
+		},
+		{
+			name: "total tasks and memory per cpu",
+			script: `
+#!/bin/sh
+#SBATCH --ntasks=10 --nodes=4 --cpus-per-task=2
+#SBATCH --mem-per-cpu=512
+srun hostname
+`,
+			expectResources: &controller.Resources{
+				Nodes:      4,
+				CPUPerNode: 6,
+				MemPerNode: 3072,
+			},
+		},
+		{
+			name: "all memory",
+			script: `
+#!/bin/sh
+#SBATCH --nodes=2 --mem=0 --mem-per-cpu=512
+srun hostname
+`,
+			expectResources: &controller.Resources{
+				Nodes: 2,
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -15,26 +15,53 @@
 package slurmjob
 
 import (
-	"github.com/sylabs/wlm-operator/pkg/operator/controller"
-
 	"github.com/pkg/errors"
 	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/slurm/sbatch"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-var errAffinityIsNotRequired = errors.New("affinity selectors is not required")
+// Reasons of slurm job being unschedulable.
+const (
+	reasonInvalidBatch      = "InvalidBatch"
+	reasonInvalidConstraint = "InvalidConstraint"
+	reasonPartitionConflict = "PartitionConflict"
+	// reasonScheduled is set once companion pod is created.
+	reasonScheduled = "Scheduled"
+)
+
+// specError is returned when slurm job spec cannot be satisfied
+// and there is no point in retrying until the spec is changed.
+type specError struct {
+	reason string
+	err    error
+}
+
+func (e *specError) Error() string {
+	return e.err.Error()
+}
+
+// PodName returns name of the job-companion pod of the slurm job.
+func PodName(slurmJob string) string {
+	return slurmJob + "-job"
+}
 
 // newPodForSJ returns a job-companion pod for the slurm job.
 func (r *Reconciler) newPodForSJ(sj *wlmv1alpha1.SlurmJob) (*corev1.Pod, error) {
 	affinity, err := affinityForSj(sj)
-	if err != nil && err != errAffinityIsNotRequired {
-		return nil, errors.Wrap(err, "could not form slurm job pod affinity")
+	if err != nil && err != controller.ErrAffinityIsNotRequired {
+		return nil, err
+	}
+	nodeSelector, err := nodeSelectorForSj(sj)
+	if err != nil {
+		return nil, err
 	}
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      sj.Name + "-job",
+			Name:      PodName(sj.Name),
 			Namespace: sj.Namespace,
 		},
 		Spec: corev1.PodSpec{
@@ -50,22 +77,57 @@ func (r *Reconciler) newPodForSJ(sj *wlmv1alpha1.SlurmJob) (*corev1.Pod, error)
 					Image: "no-image",
 				},
 			},
-			NodeSelector:  nodeSelectorForSj(sj),
+			NodeSelector:  nodeSelector,
 			RestartPolicy: corev1.RestartPolicyNever,
 		},
 	}, nil
 }
 
+// affinityForSj returns node affinity satisfying resources and constraint
+// requested with SBATCH directives. When more than one partition is requested,
+// job may be scheduled on virtual node of any of them.
 func affinityForSj(sj *wlmv1alpha1.SlurmJob) (*corev1.Affinity, error) {
-	requiredResources, err := extractBatchResources(sj.Spec.Batch)
+	opts, err := parseBatch(sj.Spec.Batch)
+	if err != nil {
+		return nil, err
+	}
+	requiredResources, err := batchResources(opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not extract required resources")
+		return nil, err
 	}
 
-	return controller.AffinityForResources(*requiredResources)
+	affinity, err := controller.AffinityForResources(*requiredResources)
+	if err != nil && err != controller.ErrAffinityIsNotRequired {
+		return nil, &specError{reason: reasonInvalidConstraint, err: err}
+	}
+	if len(opts.Partitions) < 2 {
+		return affinity, err
+	}
+
+	if affinity == nil {
+		affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{}},
+				},
+			},
+		}
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      controller.PartitionLabel,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   opts.Partitions,
+		})
+	}
+	return affinity, nil
 }
 
-func nodeSelectorForSj(sj *wlmv1alpha1.SlurmJob) map[string]string {
+// nodeSelectorForSj returns node selector for the slurm job companion pod. Single
+// partition requested with SBATCH directives is turned into partition selector,
+// an error is returned if it contradicts partition selected in the slurm job spec.
+func nodeSelectorForSj(sj *wlmv1alpha1.SlurmJob) (map[string]string, error) {
 	nodeSelector := make(map[string]string)
 
 	for k, v := range controller.DefaultNodeSelectors {
@@ -75,5 +137,46 @@ func nodeSelectorForSj(sj *wlmv1alpha1.SlurmJob) map[string]string {
 	for k, v := range sj.Spec.NodeSelector {
 		nodeSelector[k] = v
 	}
-	return nodeSelector
+
+	opts, err := parseBatch(sj.Spec.Batch)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Partitions) == 0 {
+		return nodeSelector, nil
+	}
+
+	selected, ok := nodeSelector[controller.PartitionLabel]
+	if ok && !contains(opts.Partitions, selected) {
+		return nil, &specError{
+			reason: reasonPartitionConflict,
+			err: errors.Errorf("node selector requires partition %s while batch script requests %v",
+				selected, opts.Partitions),
+		}
+	}
+	if len(opts.Partitions) == 1 {
+		nodeSelector[controller.PartitionLabel] = opts.Partitions[0]
+	}
+	return nodeSelector, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBatch parses SBATCH directives of the batch script.
+func parseBatch(script string) (*sbatch.Options, error) {
+	opts, err := sbatch.Parse(script)
+	if err != nil {
+		return nil, &specError{
+			reason: reasonInvalidBatch,
+			err:    errors.Wrap(err, "could not parse sbatch directives"),
+		}
+	}
+	return opts, nil
 }
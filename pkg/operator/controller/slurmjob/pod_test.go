@@ -222,6 +222,85 @@ srun rm lolcow_latest.sif
 				},
 			},
 		},
+		{
+			name: "constraint and partitions",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch: `
+#!/bin/sh
+#SBATCH --nodes=2
+#SBATCH --partition=debug,gpu
+#SBATCH --constraint="(intel|amd)&ib"
+srun hostname
+`,
+				},
+			},
+			expectAffinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "wlm.sylabs.io/nodes",
+										Operator: "Gt",
+										Values:   []string{"1"},
+									},
+									{
+										Key:      "feature.wlm.sylabs.io/ib",
+										Operator: "Exists",
+									},
+									{
+										Key:      "feature.wlm.sylabs.io/intel",
+										Operator: "Exists",
+									},
+									{
+										Key:      "wlm.sylabs.io/partition",
+										Operator: "In",
+										Values:   []string{"debug", "gpu"},
+									},
+								},
+							},
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "wlm.sylabs.io/nodes",
+										Operator: "Gt",
+										Values:   []string{"1"},
+									},
+									{
+										Key:      "feature.wlm.sylabs.io/amd",
+										Operator: "Exists",
+									},
+									{
+										Key:      "feature.wlm.sylabs.io/ib",
+										Operator: "Exists",
+									},
+									{
+										Key:      "wlm.sylabs.io/partition",
+										Operator: "In",
+										Values:   []string{"debug", "gpu"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid constraint",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch: `
+#!/bin/sh
+#SBATCH --constraint=intel|
+srun hostname
+`,
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -236,3 +315,83 @@ srun rm lolcow_latest.sif
 		})
 	}
 }
+
+func TestNodeSelectorForSj(t *testing.T) {
+	tt := []struct {
+		name           string
+		sj             *v1alpha1.SlurmJob
+		expectSelector map[string]string
+		expectReason   string
+	}{
+		{
+			name: "no partition",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch:        "#!/bin/sh\nsrun hostname\n",
+					NodeSelector: map[string]string{"wlm.sylabs.io/containers": "singularity"},
+				},
+			},
+			expectSelector: map[string]string{
+				"type":                     "virtual-kubelet",
+				"wlm.sylabs.io/containers": "singularity",
+			},
+		},
+		{
+			name: "single partition",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch: "#!/bin/sh\n#SBATCH -p gpu\nsrun hostname\n",
+				},
+			},
+			expectSelector: map[string]string{
+				"type":                    "virtual-kubelet",
+				"wlm.sylabs.io/partition": "gpu",
+			},
+		},
+		{
+			name: "multiple partitions",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch:        "#!/bin/sh\n#SBATCH --partition=debug,gpu\nsrun hostname\n",
+					NodeSelector: map[string]string{"wlm.sylabs.io/partition": "gpu"},
+				},
+			},
+			expectSelector: map[string]string{
+				"type":                    "virtual-kubelet",
+				"wlm.sylabs.io/partition": "gpu",
+			},
+		},
+		{
+			name: "partition conflict",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch:        "#!/bin/sh\n#SBATCH --partition=gpu\nsrun hostname\n",
+					NodeSelector: map[string]string{"wlm.sylabs.io/partition": "debug"},
+				},
+			},
+			expectReason: reasonPartitionConflict,
+		},
+		{
+			name: "invalid batch",
+			sj: &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch: "#!/bin/sh\n#SBATCH --time=foo\nsrun hostname\n",
+				},
+			},
+			expectReason: reasonInvalidBatch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := nodeSelectorForSj(tc.sj)
+			if tc.expectReason != "" {
+				require.IsType(t, &specError{}, err)
+				require.Equal(t, tc.expectReason, err.(*specError).reason)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectSelector, actual)
+		})
+	}
+}
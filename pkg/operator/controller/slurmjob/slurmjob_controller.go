@@ -17,9 +17,11 @@ package slurmjob
 import (
 	"context"
 	"os"
+	"reflect"
 
 	"github.com/golang/glog"
 	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	wlmcontroller "github.com/sylabs/wlm-operator/pkg/operator/controller"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -107,7 +109,12 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	sjPod, err := r.newPodForSJ(sj)
 	if err != nil {
 		glog.Errorf("Could not translate slurm job into pod: %v", err)
-		return reconcile.Result{}, err
+		specErr, ok := err.(*specError)
+		if !ok {
+			return reconcile.Result{}, err
+		}
+		// spec won't fit until changed, so report it and don't requeue
+		return reconcile.Result{}, r.setUnschedulable(sj, corev1.ConditionTrue, specErr.reason, specErr.Error())
 	}
 
 	// Set SlurmJob instance as the owner and controller
@@ -138,7 +145,28 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 
 	glog.Infof("Updating slurm job %q", sj.Name)
 	// Otherwise smth has changed, need to update things
+	if wlmcontroller.RequestCancel(sj, sjCurrentPod) {
+		glog.Infof("Requesting cancellation of slurm job %q", sj.Name)
+		if err := r.client.Update(context.Background(), sjCurrentPod); err != nil {
+			glog.Errorf("Could not update pod: %v", err)
+			return reconcile.Result{}, err
+		}
+	}
 	sj.Status.Status = string(sjCurrentPod.Status.Phase)
+	usage, err := wlmcontroller.UsageFromPod(sjCurrentPod)
+	if err != nil {
+		glog.Errorf("Could not get job usage: %v", err)
+	}
+	if usage != nil {
+		sj.Status.Usage = usage
+	}
+	if wlmcontroller.HasCondition(sj.Status.Conditions, wlmv1alpha1.JobUnschedulable) {
+		sj.Status.Conditions = wlmcontroller.SetCondition(sj.Status.Conditions, wlmv1alpha1.JobCondition{
+			Type:   wlmv1alpha1.JobUnschedulable,
+			Status: corev1.ConditionFalse,
+			Reason: reasonScheduled,
+		})
+	}
 	err = r.client.Status().Update(context.Background(), sj)
 	if err != nil {
 		glog.Errorf("Could not update slurm job: %v", err)
@@ -146,3 +174,24 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	}
 	return reconcile.Result{}, nil
 }
+
+// setUnschedulable updates slurm job unschedulable condition.
+func (r *Reconciler) setUnschedulable(sj *wlmv1alpha1.SlurmJob, status corev1.ConditionStatus, reason, message string) error {
+	conditions := wlmcontroller.SetCondition(sj.Status.Conditions, wlmv1alpha1.JobCondition{
+		Type:    wlmv1alpha1.JobUnschedulable,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if reflect.DeepEqual(conditions, sj.Status.Conditions) {
+		return nil
+	}
+
+	sj.Status.Conditions = conditions
+	err := r.client.Status().Update(context.Background(), sj)
+	if err != nil {
+		glog.Errorf("Could not update slurm job: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurmjob
+
+import (
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+)
+
+// Validate checks that slurm job can be scheduled: its SBATCH directives are valid
+// and do not contradict the spec, and requested resources fit into at least one of
+// the known partitions of the cluster job is pinned to, if any.
+func Validate(sj *wlmv1alpha1.SlurmJob, known []wlmv1alpha1.WlmPartition) error {
+	if _, err := affinityForSj(sj); err != nil && err != controller.ErrAffinityIsNotRequired {
+		return err
+	}
+	nodeSelector, err := nodeSelectorForSj(sj)
+	if err != nil {
+		return err
+	}
+
+	opts, err := parseBatch(sj.Spec.Batch)
+	if err != nil {
+		return err
+	}
+	res, err := batchResources(opts)
+	if err != nil {
+		return err
+	}
+
+	partitions := opts.Partitions
+	if p, ok := nodeSelector[controller.PartitionLabel]; ok {
+		partitions = []string{p}
+	}
+	return controller.CheckResources(*res, partitions, controller.ClusterPartitions(nodeSelector, known))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurmjob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	known := []v1alpha1.WlmPartition{
+		{
+			Spec:   v1alpha1.WlmPartitionSpec{Partition: "debug"},
+			Status: v1alpha1.WlmPartitionStatus{MaxNodes: 2},
+		},
+		{
+			Spec:   v1alpha1.WlmPartitionSpec{Partition: "long"},
+			Status: v1alpha1.WlmPartitionStatus{MaxNodes: 8},
+		},
+	}
+
+	tt := []struct {
+		name         string
+		batch        string
+		nodeSelector map[string]string
+		expectError  bool
+	}{
+		{
+			name:  "valid",
+			batch: "#!/bin/sh\n#SBATCH --nodes=4\nsrun hostname\n",
+		},
+		{
+			name:        "invalid directive",
+			batch:       "#!/bin/sh\n#SBATCH --nodes=four\nsrun hostname\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid constraint",
+			batch:       "#!/bin/sh\n#SBATCH --constraint=(intel\nsrun hostname\n",
+			expectError: true,
+		},
+		{
+			name:        "too many nodes",
+			batch:       "#!/bin/sh\n#SBATCH --nodes=16\nsrun hostname\n",
+			expectError: true,
+		},
+		{
+			name:         "too many nodes for selected partition",
+			batch:        "#!/bin/sh\n#SBATCH --nodes=4\nsrun hostname\n",
+			nodeSelector: map[string]string{"wlm.sylabs.io/partition": "debug"},
+			expectError:  true,
+		},
+		{
+			name:         "partition conflict",
+			batch:        "#!/bin/sh\n#SBATCH --partition=long\nsrun hostname\n",
+			nodeSelector: map[string]string{"wlm.sylabs.io/partition": "debug"},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sj := &v1alpha1.SlurmJob{
+				Spec: v1alpha1.SlurmJobSpec{
+					Batch:        tc.batch,
+					NodeSelector: tc.nodeSelector,
+				},
+			}
+			err := Validate(sj, known)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
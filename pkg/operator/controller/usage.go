@@ -0,0 +1,87 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// UsageAnnotation is a job pod annotation that holds JSON encoded job resources
+// usage. It is set by configurator once job pod is finished, usage is empty
+// when it can't be fetched from accounting.
+const UsageAnnotation = "wlm.sylabs.io/job-usage"
+
+// UsageFromPod returns job usage stored in pod annotations. If there is
+// no usage annotation yet nil is returned.
+func UsageFromPod(pod *corev1.Pod) (*wlmv1alpha1.JobUsage, error) {
+	raw, ok := pod.Annotations[UsageAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var usage wlmv1alpha1.JobUsage
+	if err := json.Unmarshal([]byte(raw), &usage); err != nil {
+		return nil, errors.Wrap(err, "could not decode job usage")
+	}
+	return &usage, nil
+}
+
+// UsageFromResponse converts JobUsage response into job usage status.
+// Times, energy and allocated resources are taken from the job allocation,
+// while memory and disk usage are maximum among all job steps.
+func UsageFromResponse(resp *api.JobUsageResponse) (*wlmv1alpha1.JobUsage, error) {
+	if len(resp.Steps) == 0 {
+		return nil, errors.New("job usage has no steps")
+	}
+	alloc := resp.Steps[0]
+
+	cpuTime, err := ptypes.Duration(alloc.CpuTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cpu time")
+	}
+	elapsed, err := ptypes.Duration(alloc.Elapsed)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid elapsed time")
+	}
+
+	usage := &wlmv1alpha1.JobUsage{
+		CPUTime:          int64(cpuTime.Seconds()),
+		Elapsed:          int64(elapsed.Seconds()),
+		ConsumedEnergy:   alloc.ConsumedEnergy,
+		AllocTRES:        alloc.AllocTres,
+		CPUEfficiency:    resp.CpuEfficiency,
+		MemoryEfficiency: resp.MemoryEfficiency,
+	}
+	for _, s := range resp.Steps {
+		usage.MaxRSS = maxInt64(usage.MaxRSS, s.MaxRss)
+		usage.AveRSS = maxInt64(usage.AveRSS, s.AveRss)
+		usage.MaxDiskRead = maxInt64(usage.MaxDiskRead, s.MaxDiskRead)
+		usage.MaxDiskWrite = maxInt64(usage.MaxDiskWrite, s.MaxDiskWrite)
+	}
+	return usage, nil
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUsageFromPod(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *wlmv1alpha1.JobUsage
+		expectError bool
+	}{
+		{
+			name: "no usage",
+		},
+		{
+			name: "usage",
+			annotations: map[string]string{
+				UsageAnnotation: `{"cpuTime":60,"elapsed":120,"maxRSS":1024,"allocTRES":{"cpu":"1"},"cpuEfficiency":50}`,
+			},
+			want: &wlmv1alpha1.JobUsage{
+				CPUTime:       60,
+				Elapsed:       120,
+				MaxRSS:        1024,
+				AllocTRES:     map[string]string{"cpu": "1"},
+				CPUEfficiency: 50,
+			},
+		},
+		{
+			name:        "invalid usage",
+			annotations: map[string]string{UsageAnnotation: "cpu=1"},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := UsageFromPod(pod)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsageFromResponse(t *testing.T) {
+	_, err := UsageFromResponse(&api.JobUsageResponse{})
+	require.Error(t, err)
+
+	got, err := UsageFromResponse(&api.JobUsageResponse{
+		Steps: []*api.JobStepUsage{
+			{
+				Id:             "35",
+				Elapsed:        ptypes.DurationProto(2 * time.Minute),
+				CpuTime:        ptypes.DurationProto(90 * time.Second),
+				ConsumedEnergy: 360,
+				AllocTres:      map[string]string{"cpu": "1", "mem": "1G"},
+			},
+			{
+				Id:           "35.batch",
+				Elapsed:      ptypes.DurationProto(2 * time.Minute),
+				CpuTime:      ptypes.DurationProto(90 * time.Second),
+				MaxRss:       2048,
+				AveRss:       1024,
+				MaxDiskRead:  100,
+				MaxDiskWrite: 10,
+			},
+			{
+				Id:           "35.0",
+				Elapsed:      ptypes.DurationProto(time.Minute),
+				CpuTime:      ptypes.DurationProto(30 * time.Second),
+				MaxRss:       512,
+				AveRss:       256,
+				MaxDiskRead:  200,
+				MaxDiskWrite: 5,
+			},
+		},
+		CpuEfficiency:    75,
+		MemoryEfficiency: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &wlmv1alpha1.JobUsage{
+		CPUTime:          90,
+		Elapsed:          120,
+		MaxRSS:           2048,
+		AveRSS:           1024,
+		MaxDiskRead:      200,
+		MaxDiskWrite:     10,
+		ConsumedEnergy:   360,
+		AllocTRES:        map[string]string{"cpu": "1", "mem": "1G"},
+		CPUEfficiency:    75,
+		MemoryEfficiency: 1,
+	}, got)
+}
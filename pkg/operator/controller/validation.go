@@ -0,0 +1,292 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+// localImagePrefix marks image located on WLM cluster host, e.g. local.file/home/user/lolcow.sif.
+const localImagePrefix = "local.file"
+
+// unsafeChars are characters that are not allowed in images and bind specs
+// as they would break the generated batch script.
+const unsafeChars = " \t\r\n\"'`$\\;&|<>"
+
+var (
+	// imageSchemes lists image transports understood by singularity pull.
+	imageSchemes = map[string]bool{
+		"library":        true,
+		"docker":         true,
+		"docker-archive": true,
+		"docker-daemon":  true,
+		"shub":           true,
+		"oras":           true,
+		"http":           true,
+		"https":          true,
+	}
+
+	// libraryRef matches [[entity/]collection/]container[:tag|:sha256.hash] reference.
+	libraryRef = regexp.MustCompile(`^([a-z0-9]+(?:[._-][a-z0-9]+)*/){0,2}[a-z0-9]+(?:[._-][a-z0-9]+)*(?::[a-zA-Z0-9_.,-]+)?$`)
+
+	// appName matches singularity SCIF app names.
+	appName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+	// hostName matches RFC 1123 host names.
+	hostName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+	// dockerRef matches [registry[:port]/]repository[:tag][@digest] reference.
+	dockerRef = regexp.MustCompile(`^(?:[a-zA-Z0-9.-]+(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(?:@[a-z0-9]+:[a-f0-9]{32,})?$`)
+)
+
+// ValidateImage checks that image is a valid singularity image reference,
+// e.g. library://sylabsed/examples/lolcow:latest or local.file/home/user/lolcow.sif.
+func ValidateImage(image string) error {
+	if image == "" {
+		return errors.New("image is required")
+	}
+	if strings.ContainsAny(image, unsafeChars) {
+		return errors.Errorf("image %q contains forbidden characters", image)
+	}
+
+	if strings.HasPrefix(image, localImagePrefix) {
+		file := strings.TrimPrefix(image, localImagePrefix)
+		if !path.IsAbs(file) || strings.HasSuffix(file, "/") {
+			return errors.Errorf("local image %q must be an absolute path to a file", image)
+		}
+		return nil
+	}
+
+	i := strings.Index(image, "://")
+	if i == -1 {
+		return errors.Errorf("image %q must have a transport, e.g. library:// or docker://", image)
+	}
+	scheme, ref := image[:i], image[i+3:]
+	if !imageSchemes[scheme] {
+		return errors.Errorf("image %q has unsupported transport %s", image, scheme)
+	}
+	if ref == "" {
+		return errors.Errorf("image %q has empty reference", image)
+	}
+
+	switch scheme {
+	case "library":
+		if !libraryRef.MatchString(ref) {
+			return errors.Errorf("image %q is not a valid library reference", image)
+		}
+	case "docker":
+		if !dockerRef.MatchString(ref) {
+			return errors.Errorf("image %q is not a valid docker reference", image)
+		}
+	}
+	return nil
+}
+
+// ImageTransport returns transport of a valid image reference, e.g. docker,
+// or local.file for images located on WLM cluster host.
+func ImageTransport(image string) string {
+	if strings.HasPrefix(image, localImagePrefix) {
+		return localImagePrefix
+	}
+	if i := strings.Index(image, "://"); i != -1 {
+		return image[:i]
+	}
+	return ""
+}
+
+// ValidateSingularityOptions checks that app name, host name and
+// bind specs of singularity options are well formed.
+func ValidateSingularityOptions(opts wlmv1alpha1.SingularityOptions) error {
+	if opts.App != "" && !appName.MatchString(opts.App) {
+		return errors.Errorf("invalid app name %q", opts.App)
+	}
+	if opts.HostName != "" && (len(opts.HostName) > 253 || !hostName.MatchString(opts.HostName)) {
+		return errors.Errorf("invalid host name %q", opts.HostName)
+	}
+	return ValidateBinds(opts.Binds)
+}
+
+// ValidateBinds checks singularity bind specs. Each spec has the format
+// src[:dest[:opts]] and may contain several binds separated with comma.
+func ValidateBinds(binds []string) error {
+	for _, spec := range binds {
+		for _, bind := range strings.Split(spec, ",") {
+			if err := validateBind(bind); err != nil {
+				return errors.Wrapf(err, "invalid bind %q", spec)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMounts checks container runtime mount specs. Unlike singularity binds, each spec
+// holds a single mount of format src[:dest[:opts]], where at most maxParts parts are allowed.
+// Options are runtime specific and are not checked.
+func ValidateMounts(mounts []string, maxParts int) error {
+	for _, m := range mounts {
+		if strings.ContainsAny(m, unsafeChars+",") {
+			return errors.Errorf("invalid mount %q: forbidden characters", m)
+		}
+		parts := strings.Split(m, ":")
+		if len(parts) > maxParts {
+			return errors.Errorf("invalid mount %q: too many parts", m)
+		}
+		if parts[0] == "" {
+			return errors.Errorf("invalid mount %q: source path is required", m)
+		}
+		if len(parts) > 1 && !path.IsAbs(parts[1]) {
+			return errors.Errorf("invalid mount %q: destination must be an absolute path", m)
+		}
+	}
+	return nil
+}
+
+func validateBind(bind string) error {
+	if strings.ContainsAny(bind, unsafeChars) {
+		return errors.New("forbidden characters")
+	}
+
+	parts := strings.Split(bind, ":")
+	if len(parts) > 3 {
+		return errors.New("format must be src[:dest[:opts]]")
+	}
+	if parts[0] == "" {
+		return errors.New("source path is required")
+	}
+	if len(parts) > 1 && !path.IsAbs(parts[1]) {
+		return errors.Errorf("destination %q must be an absolute path", parts[1])
+	}
+	if len(parts) > 2 && parts[2] != "ro" && parts[2] != "rw" {
+		return errors.Errorf("unknown option %q, must be ro or rw", parts[2])
+	}
+	return nil
+}
+
+// ClusterPartitions returns known partitions of the cluster job node selector pins it to.
+// All known partitions are returned if job is not pinned to a cluster.
+func ClusterPartitions(nodeSelector map[string]string, known []wlmv1alpha1.WlmPartition) []wlmv1alpha1.WlmPartition {
+	cluster, ok := nodeSelector[ClusterLabel]
+	if !ok {
+		return known
+	}
+	var res []wlmv1alpha1.WlmPartition
+	for _, p := range known {
+		if p.Spec.Cluster == cluster {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// CheckResources checks that a job requesting resources r in one of the passed partitions
+// can run on at least one of known partitions. When no partitions are requested, any known
+// partition may be used. If there are no known partitions, resources are not checked.
+func CheckResources(r Resources, partitions []string, known []wlmv1alpha1.WlmPartition) error {
+	if len(known) == 0 {
+		return nil
+	}
+
+	var candidates []wlmv1alpha1.WlmPartition
+	for _, p := range known {
+		if len(partitions) == 0 || contains(partitions, p.Spec.Partition) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return errors.Errorf("unknown partition %s", strings.Join(partitions, ","))
+	}
+
+	for _, p := range candidates {
+		if partitionFits(r, &p.Status) {
+			return nil
+		}
+	}
+	return errors.Errorf("no partition can satisfy requested resources: %s", describeResources(r))
+}
+
+// partitionFits checks if resources may be allocated in partition. Zero
+// partition limits are considered unknown and are not checked.
+func partitionFits(r Resources, p *wlmv1alpha1.WlmPartitionStatus) bool {
+	if p.MaxNodes > 0 && r.Nodes > p.MaxNodes {
+		return false
+	}
+	if p.MaxTime > 0 && int64(r.WallTime/time.Second) > p.MaxTime {
+		return false
+	}
+	if p.CPUPerNode > 0 && r.CPUPerNode > p.CPUPerNode {
+		return false
+	}
+	if p.MemPerNode > 0 && r.MemPerNode > p.MemPerNode {
+		return false
+	}
+	if len(r.Features) == 0 {
+		return true
+	}
+
+	available := make(map[string]bool, len(p.Features))
+	for _, f := range p.Features {
+		available[f.Name] = true
+	}
+	for _, features := range r.Features {
+		fits := true
+		for _, f := range features {
+			fits = fits && available[f]
+		}
+		if fits {
+			return true
+		}
+	}
+	return false
+}
+
+func describeResources(r Resources) string {
+	var res []string
+	if r.Nodes != 0 {
+		res = append(res, fmt.Sprintf("nodes=%d", r.Nodes))
+	}
+	if r.CPUPerNode != 0 {
+		res = append(res, fmt.Sprintf("cpu-per-node=%d", r.CPUPerNode))
+	}
+	if r.MemPerNode != 0 {
+		res = append(res, fmt.Sprintf("mem-per-node=%dM", r.MemPerNode))
+	}
+	if r.WallTime != 0 {
+		res = append(res, fmt.Sprintf("wall-time=%s", r.WallTime))
+	}
+	for i, features := range r.Features {
+		if i == 0 {
+			res = append(res, "features="+strings.Join(features, "&"))
+			continue
+		}
+		res[len(res)-1] += "|" + strings.Join(features, "&")
+	}
+	return strings.Join(res, ", ")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
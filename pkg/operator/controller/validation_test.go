@@ -0,0 +1,223 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func TestValidateImage(t *testing.T) {
+	tt := []struct {
+		image       string
+		expectError bool
+	}{
+		{image: "library://sylabsed/examples/lolcow:latest"},
+		{image: "library://alpine"},
+		{image: "docker://ubuntu:18.04"},
+		{image: "docker://quay.io:443/org/app@sha256:0123456789abcdef0123456789abcdef"},
+		{image: "shub://GodloveD/lolcow"},
+		{image: "local.file/home/user/lolcow.sif"},
+		{image: "", expectError: true},
+		{image: "lolcow", expectError: true},
+		{image: "ftp://host/lolcow.sif", expectError: true},
+		{image: "library://", expectError: true},
+		{image: "library://Sylabsed/Examples/lolcow", expectError: true},
+		{image: "docker://ubuntu:18.04; rm -rf ~", expectError: true},
+		{image: "local.file", expectError: true},
+		{image: "local.filelolcow.sif", expectError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.image, func(t *testing.T) {
+			err := ValidateImage(tc.image)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateBinds(t *testing.T) {
+	tt := []struct {
+		name        string
+		binds       []string
+		expectError bool
+	}{
+		{name: "empty"},
+		{name: "valid", binds: []string{"/data", "/home/user:/mnt:ro", "/tmp:/tmp:rw,/opt:/opt"}},
+		{name: "relative destination", binds: []string{"/data:data"}, expectError: true},
+		{name: "unknown option", binds: []string{"/data:/data:rx"}, expectError: true},
+		{name: "empty source", binds: []string{":/data"}, expectError: true},
+		{name: "empty bind in list", binds: []string{"/data,"}, expectError: true},
+		{name: "too many parts", binds: []string{"/data:/data:ro:rw"}, expectError: true},
+		{name: "forbidden characters", binds: []string{"/data`id`"}, expectError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBinds(tc.binds)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSingularityOptions(t *testing.T) {
+	tt := []struct {
+		name        string
+		opts        wlmv1alpha1.SingularityOptions
+		expectError bool
+	}{
+		{name: "empty"},
+		{
+			name: "valid",
+			opts: wlmv1alpha1.SingularityOptions{App: "hello-world_1.0", HostName: "node-1.example.com", Binds: []string{"/data"}},
+		},
+		{name: "app with spaces", opts: wlmv1alpha1.SingularityOptions{App: "a b"}, expectError: true},
+		{name: "host name with underscore", opts: wlmv1alpha1.SingularityOptions{HostName: "my_host"}, expectError: true},
+		{name: "host name with dollar", opts: wlmv1alpha1.SingularityOptions{HostName: "$(hostname)"}, expectError: true},
+		{name: "invalid bind", opts: wlmv1alpha1.SingularityOptions{Binds: []string{"/data:data"}}, expectError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSingularityOptions(tc.opts)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMounts(t *testing.T) {
+	tt := []struct {
+		name        string
+		mounts      []string
+		maxParts    int
+		expectError bool
+	}{
+		{name: "empty", maxParts: 3},
+		{name: "valid", mounts: []string{"/data", "/home/user:/mnt:ro", "/tmp:/tmp"}, maxParts: 3},
+		{name: "runtime options", mounts: []string{"/data:/data:Z"}, maxParts: 3},
+		{name: "too many parts", mounts: []string{"/data:/data:ro"}, maxParts: 2, expectError: true},
+		{name: "relative destination", mounts: []string{"/data:data"}, maxParts: 3, expectError: true},
+		{name: "empty source", mounts: []string{":/data"}, maxParts: 3, expectError: true},
+		{name: "several mounts", mounts: []string{"/data,/opt"}, maxParts: 3, expectError: true},
+		{name: "forbidden characters", mounts: []string{"/data$(id)"}, maxParts: 3, expectError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateMounts(tc.mounts, tc.maxParts)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckResources(t *testing.T) {
+	known := []wlmv1alpha1.WlmPartition{
+		{
+			Spec: wlmv1alpha1.WlmPartitionSpec{Partition: "debug"},
+			Status: wlmv1alpha1.WlmPartitionStatus{
+				MaxTime:    3600,
+				MaxNodes:   2,
+				CPUPerNode: 4,
+				MemPerNode: 2048,
+			},
+		},
+		{
+			Spec: wlmv1alpha1.WlmPartitionSpec{Partition: "gpu"},
+			Status: wlmv1alpha1.WlmPartitionStatus{
+				MaxNodes:   8,
+				CPUPerNode: 16,
+				Features:   []wlmv1alpha1.PartitionFeature{{Name: "gpu"}, {Name: "ib"}},
+			},
+		},
+	}
+
+	tt := []struct {
+		name        string
+		resources   Resources
+		partitions  []string
+		known       []wlmv1alpha1.WlmPartition
+		expectError string
+	}{
+		{
+			name:      "no known partitions",
+			resources: Resources{Nodes: 100},
+		},
+		{
+			name:      "fits any",
+			resources: Resources{Nodes: 4, WallTime: 2 * time.Hour},
+			known:     known,
+		},
+		{
+			name:        "too many nodes",
+			resources:   Resources{Nodes: 16, CPUPerNode: 2},
+			known:       known,
+			expectError: "no partition can satisfy requested resources: nodes=16, cpu-per-node=2",
+		},
+		{
+			name:        "does not fit requested partition",
+			resources:   Resources{Nodes: 4},
+			partitions:  []string{"debug"},
+			known:       known,
+			expectError: "no partition can satisfy requested resources: nodes=4",
+		},
+		{
+			name:        "unknown partition",
+			partitions:  []string{"long", "short"},
+			known:       known,
+			expectError: "unknown partition long,short",
+		},
+		{
+			name:      "features",
+			resources: Resources{Features: [][]string{{"intel"}, {"gpu", "ib"}}},
+			known:     known,
+		},
+		{
+			name:        "missing features",
+			resources:   Resources{MemPerNode: 1024, Features: [][]string{{"intel"}, {"amd", "ib"}}},
+			known:       known,
+			expectError: "no partition can satisfy requested resources: mem-per-node=1024M, features=intel|amd&ib",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckResources(tc.resources, tc.partitions, tc.known)
+			if tc.expectError != "" {
+				require.EqualError(t, err, tc.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
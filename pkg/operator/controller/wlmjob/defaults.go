@@ -0,0 +1,88 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+)
+
+// Default sets missing wlm job fields from defaults. Boolean singularity
+// options set in defaults are enforced for singularity and apptainer jobs.
+func Default(wj *wlmv1alpha1.WlmJob, d *wlmv1alpha1.WlmJobDefaultSpec) {
+	if wj.Spec.Runtime == "" {
+		wj.Spec.Runtime = d.Runtime
+	}
+
+	wj.Spec.NodeSelector = controller.DefaultNodeSelector(wj.Spec.NodeSelector, d.NodeSelector)
+	wj.Spec.Results = controller.DefaultResults(wj.Spec.Results, d.Results)
+
+	r := &wj.Spec.Resources
+	if r.Nodes == 0 {
+		r.Nodes = d.Resources.Nodes
+	}
+	if r.CPUPerNode == 0 {
+		r.CPUPerNode = d.Resources.CPUPerNode
+	}
+	if r.MemPerNode == 0 {
+		r.MemPerNode = d.Resources.MemPerNode
+	}
+	if r.WallTime == 0 {
+		r.WallTime = d.Resources.WallTime
+	}
+
+	if d.Options == nil || !usesSingularityOptions(wj.Spec.Runtime) {
+		return
+	}
+	o := &wj.Spec.Options
+	if d.Options.AllowUnsigned != nil {
+		o.AllowUnsigned = *d.Options.AllowUnsigned
+	}
+	if d.Options.CleanEnv != nil {
+		o.CleanEnv = *d.Options.CleanEnv
+	}
+	if d.Options.NoPrivs != nil {
+		o.NoPrivs = *d.Options.NoPrivs
+	}
+	o.Binds = controller.DefaultBinds(o.Binds, d.Options.Binds)
+}
+
+// CheckEnforced checks that wlm job complies with boolean singularity options
+// enforced by defaults. Since defaults are set on creation only, it is used
+// to reject updates and jobs created while defaulting webhook was unavailable.
+func CheckEnforced(wj *wlmv1alpha1.WlmJob, d *wlmv1alpha1.WlmJobDefaultSpec) error {
+	if d.Options == nil || !usesSingularityOptions(wj.Spec.Runtime) {
+		return nil
+	}
+	o := wj.Spec.Options
+	var violated []string
+	if d.Options.AllowUnsigned != nil && o.AllowUnsigned != *d.Options.AllowUnsigned {
+		violated = append(violated, fmt.Sprintf("allowUnsigned=%t", *d.Options.AllowUnsigned))
+	}
+	if d.Options.CleanEnv != nil && o.CleanEnv != *d.Options.CleanEnv {
+		violated = append(violated, fmt.Sprintf("cleanEnv=%t", *d.Options.CleanEnv))
+	}
+	if d.Options.NoPrivs != nil && o.NoPrivs != *d.Options.NoPrivs {
+		violated = append(violated, fmt.Sprintf("noPrivs=%t", *d.Options.NoPrivs))
+	}
+	if len(violated) != 0 {
+		return errors.Errorf("job defaults enforce options %s", strings.Join(violated, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+)
+
+func TestCheckEnforced(t *testing.T) {
+	no := false
+	defaults := &v1alpha1.WlmJobDefaultSpec{
+		Options: &v1alpha1.SingularityOptionsDefaults{AllowUnsigned: &no},
+	}
+
+	tt := []struct {
+		name        string
+		runtime     v1alpha1.ContainerRuntime
+		options     v1alpha1.SingularityOptions
+		expectError bool
+	}{
+		{
+			name:    "complies",
+			runtime: v1alpha1.RuntimeSingularity,
+			options: v1alpha1.SingularityOptions{CleanEnv: true},
+		},
+		{
+			name:        "violates",
+			runtime:     v1alpha1.RuntimeSingularity,
+			options:     v1alpha1.SingularityOptions{AllowUnsigned: true},
+			expectError: true,
+		},
+		{
+			name:        "default runtime violates",
+			options:     v1alpha1.SingularityOptions{AllowUnsigned: true},
+			expectError: true,
+		},
+		{
+			name:    "other runtime",
+			runtime: v1alpha1.RuntimeEnroot,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			wj := &v1alpha1.WlmJob{
+				Spec: v1alpha1.WlmJobSpec{Runtime: tc.runtime, Options: tc.options},
+			}
+			err := CheckEnforced(wj, defaults)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
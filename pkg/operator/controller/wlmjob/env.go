@@ -0,0 +1,166 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// envName matches environment variable names that can be exported from the batch script.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envSecretName returns name of the secret that holds resolved environment of the wlm job.
+func envSecretName(wj *wlmv1alpha1.WlmJob) string {
+	return wj.Name + "-wlm-job-env"
+}
+
+// resolveEnv returns environment variables of the wlm job with values taken from ConfigMaps
+// and Secrets of the job namespace. As kubelet does, Env overrides EnvFrom and EnvFrom keys
+// that are not valid variable names are skipped. EnvFrom keys that are reserved for the job
+// runtime are skipped as well. Missing optional sources are ignored.
+//
+// Resolved values, including Secret values, are exported in plaintext from the batch script,
+// which is kept by slurm and is readable by the submitting user and slurm administrators.
+func resolveEnv(ctx context.Context, reader client.Reader, wj *wlmv1alpha1.WlmJob) (map[string]string, error) {
+	reserved := !usesSingularityOptions(wj.Spec.Runtime)
+	env := make(map[string]string)
+	for _, from := range wj.Spec.EnvFrom {
+		data, err := envSourceData(ctx, reader, wj.Namespace, from)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range data {
+			name := from.Prefix + k
+			if !envName.MatchString(name) || (reserved && slurm.IsReservedEnv(name)) {
+				continue
+			}
+			env[name] = v
+		}
+	}
+
+	for _, e := range wj.Spec.Env {
+		if e.ValueFrom == nil {
+			env[e.Name] = e.Value
+			continue
+		}
+		v, ok, err := envVarValue(ctx, reader, wj.Namespace, e.ValueFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get %s value", e.Name)
+		}
+		if ok {
+			env[e.Name] = v
+		}
+	}
+	return env, nil
+}
+
+func envSourceData(ctx context.Context, reader client.Reader, ns string, from corev1.EnvFromSource) (map[string]string, error) {
+	switch {
+	case from.ConfigMapRef != nil:
+		var cm corev1.ConfigMap
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: from.ConfigMapRef.Name}, &cm)
+		if apierrors.IsNotFound(err) && isOptional(from.ConfigMapRef.Optional) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get config map %s", from.ConfigMapRef.Name)
+		}
+		return cm.Data, nil
+	case from.SecretRef != nil:
+		var secret corev1.Secret
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: from.SecretRef.Name}, &secret)
+		if apierrors.IsNotFound(err) && isOptional(from.SecretRef.Optional) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get secret %s", from.SecretRef.Name)
+		}
+		data := make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		return data, nil
+	}
+	return nil, errors.New("env source must be a config map or a secret")
+}
+
+// envVarValue returns value referenced by source. When referenced object or key is
+// missing and the reference is optional, false is returned.
+func envVarValue(ctx context.Context, reader client.Reader, ns string, source *corev1.EnvVarSource) (string, bool, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		var cm corev1.ConfigMap
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: ref.Name}, &cm)
+		if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, errors.Wrapf(err, "could not get config map %s", ref.Name)
+		}
+		v, ok := cm.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", false, errors.Errorf("config map %s has no key %s", ref.Name, ref.Key)
+		}
+		return v, ok, nil
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		var secret corev1.Secret
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: ref.Name}, &secret)
+		if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, errors.Wrapf(err, "could not get secret %s", ref.Name)
+		}
+		v, ok := secret.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", false, errors.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return string(v), ok, nil
+	}
+	return "", false, errors.New("only config map and secret key references are supported")
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// newEnvSecretForWJ returns a secret holding resolved environment of the wlm job. The secret
+// is referenced by job-companion pod, so values are not exposed in the pod spec.
+func newEnvSecretForWJ(wj *wlmv1alpha1.WlmJob, env map[string]string) *corev1.Secret {
+	data := make(map[string][]byte, len(env))
+	for k, v := range env {
+		data[k] = []byte(v)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      envSecretName(wj),
+			Namespace: wj.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectReader serves config maps and secrets by namespace/name.
+type objectReader map[string]runtime.Object
+
+func (r objectReader) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	stored, ok := r[key.String()]
+	if !ok {
+		return apierrors.NewNotFound(corev1.Resource("object"), key.Name)
+	}
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		stored.(*corev1.ConfigMap).DeepCopyInto(o)
+	case *corev1.Secret:
+		stored.(*corev1.Secret).DeepCopyInto(o)
+	}
+	return nil
+}
+
+func (r objectReader) List(context.Context, *client.ListOptions, runtime.Object) error {
+	return nil
+}
+
+func Test_resolveEnv(t *testing.T) {
+	optional := true
+	reader := objectReader{
+		"default/app": &corev1.ConfigMap{Data: map[string]string{"mode": "fast", "log-level": "debug"}},
+		"default/creds": &corev1.Secret{Data: map[string][]byte{
+			"token": []byte("s3cr3t"),
+			"mode":  []byte("slow"),
+		}},
+	}
+	ref := func(name string) corev1.LocalObjectReference {
+		return corev1.LocalObjectReference{Name: name}
+	}
+
+	tt := []struct {
+		name        string
+		spec        v1alpha1.WlmJobSpec
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name: "env overrides env from",
+			spec: v1alpha1.WlmJobSpec{
+				EnvFrom: []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: ref("app")}},
+					{Prefix: "DB_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: ref("creds")}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "mode", Value: "manual"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: ref("creds"), Key: "token"},
+					}},
+					{Name: "MODE", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: ref("app"), Key: "mode"},
+					}},
+				},
+			},
+			// log-level is not a valid variable name and is skipped
+			expected: map[string]string{
+				"mode":     "manual",
+				"DB_token": "s3cr3t",
+				"DB_mode":  "slow",
+				"TOKEN":    "s3cr3t",
+				"MODE":     "fast",
+			},
+		},
+		{
+			name: "reserved env from",
+			spec: v1alpha1.WlmJobSpec{
+				Runtime: v1alpha1.RuntimeEnroot,
+				EnvFrom: []corev1.EnvFromSource{
+					{Prefix: "SLURM_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: ref("app")}},
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: ref("creds")}},
+				},
+			},
+			expected: map[string]string{
+				"token": "s3cr3t",
+				"mode":  "slow",
+			},
+		},
+		{
+			name: "optional missing",
+			spec: v1alpha1.WlmJobSpec{
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: ref("missing"), Optional: &optional}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "A", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: ref("creds"), Key: "a", Optional: &optional},
+					}},
+					{Name: "B", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: ref("missing"), Key: "b", Optional: &optional},
+					}},
+				},
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "missing config map",
+			spec: v1alpha1.WlmJobSpec{
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: ref("missing")}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing secret key",
+			spec: v1alpha1.WlmJobSpec{
+				Env: []corev1.EnvVar{{Name: "A", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: ref("creds"), Key: "a"},
+				}}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			wj := &v1alpha1.WlmJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "cow", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			env, err := resolveEnv(context.Background(), reader, wj)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, env)
+		})
+	}
+}
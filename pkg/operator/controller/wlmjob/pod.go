@@ -26,17 +26,23 @@ import (
 
 // newPodForWJ returns a job-companion pod for the wlm job.
 func (r *Reconciler) newPodForWJ(wj *wlmv1alpha1.WlmJob) (*corev1.Pod, error) {
-	res := controller.Resources{
-		Nodes:      wj.Spec.Resources.Nodes,
-		MemPerNode: wj.Spec.Resources.MemPerNode,
-		CPUPerNode: wj.Spec.Resources.CPUPerNode,
-		WallTime:   time.Duration(wj.Spec.Resources.WallTime) * time.Second,
-	}
-	affinity, err := controller.AffinityForResources(res)
+	affinity, err := controller.AffinityForResources(resourcesForWj(wj))
 	if err != nil && err != controller.ErrAffinityIsNotRequired {
 		return nil, errors.Wrap(err, "could not get job affinity")
 	}
 
+	container := corev1.Container{
+		Name:  "jt2",
+		Image: "no-image",
+	}
+	if hasEnv(wj) {
+		container.EnvFrom = []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: envSecretName(wj)},
+			},
+		}}
+	}
+
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      wj.Name + "-wlm-job",
@@ -47,12 +53,7 @@ func (r *Reconciler) newPodForWJ(wj *wlmv1alpha1.WlmJob) (*corev1.Pod, error) {
 				RunAsUser:  &r.jcUID,
 				RunAsGroup: &r.jcGID,
 			},
-			Containers: []corev1.Container{
-				{
-					Name:  "jt2",
-					Image: "no-image",
-				},
-			},
+			Containers:    []corev1.Container{container},
 			RestartPolicy: corev1.RestartPolicyNever,
 			Affinity:      affinity,
 			Tolerations:   controller.DefaultTolerations,
@@ -61,6 +62,26 @@ func (r *Reconciler) newPodForWJ(wj *wlmv1alpha1.WlmJob) (*corev1.Pod, error) {
 	}, nil
 }
 
+// hasEnv checks if the wlm job sets container environment.
+func hasEnv(wj *wlmv1alpha1.WlmJob) bool {
+	return len(wj.Spec.Env) != 0 || len(wj.Spec.EnvFrom) != 0
+}
+
+// resourcesForWj returns resources requested by the wlm job. When nodes are not set
+// for an MPI job with limited tasks per node, the minimal number of nodes is requested.
+func resourcesForWj(wj *wlmv1alpha1.WlmJob) controller.Resources {
+	nodes := wj.Spec.Resources.Nodes
+	if mpi := wj.Spec.MPI; nodes == 0 && mpi != nil && mpi.TasksPerNode > 0 {
+		nodes = (mpi.Ntasks + mpi.TasksPerNode - 1) / mpi.TasksPerNode
+	}
+	return controller.Resources{
+		Nodes:      nodes,
+		MemPerNode: wj.Spec.Resources.MemPerNode,
+		CPUPerNode: wj.Spec.Resources.CPUPerNode,
+		WallTime:   time.Duration(wj.Spec.Resources.WallTime) * time.Second,
+	}
+}
+
 func nodeSelectorForWj(wj *wlmv1alpha1.WlmJob) map[string]string {
 	nodeSelector := make(map[string]string)
 	for k, v := range controller.DefaultNodeSelectors {
@@ -0,0 +1,257 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// runtimeTransports lists image transports each container runtime can run.
+// Singularity and apptainer accept any transport known to singularity pull.
+var runtimeTransports = map[wlmv1alpha1.ContainerRuntime][]string{
+	wlmv1alpha1.RuntimeEnroot:       {"docker", "local.file"},
+	wlmv1alpha1.RuntimePodmanHPC:    {"docker"},
+	wlmv1alpha1.RuntimeCharliecloud: {"docker", "local.file"},
+}
+
+// Validate checks that wlm job image, runtime options, mount specs, MPI tasks, command
+// and environment are well formed and requested resources fit into at least one of the known partitions
+// of the cluster job is pinned to, if any.
+func Validate(wj *wlmv1alpha1.WlmJob, known []wlmv1alpha1.WlmPartition) error {
+	var errs []error
+	if err := controller.ValidateImage(wj.Spec.Image); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, validateRuntime(&wj.Spec)...)
+	errs = append(errs, validateMPI(&wj.Spec)...)
+	errs = append(errs, validateEnv(&wj.Spec)...)
+
+	r := wj.Spec.Resources
+	if r.Nodes < 0 || r.CPUPerNode < 0 || r.MemPerNode < 0 || r.WallTime < 0 {
+		errs = append(errs, errors.New("resources must not be negative"))
+	} else {
+		var partitions []string
+		if p, ok := wj.Spec.NodeSelector[controller.PartitionLabel]; ok {
+			partitions = []string{p}
+		}
+		known = controller.ClusterPartitions(wj.Spec.NodeSelector, known)
+		if err := controller.CheckResources(resourcesForWj(wj), partitions, known); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateRuntime checks that job runtime is known, the image can be run with it,
+// runtime options are valid and options of other runtimes are not set.
+func validateRuntime(spec *wlmv1alpha1.WlmJobSpec) []error {
+	var errs []error
+	rt := spec.Runtime
+	switch rt {
+	case "", wlmv1alpha1.RuntimeSingularity, wlmv1alpha1.RuntimeApptainer:
+		if err := controller.ValidateSingularityOptions(spec.Options); err != nil {
+			errs = append(errs, err)
+		}
+	case wlmv1alpha1.RuntimeEnroot:
+		if spec.Enroot == nil {
+			errs = append(errs, errors.New("enroot options are required by enroot runtime"))
+			break
+		}
+		errs = append(errs, validateCommand(spec.Enroot.Command)...)
+		if err := controller.ValidateMounts(spec.Enroot.Mounts, 3); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validatePath("workdir", spec.Enroot.Workdir); err != nil {
+			errs = append(errs, err)
+		}
+	case wlmv1alpha1.RuntimePodmanHPC:
+		if spec.PodmanHPC == nil {
+			break
+		}
+		if err := controller.ValidateMounts(spec.PodmanHPC.Volumes, 3); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validatePath("workdir", spec.PodmanHPC.Workdir); err != nil {
+			errs = append(errs, err)
+		}
+	case wlmv1alpha1.RuntimeCharliecloud:
+		if spec.Charliecloud == nil {
+			errs = append(errs, errors.New("charliecloud options are required by charliecloud runtime"))
+			break
+		}
+		errs = append(errs, validateCommand(spec.Charliecloud.Command)...)
+		if err := controller.ValidateMounts(spec.Charliecloud.Binds, 2); err != nil {
+			errs = append(errs, err)
+		}
+	default:
+		return []error{errors.Errorf("unknown runtime %q", rt)}
+	}
+
+	if transports, ok := runtimeTransports[rt]; ok {
+		transport := controller.ImageTransport(spec.Image)
+		if transport != "" && !contains(transports, transport) {
+			errs = append(errs, errors.Errorf("%s runtime can't run %s images", rt, transport))
+		}
+	}
+
+	var unused []string
+	if (rt == wlmv1alpha1.RuntimeEnroot || rt == wlmv1alpha1.RuntimeCharliecloud) &&
+		(len(spec.Command) != 0 || len(spec.Args) != 0) {
+		unused = append(unused, "command, args")
+	}
+	if !usesSingularityOptions(rt) && !reflect.DeepEqual(spec.Options, wlmv1alpha1.SingularityOptions{}) {
+		unused = append(unused, "options")
+	}
+	if rt != wlmv1alpha1.RuntimeEnroot && spec.Enroot != nil {
+		unused = append(unused, "enroot")
+	}
+	if rt != wlmv1alpha1.RuntimePodmanHPC && spec.PodmanHPC != nil {
+		unused = append(unused, "podmanHPC")
+	}
+	if rt != wlmv1alpha1.RuntimeCharliecloud && spec.Charliecloud != nil {
+		unused = append(unused, "charliecloud")
+	}
+	if len(unused) != 0 {
+		errs = append(errs, errors.Errorf("%s not used by %s runtime", strings.Join(unused, ", "), runtimeName(rt)))
+	}
+	return errs
+}
+
+// validateMPI checks that MPI task layout is consistent with requested nodes.
+func validateMPI(spec *wlmv1alpha1.WlmJobSpec) []error {
+	mpi := spec.MPI
+	if mpi == nil {
+		return nil
+	}
+
+	var errs []error
+	switch mpi.Type {
+	case "", wlmv1alpha1.MPIPMIx, wlmv1alpha1.MPIPMI2, wlmv1alpha1.MPINone:
+	default:
+		errs = append(errs, errors.Errorf("unknown mpi type %q", mpi.Type))
+	}
+	if mpi.Ntasks < 1 {
+		errs = append(errs, errors.New("mpi ntasks must be positive"))
+	}
+	if mpi.TasksPerNode < 0 {
+		errs = append(errs, errors.New("mpi tasksPerNode must not be negative"))
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+
+	nodes := spec.Resources.Nodes
+	if nodes > mpi.Ntasks {
+		errs = append(errs, errors.Errorf("mpi ntasks %d is less than requested nodes %d", mpi.Ntasks, nodes))
+	}
+	if nodes > 0 && mpi.TasksPerNode > 0 && nodes*mpi.TasksPerNode < mpi.Ntasks {
+		errs = append(errs, errors.Errorf("mpi ntasks %d don't fit into %d nodes with %d tasks per node",
+			mpi.Ntasks, nodes, mpi.TasksPerNode))
+	}
+	if cpus := spec.Resources.CPUPerNode; cpus > 0 && mpi.TasksPerNode > 0 && cpus%mpi.TasksPerNode != 0 {
+		errs = append(errs, errors.Errorf("cpuPerNode %d is not divisible by mpi tasksPerNode %d",
+			cpus, mpi.TasksPerNode))
+	}
+	return errs
+}
+
+// validateEnv checks container command, args and environment. Values of environment
+// variables may be taken only from ConfigMaps and Secrets that operator can read.
+func validateEnv(spec *wlmv1alpha1.WlmJobSpec) []error {
+	var errs []error
+	if len(spec.Command) != 0 {
+		errs = append(errs, validateCommand(spec.Command)...)
+	}
+	for _, arg := range spec.Args {
+		if strings.ContainsAny(arg, "\x00\n") {
+			errs = append(errs, errors.Errorf("argument %q contains forbidden characters", arg))
+		}
+	}
+
+	for _, e := range spec.Env {
+		if !envName.MatchString(e.Name) {
+			errs = append(errs, errors.Errorf("invalid environment variable name %q", e.Name))
+		}
+		if !usesSingularityOptions(spec.Runtime) && slurm.IsReservedEnv(e.Name) {
+			errs = append(errs, errors.Errorf("environment variable %s is reserved for runtime %s", e.Name, spec.Runtime))
+		}
+		if strings.ContainsRune(e.Value, 0) {
+			errs = append(errs, errors.Errorf("environment variable %s contains forbidden characters", e.Name))
+		}
+		from := e.ValueFrom
+		if from != nil && (from.ConfigMapKeyRef == nil) == (from.SecretKeyRef == nil) {
+			errs = append(errs, errors.Errorf("environment variable %s must reference either config map or secret key", e.Name))
+		}
+	}
+	for _, from := range spec.EnvFrom {
+		if from.Prefix != "" && !envName.MatchString(from.Prefix) {
+			errs = append(errs, errors.Errorf("invalid environment variable prefix %q", from.Prefix))
+		}
+		if (from.ConfigMapRef == nil) == (from.SecretRef == nil) {
+			errs = append(errs, errors.New("env source must be either config map or secret"))
+		}
+	}
+	return errs
+}
+
+// usesSingularityOptions checks if singularity options are applied with the runtime.
+func usesSingularityOptions(rt wlmv1alpha1.ContainerRuntime) bool {
+	return rt == "" || rt == wlmv1alpha1.RuntimeSingularity || rt == wlmv1alpha1.RuntimeApptainer
+}
+
+func runtimeName(rt wlmv1alpha1.ContainerRuntime) string {
+	if rt == "" {
+		return string(wlmv1alpha1.RuntimeSingularity)
+	}
+	return string(rt)
+}
+
+func validateCommand(command []string) []error {
+	if len(command) == 0 || command[0] == "" {
+		return []error{errors.New("command is required")}
+	}
+	for _, arg := range command {
+		if strings.ContainsAny(arg, "\x00\n") {
+			return []error{errors.Errorf("command argument %q contains forbidden characters", arg)}
+		}
+	}
+	return nil
+}
+
+func validatePath(name, p string) error {
+	if p != "" && !strings.HasPrefix(p, "/") {
+		return errors.Errorf("%s %q must be an absolute path", name, p)
+	}
+	if strings.ContainsAny(p, "\x00\n") {
+		return errors.Errorf("%s %q contains forbidden characters", name, p)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
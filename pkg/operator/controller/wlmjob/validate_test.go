@@ -0,0 +1,277 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wlmjob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		name        string
+		spec        v1alpha1.WlmJobSpec
+		expectError bool
+	}{
+		{
+			name: "default runtime",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "library://sylabsed/examples/lolcow:latest",
+				Options: v1alpha1.SingularityOptions{Binds: []string{"/data:/data:ro"}},
+			},
+		},
+		{
+			name: "apptainer",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimeApptainer,
+				Options: v1alpha1.SingularityOptions{CleanEnv: true},
+			},
+		},
+		{
+			name: "enroot",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://nvcr.io/nvidia/pytorch:23.05-py3",
+				Runtime: v1alpha1.RuntimeEnroot,
+				Enroot: &v1alpha1.EnrootOptions{
+					Command: []string{"python", "-c", "import torch"},
+					Mounts:  []string{"/data:/data:ro"},
+					Workdir: "/workspace",
+				},
+			},
+		},
+		{
+			name: "enroot without command",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimeEnroot,
+				Enroot:  &v1alpha1.EnrootOptions{},
+			},
+			expectError: true,
+		},
+		{
+			name: "enroot without options",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimeEnroot,
+			},
+			expectError: true,
+		},
+		{
+			name: "enroot library image",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "library://sylabsed/examples/lolcow:latest",
+				Runtime: v1alpha1.RuntimeEnroot,
+				Enroot:  &v1alpha1.EnrootOptions{Command: []string{"cowsay"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "podman-hpc",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "docker://ubuntu:18.04",
+				Runtime:   v1alpha1.RuntimePodmanHPC,
+				PodmanHPC: &v1alpha1.PodmanHPCOptions{Volumes: []string{"/scratch:/scratch"}, GPU: true},
+			},
+		},
+		{
+			name: "podman-hpc relative workdir",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "docker://ubuntu:18.04",
+				Runtime:   v1alpha1.RuntimePodmanHPC,
+				PodmanHPC: &v1alpha1.PodmanHPCOptions{Workdir: "work"},
+			},
+			expectError: true,
+		},
+		{
+			name: "podman-hpc with singularity options",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimePodmanHPC,
+				Options: v1alpha1.SingularityOptions{FakeRoot: true},
+			},
+			expectError: true,
+		},
+		{
+			name: "charliecloud",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "local.file/home/user/alpine.sqfs",
+				Runtime: v1alpha1.RuntimeCharliecloud,
+				Charliecloud: &v1alpha1.CharliecloudOptions{
+					Command: []string{"cat", "/etc/os-release"},
+					Binds:   []string{"/data:/mnt/0"},
+				},
+			},
+		},
+		{
+			name: "charliecloud bind options",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://alpine:3.9",
+				Runtime: v1alpha1.RuntimeCharliecloud,
+				Charliecloud: &v1alpha1.CharliecloudOptions{
+					Command: []string{"true"},
+					Binds:   []string{"/data:/mnt/0:ro"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "options of another runtime",
+			spec: v1alpha1.WlmJobSpec{
+				Image:  "docker://ubuntu:18.04",
+				Enroot: &v1alpha1.EnrootOptions{Command: []string{"true"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "mpi",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "library://sylabsed/examples/mpi:latest",
+				MPI:       &v1alpha1.MPIOptions{Ntasks: 8, TasksPerNode: 4, Type: v1alpha1.MPIPMIx},
+				Resources: v1alpha1.WlmResources{Nodes: 2},
+			},
+		},
+		{
+			name: "mpi without tasks",
+			spec: v1alpha1.WlmJobSpec{
+				Image: "library://sylabsed/examples/mpi:latest",
+				MPI:   &v1alpha1.MPIOptions{Type: v1alpha1.MPIPMI2},
+			},
+			expectError: true,
+		},
+		{
+			name: "mpi unknown type",
+			spec: v1alpha1.WlmJobSpec{
+				Image: "library://sylabsed/examples/mpi:latest",
+				MPI:   &v1alpha1.MPIOptions{Ntasks: 2, Type: "openmpi"},
+			},
+			expectError: true,
+		},
+		{
+			name: "mpi tasks don't fit nodes",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "library://sylabsed/examples/mpi:latest",
+				MPI:       &v1alpha1.MPIOptions{Ntasks: 9, TasksPerNode: 4},
+				Resources: v1alpha1.WlmResources{Nodes: 2},
+			},
+			expectError: true,
+		},
+		{
+			name: "mpi cpus not divisible by tasks",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "library://sylabsed/examples/mpi:latest",
+				MPI:       &v1alpha1.MPIOptions{Ntasks: 8, TasksPerNode: 4},
+				Resources: v1alpha1.WlmResources{Nodes: 2, CPUPerNode: 6},
+			},
+			expectError: true,
+		},
+		{
+			name: "mpi fewer tasks than nodes",
+			spec: v1alpha1.WlmJobSpec{
+				Image:     "library://sylabsed/examples/mpi:latest",
+				MPI:       &v1alpha1.MPIOptions{Ntasks: 1},
+				Resources: v1alpha1.WlmResources{Nodes: 2},
+			},
+			expectError: true,
+		},
+		{
+			name: "command and env",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "library://sylabsed/examples/lolcow:latest",
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{"echo $GREETING"},
+				Env: []corev1.EnvVar{
+					{Name: "GREETING", Value: "it's $HOME"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}}},
+				},
+				EnvFrom: []corev1.EnvFromSource{{Prefix: "APP_", ConfigMapRef: &corev1.ConfigMapEnvSource{}}},
+			},
+		},
+		{
+			name: "invalid env name",
+			spec: v1alpha1.WlmJobSpec{
+				Image: "library://sylabsed/examples/lolcow:latest",
+				Env:   []corev1.EnvVar{{Name: "A;reboot", Value: "1"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "env from field",
+			spec: v1alpha1.WlmJobSpec{
+				Image: "library://sylabsed/examples/lolcow:latest",
+				Env: []corev1.EnvVar{
+					{Name: "POD", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid env prefix",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "library://sylabsed/examples/lolcow:latest",
+				EnvFrom: []corev1.EnvFromSource{{Prefix: "APP-", SecretRef: &corev1.SecretEnvSource{}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "enroot reserved env",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimeEnroot,
+				Env:     []corev1.EnvVar{{Name: "LD_PRELOAD", Value: "/tmp/lib.so"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "singularity path env",
+			spec: v1alpha1.WlmJobSpec{
+				Image: "library://sylabsed/examples/lolcow:latest",
+				Env:   []corev1.EnvVar{{Name: "PATH", Value: "/opt/bin"}},
+			},
+		},
+		{
+			name: "enroot job command",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: v1alpha1.RuntimeEnroot,
+				Enroot:  &v1alpha1.EnrootOptions{Command: []string{"env"}},
+				Args:    []string{"-0"},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown runtime",
+			spec: v1alpha1.WlmJobSpec{
+				Image:   "docker://ubuntu:18.04",
+				Runtime: "docker",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&v1alpha1.WlmJob{Spec: tc.spec}, nil)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
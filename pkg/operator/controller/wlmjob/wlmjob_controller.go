@@ -17,6 +17,7 @@ package wlmjob
 import (
 	"context"
 	"os"
+	"reflect"
 
 	"github.com/golang/glog"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -25,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	wlmcontroller "github.com/sylabs/wlm-operator/pkg/operator/controller"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -35,6 +37,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+const (
+	// reasonEnforcedOptions is set when wlm job violates options enforced by job defaults.
+	reasonEnforcedOptions = "EnforcedOptions"
+	// reasonScheduled is set once companion pod is created.
+	reasonScheduled = "Scheduled"
+)
+
 // Reconciler reconciles a WlmJob object.
 type Reconciler struct {
 	client client.Client
@@ -125,6 +134,26 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 			return reconcile.Result{}, nil
 		}
 
+		// defaulting webhook may be bypassed, so enforced options are checked before submit
+		defaults, err := wlmcontroller.NamespaceDefaults(context.Background(), r.client, wj.Namespace)
+		if err != nil {
+			glog.Errorf("Could not get job defaults: %v", err)
+			return reconcile.Result{}, err
+		}
+		if defaults != nil {
+			if err := CheckEnforced(wj, defaults); err != nil {
+				glog.Errorf("Wlm job %q is not allowed: %v", wj.Name, err)
+				return reconcile.Result{}, r.setUnschedulable(wj, reasonEnforcedOptions, err.Error())
+			}
+		}
+
+		if hasEnv(wj) {
+			if err := r.createEnvSecret(wj); err != nil {
+				glog.Errorf("Could not create job environment secret: %v", err)
+				return reconcile.Result{}, err
+			}
+		}
+
 		glog.Infof("Creating new pod %q for wlm job %q", sjPod.Name, wj.Name)
 		err = r.client.Create(context.Background(), sjPod)
 		if err != nil {
@@ -136,7 +165,28 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 
 	glog.Infof("Updating wlm job %q", wj.Name)
 	// Otherwise smth has changed, need to update things
+	if wlmcontroller.RequestCancel(wj, wjCurrentPod) {
+		glog.Infof("Requesting cancellation of wlm job %q", wj.Name)
+		if err := r.client.Update(context.Background(), wjCurrentPod); err != nil {
+			glog.Errorf("Could not update pod: %v", err)
+			return reconcile.Result{}, err
+		}
+	}
 	wj.Status.Status = string(wjCurrentPod.Status.Phase)
+	usage, err := wlmcontroller.UsageFromPod(wjCurrentPod)
+	if err != nil {
+		glog.Errorf("Could not get job usage: %v", err)
+	}
+	if usage != nil {
+		wj.Status.Usage = usage
+	}
+	if wlmcontroller.HasCondition(wj.Status.Conditions, wlmv1alpha1.JobUnschedulable) {
+		wj.Status.Conditions = wlmcontroller.SetCondition(wj.Status.Conditions, wlmv1alpha1.JobCondition{
+			Type:   wlmv1alpha1.JobUnschedulable,
+			Status: corev1.ConditionFalse,
+			Reason: reasonScheduled,
+		})
+	}
 	err = r.client.Status().Update(context.Background(), wj)
 	if err != nil {
 		glog.Errorf("Could not update wlm job: %v", err)
@@ -144,3 +194,50 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	}
 	return reconcile.Result{}, nil
 }
+
+// setUnschedulable sets wlm job unschedulable condition. Job won't be scheduled
+// until its spec is changed, so the request is not requeued.
+func (r *Reconciler) setUnschedulable(wj *wlmv1alpha1.WlmJob, reason, message string) error {
+	conditions := wlmcontroller.SetCondition(wj.Status.Conditions, wlmv1alpha1.JobCondition{
+		Type:    wlmv1alpha1.JobUnschedulable,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	if reflect.DeepEqual(conditions, wj.Status.Conditions) {
+		return nil
+	}
+
+	wj.Status.Conditions = conditions
+	err := r.client.Status().Update(context.Background(), wj)
+	if err != nil {
+		glog.Errorf("Could not update wlm job: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createEnvSecret resolves environment of the wlm job and stores it in a secret owned by the job.
+// Secret left by a failed reconcile is updated, so job gets up to date values.
+func (r *Reconciler) createEnvSecret(wj *wlmv1alpha1.WlmJob) error {
+	env, err := resolveEnv(context.Background(), r.client, wj)
+	if err != nil {
+		return err
+	}
+	secret := newEnvSecretForWJ(wj, env)
+	if err := controllerutil.SetControllerReference(wj, secret, r.scheme); err != nil {
+		return err
+	}
+
+	err = r.client.Create(context.Background(), secret)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	current := &corev1.Secret{}
+	key := types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}
+	if err := r.client.Get(context.Background(), key, current); err != nil {
+		return err
+	}
+	current.Data = secret.Data
+	return r.client.Update(context.Background(), current)
+}
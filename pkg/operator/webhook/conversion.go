@@ -0,0 +1,196 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	wlmv1beta1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1beta1"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// Converter converts SlurmJob and WlmJob objects between API versions
+// in ConversionReview requests sent by API server.
+type Converter struct{}
+
+// ServeHTTP handles ConversionReview request. Conversion errors are reported
+// in review response, as expected by API server, rather than with http status.
+func (c *Converter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review apiextv1beta1.ConversionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, errors.Wrap(err, "could not decode conversion review").Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "conversion request is missing", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convertReview(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&review); err != nil {
+		glog.Errorf("Could not write conversion response: %v", err)
+	}
+}
+
+func convertReview(req *apiextv1beta1.ConversionRequest) *apiextv1beta1.ConversionResponse {
+	resp := &apiextv1beta1.ConversionResponse{
+		UID:    req.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range req.Objects {
+		converted, err := convert(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			glog.Errorf("Could not convert object to %s: %v", req.DesiredAPIVersion, err)
+			return &apiextv1beta1.ConversionResponse{
+				UID: req.UID,
+				Result: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				},
+			}
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	return resp
+}
+
+// convert converts serialized object to the desired API version.
+func convert(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, errors.Wrap(err, "could not decode object")
+	}
+	if meta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	desired, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid desired api version")
+	}
+
+	obj, err := newJob(meta.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", meta.Kind)
+	}
+	hub, err := toHub(obj)
+	if err != nil {
+		return nil, err
+	}
+	out, err := fromHub(hub, desired.WithKind(meta.Kind))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// decodeJob decodes job passed in admission request and converts it to v1alpha1.
+func decodeJob(req atypes.Request) (runtime.Object, error) {
+	return decodeRawJob(req.AdmissionRequest.Kind, req.AdmissionRequest.Object.Raw)
+}
+
+// decodeOldJob decodes existing job passed in UPDATE admission request and converts it to v1alpha1.
+func decodeOldJob(req atypes.Request) (runtime.Object, error) {
+	return decodeRawJob(req.AdmissionRequest.Kind, req.AdmissionRequest.OldObject.Raw)
+}
+
+func decodeRawJob(kind metav1.GroupVersionKind, raw []byte) (runtime.Object, error) {
+	obj, err := newJob(schema.GroupVersionKind{Group: kind.Group, Version: kind.Version, Kind: kind.Kind})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", kind.Kind)
+	}
+	return toHub(obj)
+}
+
+// newJob returns an empty job object of the given kind.
+func newJob(gvk schema.GroupVersionKind) (runtime.Object, error) {
+	switch gvk {
+	case wlmv1alpha1.SchemeGroupVersion.WithKind("SlurmJob"):
+		return &wlmv1alpha1.SlurmJob{}, nil
+	case wlmv1alpha1.SchemeGroupVersion.WithKind("WlmJob"):
+		return &wlmv1alpha1.WlmJob{}, nil
+	case wlmv1beta1.SchemeGroupVersion.WithKind("SlurmJob"):
+		return &wlmv1beta1.SlurmJob{}, nil
+	case wlmv1beta1.SchemeGroupVersion.WithKind("WlmJob"):
+		return &wlmv1beta1.WlmJob{}, nil
+	}
+	return nil, errors.Errorf("unsupported kind %s", gvk)
+}
+
+// toHub converts job of any supported version to v1alpha1 which all
+// versions are converted through.
+func toHub(obj runtime.Object) (runtime.Object, error) {
+	switch job := obj.(type) {
+	case *wlmv1alpha1.SlurmJob, *wlmv1alpha1.WlmJob:
+		return obj, nil
+	case *wlmv1beta1.SlurmJob:
+		hub := &wlmv1alpha1.SlurmJob{}
+		job.ConvertTo(hub)
+		return hub, nil
+	case *wlmv1beta1.WlmJob:
+		hub := &wlmv1alpha1.WlmJob{}
+		job.ConvertTo(hub)
+		return hub, nil
+	}
+	return nil, errors.Errorf("unsupported object %T", obj)
+}
+
+// fromHub converts v1alpha1 job to the given version and kind.
+func fromHub(hub runtime.Object, gvk schema.GroupVersionKind) (runtime.Object, error) {
+	obj, err := newJob(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	switch out := obj.(type) {
+	case *wlmv1alpha1.SlurmJob, *wlmv1alpha1.WlmJob:
+		if reflect.TypeOf(out) != reflect.TypeOf(hub) {
+			return nil, errors.Errorf("could not convert %T to %s", hub, gvk)
+		}
+		obj = hub.DeepCopyObject()
+	case *wlmv1beta1.SlurmJob:
+		in, ok := hub.(*wlmv1alpha1.SlurmJob)
+		if !ok {
+			return nil, errors.Errorf("could not convert %T to %s", hub, gvk)
+		}
+		out.ConvertFrom(in)
+	case *wlmv1beta1.WlmJob:
+		in, ok := hub.(*wlmv1alpha1.WlmJob)
+		if !ok {
+			return nil, errors.Errorf("could not convert %T to %s", hub, gvk)
+		}
+		out.ConvertFrom(in)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return obj, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestConverter(t *testing.T) {
+	tt := []struct {
+		name          string
+		objects       []string
+		desired       string
+		expectStatus  string
+		expectObjects []string
+	}{
+		{
+			name: "v1beta1 to v1alpha1",
+			objects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1beta1","kind":"WlmJob","metadata":{"name":"cow"},` +
+					`"spec":{"image":"library://sylabsed/examples/lolcow","resources":{"nodes":1,"memPerNode":"2Gi","wallTime":"1h"}},` +
+					`"status":{"phase":"Running"}}`,
+			},
+			desired:      "wlm.sylabs.io/v1alpha1",
+			expectStatus: metav1.StatusSuccess,
+			expectObjects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1alpha1","kind":"WlmJob","metadata":{"name":"cow","creationTimestamp":null},` +
+					`"spec":{"image":"library://sylabsed/examples/lolcow","resources":{"nodes":1,"memPerNode":2048,"wallTime":3600},"options":{}},` +
+					`"status":{"status":"Running"}}`,
+			},
+		},
+		{
+			name: "v1alpha1 to v1beta1",
+			objects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1alpha1","kind":"SlurmJob","metadata":{"name":"cow"},` +
+					`"spec":{"batch":"#!/bin/sh"},"status":{"status":"Failed","usage":{"cpuTime":30}}}`,
+			},
+			desired:      "wlm.sylabs.io/v1beta1",
+			expectStatus: metav1.StatusSuccess,
+			expectObjects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1beta1","kind":"SlurmJob","metadata":{"name":"cow","creationTimestamp":null},` +
+					`"spec":{"batch":"#!/bin/sh"},"status":{"phase":"Failed","usage":{"cpuTime":"30s"}}}`,
+			},
+		},
+		{
+			name: "same version",
+			objects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1beta1","kind":"SlurmJob","metadata":{"name":"cow"}}`,
+			},
+			desired:      "wlm.sylabs.io/v1beta1",
+			expectStatus: metav1.StatusSuccess,
+			expectObjects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1beta1","kind":"SlurmJob","metadata":{"name":"cow"}}`,
+			},
+		},
+		{
+			name: "unsupported kind",
+			objects: []string{
+				`{"apiVersion":"wlm.sylabs.io/v1alpha1","kind":"WlmPartition","metadata":{"name":"debug"}}`,
+			},
+			desired:      "wlm.sylabs.io/v1beta1",
+			expectStatus: metav1.StatusFailure,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			review := apiextv1beta1.ConversionReview{
+				Request: &apiextv1beta1.ConversionRequest{
+					UID:               "42",
+					DesiredAPIVersion: tc.desired,
+				},
+			}
+			for _, obj := range tc.objects {
+				review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: []byte(obj)})
+			}
+			body, err := json.Marshal(review)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			(&Converter{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader(body)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var actual apiextv1beta1.ConversionReview
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+			require.NotNil(t, actual.Response)
+			require.EqualValues(t, "42", actual.Response.UID)
+			require.Equal(t, tc.expectStatus, actual.Response.Result.Status)
+			require.Len(t, actual.Response.ConvertedObjects, len(tc.expectObjects))
+			for i, obj := range tc.expectObjects {
+				require.JSONEq(t, obj, string(actual.Response.ConvertedObjects[i].Raw))
+			}
+		})
+	}
+}
+
+func TestConverter_BadRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&Converter{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader([]byte(`{}`))))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
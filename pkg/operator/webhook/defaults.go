@@ -0,0 +1,83 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang/glog"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller/slurmjob"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller/wlmjob"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// Defaulter sets missing SlurmJob and WlmJob fields from WlmJobDefault
+// resources that select job namespace.
+type Defaulter struct {
+	reader client.Reader
+}
+
+// Handle returns patch that sets defaults for SlurmJob or WlmJob of any served version passed
+// in admission request. Objects of other kinds are left intact.
+func (d *Defaulter) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	switch req.AdmissionRequest.Kind.Kind {
+	case "SlurmJob", "WlmJob":
+	default:
+		return admission.ValidationResponse(true, "")
+	}
+	obj, err := decodeJob(req)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	defaults, err := controller.NamespaceDefaults(ctx, d.reader, req.AdmissionRequest.Namespace)
+	if err != nil {
+		// do not block jobs when defaults cannot be read
+		glog.Errorf("Could not get job defaults: %v", err)
+		return admission.ValidationResponse(true, "")
+	}
+	if defaults == nil {
+		return admission.ValidationResponse(true, "")
+	}
+
+	defaulted := obj.DeepCopyObject()
+	switch job := defaulted.(type) {
+	case *wlmv1alpha1.SlurmJob:
+		if err := slurmjob.Default(job, defaults); err != nil {
+			return admission.ErrorResponse(http.StatusForbidden, err)
+		}
+	case *wlmv1alpha1.WlmJob:
+		wlmjob.Default(job, defaults)
+	}
+
+	// defaults are set on v1alpha1 job, so both original and defaulted jobs
+	// are converted back to requested version to get the patch
+	kind := req.AdmissionRequest.Kind
+	gvk := schema.GroupVersionKind{Group: kind.Group, Version: kind.Version, Kind: kind.Kind}
+	original, err := fromHub(obj, gvk)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if defaulted, err = fromHub(defaulted, gvk); err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponse(original, defaulted)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook implements admission webhooks that set defaults for slurm
+// and wlm jobs and reject jobs that cannot be run before they are stored, as well
+// as conversion webhook that converts jobs between API versions.
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+)
+
+const (
+	// ValidatePath is a path validating webhook is served on.
+	ValidatePath = "/validate"
+	// MutatePath is a path mutating webhook is served on.
+	MutatePath = "/mutate"
+	// ConvertPath is a path conversion webhook is served on.
+	ConvertPath = "/convert"
+
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+)
+
+// AddToManager adds https server that serves admission and conversion webhooks to the given Manager.
+// Server certificate and key are read from tls.crt and tls.key files in certDir.
+func AddToManager(mgr manager.Manager, addr, certDir string) error {
+	certPath := filepath.Join(certDir, certFile)
+	keyPath := filepath.Join(certDir, keyFile)
+	for _, f := range []string{certPath, keyPath} {
+		if _, err := os.Stat(f); err != nil {
+			return errors.Wrap(err, "could not find webhook certificate")
+		}
+	}
+
+	// partitions and job defaults are not restricted to operator
+	// namespace, so an uncached client is used to read them
+	reader, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not create client")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(ValidatePath, &admission.Webhook{
+		Name:     "validate.wlm.sylabs.io",
+		Type:     types.WebhookTypeValidating,
+		Path:     ValidatePath,
+		Handlers: []admission.Handler{&Validator{reader: reader}},
+	})
+	mux.Handle(MutatePath, &admission.Webhook{
+		Name:     "mutate.wlm.sylabs.io",
+		Type:     types.WebhookTypeMutating,
+		Path:     MutatePath,
+		Handlers: []admission.Handler{&Defaulter{reader: reader}},
+	})
+	mux.Handle(ConvertPath, &Converter{})
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		go func() {
+			<-stop
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				glog.Errorf("Could not shutdown webhook server: %v", err)
+			}
+		}()
+
+		glog.Infof("Serving webhooks on %s", addr)
+		err := srv.ListenAndServeTLS(certPath, keyPath)
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	}))
+}
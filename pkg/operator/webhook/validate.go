@@ -0,0 +1,142 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/golang/glog"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller/slurmjob"
+	"github.com/sylabs/wlm-operator/pkg/operator/controller/wlmjob"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// Validator validates SlurmJob and WlmJob objects on admission.
+type Validator struct {
+	reader client.Reader
+}
+
+// Handle validates SlurmJob or WlmJob of any served version passed in admission request. Objects
+// of other kinds are allowed so that webhook configuration mistakes do not
+// block unrelated resources. Resources are checked against partitions on creation
+// and on updates that change them only, so that jobs can be updated, e.g. labelled,
+// after partition limits change. Options enforced by job defaults are checked the same way,
+// since defaults are set on creation only.
+func (v *Validator) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	switch req.AdmissionRequest.Kind.Kind {
+	case "SlurmJob", "WlmJob":
+	default:
+		return admission.ValidationResponse(true, "")
+	}
+	obj, err := decodeJob(req)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	checkResources, checkOptions := true, true
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update {
+		old, err := decodeOldJob(req)
+		if err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		checkResources = resourcesChanged(old, obj)
+		checkOptions = optionsChanged(old, obj)
+	}
+
+	// resources are checked against an empty list of
+	// partitions, which is always satisfied, when not needed
+	var known []wlmv1alpha1.WlmPartition
+	if checkResources {
+		known = v.partitions(ctx)
+	}
+
+	switch job := obj.(type) {
+	case *wlmv1alpha1.SlurmJob:
+		err = slurmjob.Validate(job, known)
+	case *wlmv1alpha1.WlmJob:
+		err = wlmjob.Validate(job, known)
+		if err == nil && checkOptions {
+			err = v.checkEnforced(ctx, job)
+		}
+	}
+	if err != nil {
+		glog.Infof("Rejecting %s %s/%s: %v", req.AdmissionRequest.Kind.Kind,
+			req.AdmissionRequest.Namespace, req.AdmissionRequest.Name, err)
+		return admission.ErrorResponse(http.StatusForbidden, err)
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+// partitions returns known wlm partitions. Jobs are not blocked when partitions are not
+// available, so on error the error is logged and no partitions are returned.
+func (v *Validator) partitions(ctx context.Context) []wlmv1alpha1.WlmPartition {
+	var partitions wlmv1alpha1.WlmPartitionList
+	if err := v.reader.List(ctx, &client.ListOptions{}, &partitions); err != nil {
+		glog.Errorf("Could not list wlm partitions: %v", err)
+		return nil
+	}
+	return partitions.Items
+}
+
+// checkEnforced checks wlm job against options enforced by job defaults. Jobs are
+// not blocked when defaults are not available, so on error the error is logged.
+func (v *Validator) checkEnforced(ctx context.Context, wj *wlmv1alpha1.WlmJob) error {
+	defaults, err := controller.NamespaceDefaults(ctx, v.reader, wj.Namespace)
+	if err != nil {
+		glog.Errorf("Could not get job defaults: %v", err)
+		return nil
+	}
+	if defaults == nil {
+		return nil
+	}
+	return wlmjob.CheckEnforced(wj, defaults)
+}
+
+// resourcesChanged checks if job update changes resources the job requests
+// or partitions it may run in, so that resources should be checked again.
+func resourcesChanged(old, updated runtime.Object) bool {
+	switch job := updated.(type) {
+	case *wlmv1alpha1.SlurmJob:
+		o, ok := old.(*wlmv1alpha1.SlurmJob)
+		return !ok || o.Spec.Batch != job.Spec.Batch ||
+			!reflect.DeepEqual(o.Spec.NodeSelector, job.Spec.NodeSelector)
+	case *wlmv1alpha1.WlmJob:
+		o, ok := old.(*wlmv1alpha1.WlmJob)
+		return !ok || !reflect.DeepEqual(o.Spec.Resources, job.Spec.Resources) ||
+			!reflect.DeepEqual(o.Spec.MPI, job.Spec.MPI) ||
+			!reflect.DeepEqual(o.Spec.NodeSelector, job.Spec.NodeSelector)
+	}
+	return true
+}
+
+// optionsChanged checks if wlm job update changes runtime or its options,
+// so that options enforced by job defaults should be checked again.
+func optionsChanged(old, updated runtime.Object) bool {
+	job, ok := updated.(*wlmv1alpha1.WlmJob)
+	if !ok {
+		return false
+	}
+	o, ok := old.(*wlmv1alpha1.WlmJob)
+	return !ok || o.Spec.Runtime != job.Spec.Runtime ||
+		!reflect.DeepEqual(o.Spec.Options, job.Spec.Options)
+}
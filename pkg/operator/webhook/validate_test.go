@@ -0,0 +1,163 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wlmv1alpha1 "github.com/sylabs/wlm-operator/pkg/operator/apis/wlm/v1alpha1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// jobReader lists the given wlm partitions and job defaults.
+// Namespaces are returned without labels.
+type jobReader struct {
+	partitions []wlmv1alpha1.WlmPartition
+	defaults   []wlmv1alpha1.WlmJobDefault
+}
+
+func (r *jobReader) Get(context.Context, client.ObjectKey, runtime.Object) error {
+	return nil
+}
+
+func (r *jobReader) List(_ context.Context, _ *client.ListOptions, obj runtime.Object) error {
+	switch list := obj.(type) {
+	case *wlmv1alpha1.WlmPartitionList:
+		list.Items = append([]wlmv1alpha1.WlmPartition(nil), r.partitions...)
+	case *wlmv1alpha1.WlmJobDefaultList:
+		list.Items = append([]wlmv1alpha1.WlmJobDefault(nil), r.defaults...)
+	}
+	return nil
+}
+
+func TestValidator_Handle(t *testing.T) {
+	partition := func(cluster string, maxNodes int64) wlmv1alpha1.WlmPartition {
+		return wlmv1alpha1.WlmPartition{
+			ObjectMeta: metav1.ObjectMeta{Name: "slurm-" + cluster + "-debug"},
+			Spec:       wlmv1alpha1.WlmPartitionSpec{Cluster: cluster, Partition: "debug"},
+			Status:     wlmv1alpha1.WlmPartitionStatus{MaxNodes: maxNodes},
+		}
+	}
+	no := false
+	v := &Validator{reader: &jobReader{
+		partitions: []wlmv1alpha1.WlmPartition{partition("small", 2), partition("big", 8)},
+		defaults: []wlmv1alpha1.WlmJobDefault{{
+			ObjectMeta: metav1.ObjectMeta{Name: "signed"},
+			Spec: wlmv1alpha1.WlmJobDefaultSpec{
+				Options: &wlmv1alpha1.SingularityOptionsDefaults{AllowUnsigned: &no},
+			},
+		}},
+	}}
+
+	job := func(nodes int64, cluster string, labels map[string]string, unsigned bool) []byte {
+		wj := &wlmv1alpha1.WlmJob{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "wlm.sylabs.io/v1alpha1", Kind: "WlmJob"},
+			ObjectMeta: metav1.ObjectMeta{Name: "cow", Labels: labels},
+			Spec: wlmv1alpha1.WlmJobSpec{
+				Image:     "library://sylabsed/examples/lolcow",
+				Resources: wlmv1alpha1.WlmResources{Nodes: nodes},
+				Options:   wlmv1alpha1.SingularityOptions{AllowUnsigned: unsigned},
+			},
+		}
+		if cluster != "" {
+			wj.Spec.NodeSelector = map[string]string{"wlm.sylabs.io/cluster": cluster}
+		}
+		raw, err := json.Marshal(wj)
+		require.NoError(t, err)
+		return raw
+	}
+
+	tt := []struct {
+		name      string
+		operation admissionv1beta1.Operation
+		object    []byte
+		oldObject []byte
+		allowed   bool
+	}{
+		{
+			name:      "fits any cluster",
+			operation: admissionv1beta1.Create,
+			object:    job(4, "", nil, false),
+			allowed:   true,
+		},
+		{
+			name:      "fits selected cluster",
+			operation: admissionv1beta1.Create,
+			object:    job(4, "big", nil, false),
+			allowed:   true,
+		},
+		{
+			name:      "does not fit selected cluster",
+			operation: admissionv1beta1.Create,
+			object:    job(4, "small", nil, false),
+			allowed:   false,
+		},
+		{
+			name:      "update without resources change",
+			operation: admissionv1beta1.Update,
+			object:    job(4, "small", map[string]string{"team": "hpc"}, false),
+			oldObject: job(4, "small", nil, false),
+			allowed:   true,
+		},
+		{
+			name:      "update with resources change",
+			operation: admissionv1beta1.Update,
+			object:    job(3, "small", nil, false),
+			oldObject: job(1, "small", nil, false),
+			allowed:   false,
+		},
+		{
+			name:      "violates enforced options",
+			operation: admissionv1beta1.Create,
+			object:    job(1, "", nil, true),
+			allowed:   false,
+		},
+		{
+			name:      "update with enforced options change",
+			operation: admissionv1beta1.Update,
+			object:    job(1, "", nil, true),
+			oldObject: job(1, "", nil, false),
+			allowed:   false,
+		},
+		{
+			name:      "update without options change",
+			operation: admissionv1beta1.Update,
+			object:    job(1, "", map[string]string{"team": "hpc"}, true),
+			oldObject: job(1, "", nil, true),
+			allowed:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := v.Handle(context.Background(), atypes.Request{
+				AdmissionRequest: &admissionv1beta1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: "wlm.sylabs.io", Version: "v1alpha1", Kind: "WlmJob"},
+					Operation: tc.operation,
+					Object:    runtime.RawExtension{Raw: tc.object},
+					OldObject: runtime.RawExtension{Raw: tc.oldObject},
+				},
+			})
+			require.Equal(t, tc.allowed, resp.Response.Allowed, "%v", resp.Response.Result)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurm
+
+import "strings"
+
+var (
+	// reservedEnv lists variables that affect how host runs job steps.
+	reservedEnv = map[string]bool{
+		"PATH":            true,
+		"LD_LIBRARY_PATH": true,
+		"LD_PRELOAD":      true,
+		"HOME":            true,
+		"SHELL":           true,
+		"IFS":             true,
+		"TMPDIR":          true,
+	}
+	// reservedEnvPrefixes lists prefixes of variables that configure slurm
+	// commands and MPI plugins.
+	reservedEnvPrefixes = []string{"SLURM_", "SBATCH_", "SRUN_", "SALLOC_", "PMI_", "PMIX_"}
+)
+
+// IsReservedEnv checks if environment variable set in batch script would change how
+// slurm and the host run the job, e.g. PATH or SLURM_* variables. Such variables cannot
+// be passed to container runtimes that take container environment from the batch script.
+func IsReservedEnv(name string) bool {
+	if reservedEnv[name] {
+		return true
+	}
+	for _, prefix := range reservedEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
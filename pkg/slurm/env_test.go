@@ -0,0 +1,41 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReservedEnv(t *testing.T) {
+	tt := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "PATH", expected: true},
+		{name: "LD_PRELOAD", expected: true},
+		{name: "SLURM_CPUS_PER_TASK", expected: true},
+		{name: "PMIX_MCA_psec", expected: true},
+		{name: "GREETING"},
+		{name: "MY_PATH"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsReservedEnv(tc.name))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurm
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultOutputPattern is used by sbatch when job output is not set.
+	defaultOutputPattern = "slurm-%j.out"
+	// defaultArrayOutputPattern is used by sbatch when job array output is not set.
+	defaultArrayOutputPattern = "slurm-%A_%a.out"
+)
+
+// OutputPaths returns absolute paths of job standard output and error files.
+// Filename patterns, e.g. %j or %x, are expanded and relative paths are resolved
+// against job working directory. When output is not known, e.g. job info comes from
+// accounting, sbatch default slurm-%j.out is assumed. When error is not set it goes
+// to the output file as sbatch does.
+func (ji *JobInfo) OutputPaths() (stdout, stderr string) {
+	outPattern := ji.StdOut
+	if outPattern == "" {
+		outPattern = defaultOutputPattern
+		if ji.ArrayTaskID != "" {
+			outPattern = defaultArrayOutputPattern
+		}
+	}
+	errPattern := ji.StdErr
+	if errPattern == "" {
+		errPattern = outPattern
+	}
+
+	return ji.outputPath(outPattern), ji.outputPath(errPattern)
+}
+
+func (ji *JobInfo) outputPath(pattern string) string {
+	p := ExpandFilenamePattern(pattern, ji)
+	if !path.IsAbs(p) && ji.WorkDir != "" {
+		p = path.Join(ji.WorkDir, p)
+	}
+	return p
+}
+
+// ExpandFilenamePattern replaces sbatch filename pattern symbols with job values:
+//
+//	%% - the character "%"
+//	%A - job array's master job allocation number
+//	%a - job array ID (index) number
+//	%J - job allocation number, for batch script it is the same as %j
+//	%j - job allocation number
+//	%N - short hostname of the batch host
+//	%n, %t - node and task identifier, 0 for batch script
+//	%s - step ID, "batch" for batch script
+//	%u - user name
+//	%x - job name
+//
+// A number between "%" and the symbol pads numeric values with zeroes, e.g. %3a.
+// A backslash anywhere in the pattern disables expansion, as in sbatch.
+// Unknown symbols are left intact.
+func ExpandFilenamePattern(pattern string, ji *JobInfo) string {
+	if strings.Contains(pattern, `\`) {
+		return strings.Replace(pattern, `\`, "", -1)
+	}
+
+	arrayJobID := ji.ArrayJobID
+	if arrayJobID == "" {
+		arrayJobID = ji.ID
+	}
+	values := map[byte]string{
+		'A': arrayJobID,
+		'a': ji.ArrayTaskID,
+		'J': ji.ID,
+		'j': ji.ID,
+		'N': shortHostname(ji.BatchHost),
+		'n': "0",
+		't': "0",
+		's': "batch",
+		'u': userName(ji.UserID),
+		'x': ji.Name,
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			b.WriteByte(pattern[i])
+			continue
+		}
+
+		j := i + 1
+		for j < len(pattern) && pattern[j] >= '0' && pattern[j] <= '9' {
+			j++
+		}
+		if j == len(pattern) {
+			b.WriteString(pattern[i:])
+			break
+		}
+		if pattern[j] == '%' && j == i+1 {
+			b.WriteByte('%')
+			i = j
+			continue
+		}
+
+		v, ok := values[pattern[j]]
+		if !ok {
+			b.WriteString(pattern[i : j+1])
+			i = j
+			continue
+		}
+		if width, err := strconv.Atoi(pattern[i+1 : j]); err == nil {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				v = fmt.Sprintf("%0*d", width, n)
+			}
+		}
+		b.WriteString(v)
+		i = j
+	}
+	return b.String()
+}
+
+// userName returns user name from slurm user id, e.g. vagrant for vagrant(1000).
+func userName(userID string) string {
+	if i := strings.IndexByte(userID, '('); i != -1 {
+		return userID[:i]
+	}
+	return userID
+}
+
+func shortHostname(host string) string {
+	if i := strings.IndexByte(host, '.'); i != -1 {
+		return host[:i]
+	}
+	return host
+}
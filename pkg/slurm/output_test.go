@@ -0,0 +1,115 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slurm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandFilenamePattern(t *testing.T) {
+	job := &JobInfo{
+		ID:        "53",
+		UserID:    "vagrant(1000)",
+		Name:      "cow",
+		BatchHost: "node1.cluster.local",
+	}
+	task := &JobInfo{
+		ID:          "196",
+		ArrayJobID:  "192",
+		ArrayTaskID: "4",
+		UserID:      "vagrant(1000)",
+		Name:        "sbatch",
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		job     *JobInfo
+		want    string
+	}{
+		{name: "no pattern", pattern: "/home/vagrant/cow.out", job: job, want: "/home/vagrant/cow.out"},
+		{name: "job id", pattern: "slurm-%j.out", job: job, want: "slurm-53.out"},
+		{name: "name and user", pattern: "/home/%u/%x-%J.log", job: job, want: "/home/vagrant/cow-53.log"},
+		{name: "host", pattern: "%N-%n-%t-%s.out", job: job, want: "node1-0-0-batch.out"},
+		{name: "percent", pattern: "100%%-%j", job: job, want: "100%-53"},
+		{name: "padding", pattern: "%5j.out", job: job, want: "00053.out"},
+		{name: "array", pattern: "slurm-%A_%3a.out", job: task, want: "slurm-192_004.out"},
+		{name: "not array", pattern: "slurm-%A.out", job: job, want: "slurm-53.out"},
+		{name: "unknown symbol", pattern: "%q-%j", job: job, want: "%q-53"},
+		{name: "trailing percent", pattern: "%j-%", job: job, want: "53-%"},
+		{name: "escaped", pattern: `\%j.out`, job: job, want: "%j.out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExpandFilenamePattern(tt.pattern, tt.job))
+		})
+	}
+}
+
+func TestJobInfo_OutputPaths(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        *JobInfo
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name: "expanded",
+			job: &JobInfo{
+				ID:      "53",
+				WorkDir: "/home/vagrant",
+				StdOut:  "/home/vagrant/slurm-53.out",
+				StdErr:  "/home/vagrant/slurm-53.out",
+			},
+			wantStdout: "/home/vagrant/slurm-53.out",
+			wantStderr: "/home/vagrant/slurm-53.out",
+		},
+		{
+			name: "relative patterns",
+			job: &JobInfo{
+				ID:      "53",
+				Name:    "cow",
+				WorkDir: "/home/vagrant",
+				StdOut:  "%x.out",
+				StdErr:  "logs/%x-%j.err",
+			},
+			wantStdout: "/home/vagrant/cow.out",
+			wantStderr: "/home/vagrant/logs/cow-53.err",
+		},
+		{
+			name:       "from accounting",
+			job:        &JobInfo{ID: "53", WorkDir: "/home/vagrant"},
+			wantStdout: "/home/vagrant/slurm-53.out",
+			wantStderr: "/home/vagrant/slurm-53.out",
+		},
+		{
+			name:       "array from accounting",
+			job:        &JobInfo{ID: "196", ArrayJobID: "192", ArrayTaskID: "4", WorkDir: "/home/vagrant"},
+			wantStdout: "/home/vagrant/slurm-192_4.out",
+			wantStderr: "/home/vagrant/slurm-192_4.out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := tt.job.OutputPaths()
+			require.Equal(t, tt.wantStdout, stdout)
+			require.Equal(t, tt.wantStderr, stderr)
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package slurm
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +32,9 @@ const (
 	maxCPUsPerNode = "MaxCPUsPerNode"
 	totalCPUs      = "TotalCPUs"
 	maxMemPerNode  = "MaxMemPerNode"
+	partitionState = "State"
+
+	slurmTimeLayout = "2006-01-02T15:04:05"
 )
 
 // ParseDuration parses slurm duration string. Possible formats are:
@@ -189,6 +193,42 @@ func parseResources(partitionInfo string) (*Resources, error) {
 	return &resources, nil
 }
 
+// parsePartitionState extracts partition state from scontrol show partition response.
+func parsePartitionState(partitionInfo string) (string, error) {
+	for _, f := range strings.Fields(partitionInfo) {
+		s := strings.SplitN(f, "=", 2)
+		if len(s) == 2 && s[0] == partitionState {
+			return s[1], nil
+		}
+	}
+	return "", errors.New("partition state is missing")
+}
+
+// parsePartitionStates extracts states of all partitions from scontrol show partition response.
+func parsePartitionStates(raw string) (map[string]string, error) {
+	const partitionNameF = "PartitionName"
+
+	states := make(map[string]string)
+	for _, p := range strings.Split(strings.TrimSpace(raw), "\n\n") {
+		var name string
+		for _, f := range strings.Fields(p) {
+			if s := strings.SplitN(f, "=", 2); len(s) == 2 && s[0] == partitionNameF {
+				name = s[1]
+				break
+			}
+		}
+		if name == "" {
+			continue
+		}
+		state, err := parsePartitionState(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "partition %s", name)
+		}
+		states[name] = state
+	}
+	return states, nil
+}
+
 // parsePartitionsNames extracts names from scontrol show partitions response.
 func parsePartitionsNames(raw string) []string {
 	const partitionNameF = "PartitionName"
@@ -209,6 +249,250 @@ func parsePartitionsNames(raw string) []string {
 	return names
 }
 
+// parseNodeFeatures aggregates features and generic resources of nodes
+// out of sinfo output in form node|features|gres. Node features are counted
+// per node having them, while generic resources quantities are summed up.
+func parseNodeFeatures(raw string) ([]Feature, error) {
+	type key struct{ name, version string }
+
+	var keys []key
+	quantity := make(map[key]int64)
+	add := func(k key, q int64) {
+		if _, ok := quantity[k]; !ok {
+			keys = append(keys, k)
+		}
+		quantity[k] += q
+	}
+
+	seen := make(map[string]bool)
+	for _, l := range strings.Split(strings.TrimSpace(raw), "\n") {
+		if l == "" {
+			continue
+		}
+		fields := strings.Split(l, "|")
+		if len(fields) != 3 {
+			return nil, errors.Errorf("output must contain 3 sections: %s", l)
+		}
+		// nodes may be listed more than once, e.g. when partition is not specified
+		if seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+
+		if fields[1] != nullValue {
+			for _, f := range strings.Split(fields[1], ",") {
+				if f != "" {
+					add(key{name: f}, 1)
+				}
+			}
+		}
+
+		if fields[2] != nullValue {
+			for _, g := range splitOutsideParens(fields[2]) {
+				name, typ, count, err := parseGRES(g)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not parse gres %s", g)
+				}
+				add(key{name: GRESFeaturePrefix + name, version: typ}, count)
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].version < keys[j].version
+	})
+
+	var features []Feature
+	for _, k := range keys {
+		features = append(features, Feature{
+			Name:     k.name,
+			Version:  k.version,
+			Quantity: quantity[k],
+		})
+	}
+	return features, nil
+}
+
+// parseGRES parses a single generic resource in form name[:type][:count][(S:sockets)].
+// The last field is a count when it is numeric, so gpu:2 is two gpus of any type
+// and gpu:tesla is a single tesla gpu. Count is 1 when it is omitted.
+func parseGRES(gres string) (name, typ string, count int64, err error) {
+	if i := strings.IndexByte(gres, '('); i != -1 {
+		gres = gres[:i]
+	}
+
+	parts := strings.Split(gres, ":")
+	if parts[0] == "" || len(parts) > 3 {
+		return "", "", 0, errors.New("invalid gres format")
+	}
+	name, count = parts[0], 1
+
+	fields := parts[1:]
+	if n := len(fields); n != 0 {
+		c, err := parseSize(fields[n-1])
+		switch {
+		case err == nil:
+			count, fields = c, fields[:n-1]
+		case n == 2:
+			// type is followed by something that is not a count
+			return "", "", 0, errors.Wrap(err, "invalid gres count")
+		}
+	}
+	if len(fields) != 0 {
+		typ = fields[0]
+	}
+	return name, typ, count, nil
+}
+
+// splitOutsideParens splits comma separated list ignoring commas
+// inside parenthesis, e.g. gpu:2(S:0,1),mps:100.
+func splitOutsideParens(s string) []string {
+	var res []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(s) {
+		res = append(res, s[start:])
+	}
+	return res
+}
+
+// parseNodes parses scontrol show node -o response.
+func parseNodes(raw string) ([]*NodeInfo, error) {
+	var nodes []*NodeInfo
+	for _, l := range strings.Split(strings.TrimSpace(raw), "\n") {
+		fields := parseOneliner(l)
+		name, ok := fields["NodeName"]
+		if !ok {
+			continue
+		}
+
+		n := NodeInfo{
+			Name:   name,
+			State:  fields["State"],
+			Reason: fields["Reason"],
+		}
+		for _, f := range []struct {
+			name string
+			to   *int64
+		}{
+			{name: "CPUTot", to: &n.CPUs},
+			{name: "CPUAlloc", to: &n.AllocCPUs},
+			{name: "RealMemory", to: &n.RealMemory},
+			{name: "AllocMem", to: &n.AllocMemory},
+		} {
+			v, ok := fields[f.name]
+			if !ok {
+				continue
+			}
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse %s of node %s", f.name, name)
+			}
+			*f.to = i
+		}
+		if f := fields["AvailableFeatures"]; f != "" && f != nullValue {
+			n.Features = strings.Split(f, ",")
+		}
+		if g := fields["Gres"]; g != "" && g != nullValue {
+			for _, gres := range splitOutsideParens(g) {
+				name, typ, count, err := parseGRES(gres)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not parse gres %s of node %s", gres, n.Name)
+				}
+				n.Gres = append(n.Gres, Feature{Name: name, Version: typ, Quantity: count})
+			}
+		}
+		if p := fields["Partitions"]; p != "" {
+			n.Partitions = strings.Split(p, ",")
+		}
+		nodes = append(nodes, &n)
+	}
+	return nodes, nil
+}
+
+// parseOneliner parses a single line of scontrol -o output into key value pairs.
+// Values may contain spaces, e.g. OS or Reason, so words that don't look like
+// a key=value pair are appended to the previous value.
+func parseOneliner(line string) map[string]string {
+	fields := make(map[string]string)
+	var key string
+	for _, w := range strings.Fields(line) {
+		if i := strings.IndexByte(w, '='); i > 0 && isKey(w[:i]) {
+			key = w[:i]
+			fields[key] = w[i+1:]
+			continue
+		}
+		if key != "" {
+			fields[key] += " " + w
+		}
+	}
+	return fields
+}
+
+func isKey(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLicenses extracts licenses from scontrol show licenses -o response.
+// License quantity is its total amount.
+func parseLicenses(raw string) ([]Feature, error) {
+	var licenses []Feature
+	for _, l := range strings.Split(strings.TrimSpace(raw), "\n") {
+		var license Feature
+		for _, f := range strings.Fields(l) {
+			s := strings.SplitN(f, "=", 2)
+			if len(s) != 2 {
+				continue
+			}
+			switch s[0] {
+			case "LicenseName":
+				license.Name = s[1]
+			case "Total":
+				total, err := strconv.ParseInt(s[1], 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not parse %s license total", license.Name)
+				}
+				license.Quantity = total
+			}
+		}
+		if license.Name != "" {
+			licenses = append(licenses, license)
+		}
+	}
+	return licenses, nil
+}
+
+// isControllerUp checks scontrol ping response and returns true
+// if at least one of the controllers is up.
+func isControllerUp(raw string) bool {
+	for _, line := range strings.Split(strings.TrimSpace(raw), "\n") {
+		if strings.HasPrefix(line, "Slurmctld") && strings.HasSuffix(strings.TrimSpace(line), "is UP") {
+			return true
+		}
+	}
+	return false
+}
+
 // parseSacctResponse is a helper that parses sacct output and
 // returns results in a convenient form.
 func parseSacctResponse(raw string) ([]*JobStepInfo, error) {
@@ -252,14 +536,250 @@ func parseSacctResponse(raw string) ([]*JobStepInfo, error) {
 	return infos, nil
 }
 
-func parseTime(timeStr string) (*time.Time, error) {
-	const slurmTimeLayout = "2006-01-02T15:04:05"
+// parseJobList parses squeue or sacct output in form
+// id|state|partition|user|submit time|comment|name.
+// Job name goes last since it may contain separator.
+func parseJobList(raw string) ([]*JobInfo, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(raw, "\n")
+	infos := make([]*JobInfo, len(lines))
+	for i, l := range lines {
+		fields := strings.SplitN(l, "|", 7)
+		if len(fields) != 7 {
+			return nil, errors.Errorf("output must contain 7 sections: %s", l)
+		}
+
+		submitted, err := parseTime(fields[4])
+		if err != nil {
+			return nil, err
+		}
+
+		// sacct reports states like 'CANCELLED by 1000'
+		state := fields[1]
+		if i := strings.IndexByte(state, ' '); i != -1 {
+			state = state[:i]
+		}
+
+		if fields[5] == nullValue {
+			fields[5] = ""
+		}
+
+		infos[i] = &JobInfo{
+			ID:         fields[0],
+			State:      state,
+			Partition:  fields[2],
+			UserID:     fields[3],
+			SubmitTime: submitted,
+			Comment:    fields[5],
+			Name:       fields[6],
+		}
+	}
+	return infos, nil
+}
+
+// parseSacctJobInfo parses sacct output in form
+// jobidraw|jobid|state|user|workdir|name for a single job.
+// Job name goes last since it may contain separator.
+func parseSacctJobInfo(raw string) (*JobInfo, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, errors.New("job is not found")
+	}
+
+	line := strings.SplitN(raw, "\n", 2)[0]
+	fields := strings.SplitN(line, "|", 6)
+	if len(fields) != 6 {
+		return nil, errors.Errorf("output must contain 6 sections: %s", line)
+	}
+
+	// sacct reports states like 'CANCELLED by 1000'
+	state := fields[2]
+	if i := strings.IndexByte(state, ' '); i != -1 {
+		state = state[:i]
+	}
+
+	ji := &JobInfo{
+		ID:      fields[0],
+		State:   state,
+		UserID:  fields[3],
+		WorkDir: fields[4],
+		Name:    fields[5],
+	}
+	// array tasks are reported as <array job id>_<task id>
+	if i := strings.IndexByte(fields[1], '_'); i != -1 {
+		ji.ArrayJobID = fields[1][:i]
+		ji.ArrayTaskID = fields[1][i+1:]
+	}
+	return ji, nil
+}
+
+// parseSacctUsage parses sacct output in form
+// jobid|elapsedraw|totalcpu|maxrss|averss|maxdiskread|maxdiskwrite|consumedenergyraw|alloctres.
+// Fields that are not reported, e.g. for a running job, are left zero.
+func parseSacctUsage(raw string) ([]*JobStepUsage, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(raw, "\n")
+	usage := make([]*JobStepUsage, len(lines))
+	for i, l := range lines {
+		fields := strings.Split(l, "|")
+		if len(fields) != 9 {
+			return nil, errors.Errorf("output must contain 9 sections: %s", l)
+		}
+
+		u := JobStepUsage{ID: fields[0]}
+		var err error
+		if fields[1] != "" {
+			elapsed, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not parse elapsed time")
+			}
+			u.Elapsed = time.Duration(elapsed) * time.Second
+		}
+		if u.CPUTime, err = parseCPUTime(fields[2]); err != nil {
+			return nil, errors.Wrap(err, "could not parse cpu time")
+		}
+		if u.MaxRSS, err = parseSize(fields[3]); err != nil {
+			return nil, errors.Wrap(err, "could not parse max rss")
+		}
+		if u.AveRSS, err = parseSize(fields[4]); err != nil {
+			return nil, errors.Wrap(err, "could not parse ave rss")
+		}
+		if u.MaxDiskRead, err = parseSize(fields[5]); err != nil {
+			return nil, errors.Wrap(err, "could not parse max disk read")
+		}
+		if u.MaxDiskWrite, err = parseSize(fields[6]); err != nil {
+			return nil, errors.Wrap(err, "could not parse max disk write")
+		}
+		if fields[7] != "" {
+			if u.ConsumedEnergy, err = strconv.ParseInt(fields[7], 10, 64); err != nil {
+				return nil, errors.Wrap(err, "could not parse consumed energy")
+			}
+		}
+		u.AllocTRES = parseTRES(fields[8])
+		usage[i] = &u
+	}
+	return usage, nil
+}
+
+// Efficiency calculates CPU and memory efficiency of a job in percent out of its usage,
+// as returned by SJobUsage. CPU efficiency is a ratio of consumed CPU time to allocated one,
+// i.e. elapsed time multiplied by the number of allocated CPUs. Memory efficiency is a ratio
+// of the maximum resident set size among job steps to allocated memory. Zero is returned
+// when the corresponding value can't be calculated, e.g. job has not started yet.
+func Efficiency(usage []*JobStepUsage) (cpu, mem int32) {
+	if len(usage) == 0 {
+		return 0, 0
+	}
+	alloc := usage[0]
+
+	cpus, _ := strconv.ParseInt(alloc.AllocTRES["cpu"], 10, 64)
+	if cpus > 0 && alloc.Elapsed > 0 {
+		cpu = int32(alloc.CPUTime * 100 / (alloc.Elapsed * time.Duration(cpus)))
+	}
+
+	allocMem, _ := parseSize(alloc.AllocTRES["mem"])
+	if allocMem > 0 {
+		var maxRSS int64
+		for _, u := range usage {
+			if u.MaxRSS > maxRSS {
+				maxRSS = u.MaxRSS
+			}
+		}
+		mem = int32(maxRSS * 100 / allocMem)
+	}
+	return cpu, mem
+}
+
+// parseCPUTime parses sacct cpu time that has format of duration
+// with optional milliseconds part, e.g. 1-02:03:04 or 01:02.345.
+func parseCPUTime(cpuTime string) (time.Duration, error) {
+	if cpuTime == "" {
+		return 0, nil
+	}
+
+	var ms time.Duration
+	if i := strings.IndexByte(cpuTime, '.'); i != -1 {
+		v, err := strconv.ParseInt(cpuTime[i+1:], 10, 0)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid amount of milliseconds")
+		}
+		ms = time.Duration(v) * time.Millisecond
+		cpuTime = cpuTime[:i]
+	}
 
+	d, err := ParseDuration(cpuTime)
+	if err != nil {
+		return 0, err
+	}
+	return *d + ms, nil
+}
+
+// parseSize parses sacct size that may have a binary unit suffix, e.g. 1.50M.
+// Returned value is in bytes.
+func parseSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	multiplier := float64(1)
+	switch size[len(size)-1] {
+	case 'K':
+		multiplier = 1 << 10
+	case 'M':
+		multiplier = 1 << 20
+	case 'G':
+		multiplier = 1 << 30
+	case 'T':
+		multiplier = 1 << 40
+	case 'P':
+		multiplier = 1 << 50
+	}
+	if multiplier != 1 {
+		size = size[:len(size)-1]
+	}
+
+	v, err := strconv.ParseFloat(size, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v * multiplier), nil
+}
+
+// parseTRES parses trackable resources list, e.g. cpu=2,mem=1G,node=1.
+func parseTRES(tres string) map[string]string {
+	if tres == "" {
+		return nil
+	}
+
+	res := make(map[string]string)
+	for _, r := range strings.Split(tres, ",") {
+		if s := strings.SplitN(r, "=", 2); len(s) == 2 {
+			res[s[0]] = s[1]
+		}
+	}
+	return res
+}
+
+// formatTime formats time the way slurm commands accept it, i.e. in local time of the host.
+func formatTime(t time.Time) string {
+	return t.In(time.Local).Format(slurmTimeLayout)
+}
+
+// parseTime parses time printed by slurm commands, which is in local time of the host.
+func parseTime(timeStr string) (*time.Time, error) {
 	if timeStr == "" || strings.ToLower(timeStr) == "unknown" {
 		return nil, nil
 	}
 
-	t, err := time.Parse(slurmTimeLayout, timeStr)
+	t, err := time.ParseInLocation(slurmTimeLayout, timeStr, time.Local)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testSacctTime = time.Date(2019, 2, 20, 11, 16, 55, 0, time.UTC)
+var testSacctTime = time.Date(2019, 2, 20, 11, 16, 55, 0, time.Local)
 
 func TestParseDuration(t *testing.T) {
 	tt := []struct {
@@ -312,3 +312,571 @@ func Test_parsePartitionsNames(t *testing.T) {
 		})
 	}
 }
+
+func Test_parsePartitionState(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        string
+		expectedErr bool
+	}{
+		{
+			name: "up",
+			in:   testScontrolShowPartition,
+			want: "UP",
+		},
+		{
+			name: "drain",
+			in:   "PartitionName=debug MaxNodes=3 State=DRAIN TotalCPUs=2",
+			want: "DRAIN",
+		},
+		{
+			name:        "missing",
+			in:          "PartitionName=debug MaxNodes=3",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePartitionState(tt.in)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parsePartitionStates(t *testing.T) {
+	got, err := parsePartitionStates(`PartitionName=debug MaxNodes=3 State=UP TotalCPUs=2
+
+PartitionName=gpu MaxNodes=1 State=DRAIN TotalCPUs=4
+`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"debug": "UP", "gpu": "DRAIN"}, got)
+
+	got, err = parsePartitionStates("No partitions in the system\n")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = parsePartitionStates("PartitionName=debug MaxNodes=3")
+	require.Error(t, err)
+}
+
+func Test_isControllerUp(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{
+			name: "primary up",
+			in:   "Slurmctld(primary) at vagrant is UP\n",
+			want: true,
+		},
+		{
+			name: "primary down",
+			in:   "Slurmctld(primary) at vagrant is DOWN\n",
+			want: false,
+		},
+		{
+			name: "backup up",
+			in:   "Slurmctld(primary) at ctl1 is DOWN\nSlurmctld(backup) at ctl2 is UP\n",
+			want: true,
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isControllerUp(tt.in))
+		})
+	}
+}
+
+func Test_parseJobList(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        []*JobInfo
+		expectError bool
+	}{
+		{
+			name: "empty",
+			in:   "\n",
+			want: nil,
+		},
+		{
+			name: "squeue",
+			in: `35|RUNNING|debug|vagrant|2019-02-20T11:16:55|(null)|test
+36|PENDING|debug|vagrant|2019-02-20T11:16:55|cow|name|with|pipes
+`,
+			want: []*JobInfo{
+				{
+					ID:         "35",
+					State:      "RUNNING",
+					Partition:  "debug",
+					UserID:     "vagrant",
+					SubmitTime: &testSacctTime,
+					Name:       "test",
+				},
+				{
+					ID:         "36",
+					State:      "PENDING",
+					Partition:  "debug",
+					UserID:     "vagrant",
+					SubmitTime: &testSacctTime,
+					Comment:    "cow",
+					Name:       "name|with|pipes",
+				},
+			},
+		},
+		{
+			name: "sacct",
+			in:   "37|CANCELLED by 1000|debug|vagrant|2019-02-20T11:16:55||sbatch",
+			want: []*JobInfo{
+				{
+					ID:         "37",
+					State:      "CANCELLED",
+					Partition:  "debug",
+					UserID:     "vagrant",
+					SubmitTime: &testSacctTime,
+					Name:       "sbatch",
+				},
+			},
+		},
+		{
+			name:        "invalid format",
+			in:          "37|COMPLETED|debug",
+			expectError: true,
+		},
+		{
+			name:        "invalid time",
+			in:          "37|COMPLETED|debug|vagrant|yesterday||sbatch",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJobList(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseTime_localTime(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	time.Local = time.FixedZone("UTC+3", 3*60*60)
+
+	submitted, err := parseTime("2019-02-20T14:16:55")
+	require.NoError(t, err)
+	require.True(t, submitted.Equal(time.Date(2019, 2, 20, 11, 16, 55, 0, time.UTC)), "got %s", submitted)
+
+	since := time.Date(2019, 2, 20, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, "2019-02-20T15:00:00", formatTime(since))
+
+	jobs, err := parseJobList("35|RUNNING|debug|vagrant|2019-02-20T14:16:55|(null)|test")
+	require.NoError(t, err)
+	require.True(t, jobs[0].SubmitTime.Before(since), "job was submitted an hour before since")
+}
+
+func Test_parseSacctJobInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        *JobInfo
+		expectError bool
+	}{
+		{
+			name: "job",
+			in:   "53|53|COMPLETED|vagrant|/home/vagrant|cow|with|pipes\n",
+			want: &JobInfo{
+				ID:      "53",
+				State:   "COMPLETED",
+				UserID:  "vagrant",
+				WorkDir: "/home/vagrant",
+				Name:    "cow|with|pipes",
+			},
+		},
+		{
+			name: "array task",
+			in:   "196|192_4|CANCELLED by 1000|vagrant|/home/vagrant|sbatch\n",
+			want: &JobInfo{
+				ID:          "196",
+				ArrayJobID:  "192",
+				ArrayTaskID: "4",
+				State:       "CANCELLED",
+				UserID:      "vagrant",
+				WorkDir:     "/home/vagrant",
+				Name:        "sbatch",
+			},
+		},
+		{
+			name:        "not found",
+			in:          "\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid",
+			in:          "53|53|COMPLETED",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSacctJobInfo(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseSacctUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        []*JobStepUsage
+		expectError bool
+	}{
+		{
+			name: "finished job",
+			in: `35|120|01:30.500|||||360|billing=2,cpu=2,mem=1G,node=1
+35.batch|120|01:30.500|1048576K|512K|2.50M|0|360|cpu=2,mem=1G,node=1
+35.extern|120|00:00:00|0|0|0|0|0|billing=2,cpu=2,mem=1G,node=1
+`,
+			want: []*JobStepUsage{
+				{
+					ID:             "35",
+					Elapsed:        2 * time.Minute,
+					CPUTime:        time.Minute + 30*time.Second + 500*time.Millisecond,
+					ConsumedEnergy: 360,
+					AllocTRES:      map[string]string{"billing": "2", "cpu": "2", "mem": "1G", "node": "1"},
+				},
+				{
+					ID:             "35.batch",
+					Elapsed:        2 * time.Minute,
+					CPUTime:        time.Minute + 30*time.Second + 500*time.Millisecond,
+					MaxRSS:         1 << 30,
+					AveRSS:         512 << 10,
+					MaxDiskRead:    5 << 19,
+					ConsumedEnergy: 360,
+					AllocTRES:      map[string]string{"cpu": "2", "mem": "1G", "node": "1"},
+				},
+				{
+					ID:        "35.extern",
+					Elapsed:   2 * time.Minute,
+					AllocTRES: map[string]string{"billing": "2", "cpu": "2", "mem": "1G", "node": "1"},
+				},
+			},
+		},
+		{
+			name: "pending job",
+			in:   "36|0|00:00:00||||||billing=1,cpu=1,node=1",
+			want: []*JobStepUsage{
+				{
+					ID:        "36",
+					AllocTRES: map[string]string{"billing": "1", "cpu": "1", "node": "1"},
+				},
+			},
+		},
+		{
+			name:        "invalid format",
+			in:          "35|120|01:30",
+			expectError: true,
+		},
+		{
+			name:        "invalid size",
+			in:          "35|120|01:30|1X||||||",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSacctUsage(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEfficiency(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []*JobStepUsage
+		wantCPU int32
+		wantMem int32
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "finished job",
+			in: []*JobStepUsage{
+				{
+					Elapsed:   100 * time.Second,
+					CPUTime:   150 * time.Second,
+					AllocTRES: map[string]string{"cpu": "2", "mem": "1G"},
+				},
+				{MaxRSS: 256 << 20},
+				{MaxRSS: 512 << 20},
+			},
+			wantCPU: 75,
+			wantMem: 50,
+		},
+		{
+			name: "not started job",
+			in: []*JobStepUsage{
+				{AllocTRES: map[string]string{"cpu": "2"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, mem := Efficiency(tt.in)
+			require.Equal(t, tt.wantCPU, cpu)
+			require.Equal(t, tt.wantMem, mem)
+		})
+	}
+}
+
+const testSinfoNodes = `node-1|avx2,singularity|gpu:tesla:2(S:0-1),mps:100
+node-2|avx2|gpu:tesla:1(S:0,1),gpu:k80:2
+node-3|(null)|(null)
+node-3|(null)|(null)
+node-4|singularity|bandwidth:lustre:4K
+`
+
+func Test_parseNodeFeatures(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        []Feature
+		expectError bool
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: nil,
+		},
+		{
+			name: "features and gres",
+			in:   testSinfoNodes,
+			want: []Feature{
+				{Name: "avx2", Quantity: 2},
+				{Name: "gres/bandwidth", Version: "lustre", Quantity: 4096},
+				{Name: "gres/gpu", Version: "k80", Quantity: 2},
+				{Name: "gres/gpu", Version: "tesla", Quantity: 3},
+				{Name: "gres/mps", Quantity: 100},
+				{Name: "singularity", Quantity: 2},
+			},
+		},
+		{
+			name: "gres without count",
+			in:   "node-1|(null)|gpu,gpu:tesla",
+			want: []Feature{
+				{Name: "gres/gpu", Quantity: 1},
+				{Name: "gres/gpu", Version: "tesla", Quantity: 1},
+			},
+		},
+		{
+			name: "feature and gres with the same name",
+			in:   "node-1|gpu|gpu:2\nnode-2|gpu|(null)",
+			want: []Feature{
+				{Name: "gpu", Quantity: 2},
+				{Name: "gres/gpu", Quantity: 2},
+			},
+		},
+		{
+			name:        "invalid format",
+			in:          "node-1|avx2",
+			expectError: true,
+		},
+		{
+			name:        "invalid gres count",
+			in:          "node-1|(null)|gpu:tesla:many",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodeFeatures(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseGRES(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantName    string
+		wantType    string
+		wantCount   int64
+		expectError bool
+	}{
+		{
+			name:      "name only",
+			in:        "gpu",
+			wantName:  "gpu",
+			wantCount: 1,
+		},
+		{
+			name:      "type and count",
+			in:        "gpu:tesla:2",
+			wantName:  "gpu",
+			wantType:  "tesla",
+			wantCount: 2,
+		},
+		{
+			name:      "count only",
+			in:        "gpu:2",
+			wantName:  "gpu",
+			wantCount: 2,
+		},
+		{
+			name:      "type only",
+			in:        "gpu:tesla",
+			wantName:  "gpu",
+			wantType:  "tesla",
+			wantCount: 1,
+		},
+		{
+			name:      "sockets",
+			in:        "gpu:tesla:2(S:0-1)",
+			wantName:  "gpu",
+			wantType:  "tesla",
+			wantCount: 2,
+		},
+		{
+			name:        "invalid count",
+			in:          "gpu:tesla:many",
+			expectError: true,
+		},
+		{
+			name:        "too many fields",
+			in:          "gpu:tesla:k80:2",
+			expectError: true,
+		},
+		{
+			name:        "no name",
+			in:          ":2",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, typ, count, err := parseGRES(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantName, name)
+			require.Equal(t, tt.wantType, typ)
+			require.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func Test_parseLicenses(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        []Feature
+		expectError bool
+	}{
+		{
+			name: "no licenses",
+			in:   "No licenses configured\n",
+			want: nil,
+		},
+		{
+			name: "licenses",
+			in: `LicenseName=matlab Total=10 Used=2 Free=8 Reserved=0 Remote=no
+LicenseName=fluent@db Total=100 Used=0 Free=100 Reserved=0 Remote=yes
+`,
+			want: []Feature{
+				{Name: "matlab", Quantity: 10},
+				{Name: "fluent@db", Quantity: 100},
+			},
+		},
+		{
+			name:        "invalid total",
+			in:          "LicenseName=matlab Total=ten Used=2 Free=8 Reserved=0 Remote=no",
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLicenses(tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+const testScontrolShowNodes = `NodeName=node-1 Arch=x86_64 CoresPerSocket=1 CPUAlloc=1 CPUTot=2 CPULoad=0.01 AvailableFeatures=avx2,singularity ActiveFeatures=avx2,singularity Gres=gpu:tesla:2(S:0-1) NodeAddr=node-1 NodeHostName=node-1 Version=18.08 OS=Linux 4.15.0-45-generic #48-Ubuntu SMP Tue Jan 29 16:28:13 UTC 2019 RealMemory=2048 AllocMem=512 FreeMem=1200 Sockets=2 Boards=1 State=MIXED ThreadsPerCore=1 TmpDisk=0 Weight=1 Owner=N/A MCS_label=N/A Partitions=debug,gpu BootTime=2019-02-19T10:00:00 SlurmdStartTime=2019-02-19T10:01:00 CfgTRES=cpu=2,mem=2G,billing=2 AllocTRES=cpu=1,mem=512M CapWatts=n/a CurrentWatts=0 LowestJoules=0 ConsumedJoules=0 ExtSensorsJoules=n/s ExtSensorsWatts=0 ExtSensorsTemp=n/s
+NodeName=node-2 Arch=x86_64 CoresPerSocket=1 CPUAlloc=0 CPUTot=2 CPULoad=0.00 AvailableFeatures=(null) ActiveFeatures=(null) Gres=(null) NodeAddr=node-2 NodeHostName=node-2 Version=18.08 OS=Linux 4.15.0-45-generic #48-Ubuntu SMP Tue Jan 29 16:28:13 UTC 2019 RealMemory=1024 AllocMem=0 FreeMem=900 Sockets=2 Boards=1 State=IDLE+DRAIN ThreadsPerCore=1 TmpDisk=0 Weight=1 Owner=N/A MCS_label=N/A Partitions=debug BootTime=2019-02-19T10:00:00 SlurmdStartTime=2019-02-19T10:01:00 CfgTRES=cpu=2,mem=1G,billing=2 AllocTRES= CapWatts=n/a CurrentWatts=0 LowestJoules=0 ConsumedJoules=0 ExtSensorsJoules=n/s ExtSensorsWatts=0 ExtSensorsTemp=n/s Reason=maintenance window [root@2019-02-20T10:00:00]
+`
+
+func Test_parseNodes(t *testing.T) {
+	got, err := parseNodes(testScontrolShowNodes)
+	require.NoError(t, err)
+	require.Equal(t, []*NodeInfo{
+		{
+			Name:        "node-1",
+			State:       "MIXED",
+			CPUs:        2,
+			AllocCPUs:   1,
+			RealMemory:  2048,
+			AllocMemory: 512,
+			Features:    []string{"avx2", "singularity"},
+			Gres:        []Feature{{Name: "gpu", Version: "tesla", Quantity: 2}},
+			Partitions:  []string{"debug", "gpu"},
+		},
+		{
+			Name:       "node-2",
+			State:      "IDLE+DRAIN",
+			CPUs:       2,
+			RealMemory: 1024,
+			Reason:     "maintenance window [root@2019-02-20T10:00:00]",
+			Partitions: []string{"debug"},
+		},
+	}, got)
+
+	got, err = parseNodes("No nodes in the system\n")
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	_, err = parseNodes("NodeName=node-1 CPUTot=two")
+	require.Error(t, err)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbatch
+
+// argument describes whether option takes a value.
+type argument int
+
+const (
+	noArgument argument = iota
+	requiredArgument
+	optionalArgument
+)
+
+// option is a documented sbatch option.
+type option struct {
+	long  string
+	short byte
+	arg   argument
+}
+
+// options lists all documented sbatch options.
+var options = []option{
+	{long: "account", short: 'A', arg: requiredArgument},
+	{long: "acctg-freq", arg: requiredArgument},
+	{long: "array", short: 'a', arg: requiredArgument},
+	{long: "batch", arg: requiredArgument},
+	{long: "bb", arg: requiredArgument},
+	{long: "bbf", arg: requiredArgument},
+	{long: "begin", short: 'b', arg: requiredArgument},
+	{long: "chdir", short: 'D', arg: requiredArgument},
+	{long: "cluster-constraint", arg: requiredArgument},
+	{long: "clusters", short: 'M', arg: requiredArgument},
+	{long: "comment", arg: requiredArgument},
+	{long: "constraint", short: 'C', arg: requiredArgument},
+	{long: "contiguous", arg: noArgument},
+	{long: "core-spec", short: 'S', arg: requiredArgument},
+	{long: "cores-per-socket", arg: requiredArgument},
+	{long: "cpu-freq", arg: requiredArgument},
+	{long: "cpus-per-gpu", arg: requiredArgument},
+	{long: "cpus-per-task", short: 'c', arg: requiredArgument},
+	{long: "deadline", arg: requiredArgument},
+	{long: "delay-boot", arg: requiredArgument},
+	{long: "dependency", short: 'd', arg: requiredArgument},
+	{long: "distribution", short: 'm', arg: requiredArgument},
+	{long: "error", short: 'e', arg: requiredArgument},
+	{long: "exclude", short: 'x', arg: requiredArgument},
+	{long: "exclusive", arg: optionalArgument},
+	{long: "export", arg: requiredArgument},
+	{long: "export-file", arg: requiredArgument},
+	{long: "extra-node-info", short: 'B', arg: requiredArgument},
+	{long: "get-user-env", arg: optionalArgument},
+	{long: "gid", arg: requiredArgument},
+	{long: "gpu-bind", arg: requiredArgument},
+	{long: "gpu-freq", arg: requiredArgument},
+	{long: "gpus", short: 'G', arg: requiredArgument},
+	{long: "gpus-per-node", arg: requiredArgument},
+	{long: "gpus-per-socket", arg: requiredArgument},
+	{long: "gpus-per-task", arg: requiredArgument},
+	{long: "gres", arg: requiredArgument},
+	{long: "gres-flags", arg: requiredArgument},
+	{long: "help", short: 'h', arg: noArgument},
+	{long: "hint", arg: requiredArgument},
+	{long: "hold", short: 'H', arg: noArgument},
+	{long: "ignore-pbs", arg: noArgument},
+	{long: "immediate", short: 'I', arg: optionalArgument},
+	{long: "input", short: 'i', arg: requiredArgument},
+	{long: "job-name", short: 'J', arg: requiredArgument},
+	{long: "kill-on-invalid-dep", arg: requiredArgument},
+	{long: "licenses", short: 'L', arg: requiredArgument},
+	{long: "mail-type", arg: requiredArgument},
+	{long: "mail-user", arg: requiredArgument},
+	{long: "mcs-label", arg: requiredArgument},
+	{long: "mem", arg: requiredArgument},
+	{long: "mem-bind", arg: requiredArgument},
+	{long: "mem-per-cpu", arg: requiredArgument},
+	{long: "mem-per-gpu", arg: requiredArgument},
+	{long: "mincpus", arg: requiredArgument},
+	{long: "network", arg: requiredArgument},
+	{long: "nice", arg: optionalArgument},
+	{long: "no-kill", short: 'k', arg: optionalArgument},
+	{long: "no-requeue", arg: noArgument},
+	{long: "nodefile", short: 'F', arg: requiredArgument},
+	{long: "nodelist", short: 'w', arg: requiredArgument},
+	{long: "nodes", short: 'N', arg: requiredArgument},
+	{long: "ntasks", short: 'n', arg: requiredArgument},
+	{long: "ntasks-per-core", arg: requiredArgument},
+	{long: "ntasks-per-gpu", arg: requiredArgument},
+	{long: "ntasks-per-node", arg: requiredArgument},
+	{long: "ntasks-per-socket", arg: requiredArgument},
+	{long: "open-mode", arg: requiredArgument},
+	{long: "output", short: 'o', arg: requiredArgument},
+	{long: "overcommit", short: 'O', arg: noArgument},
+	{long: "oversubscribe", short: 's', arg: noArgument},
+	{long: "parsable", arg: noArgument},
+	{long: "partition", short: 'p', arg: requiredArgument},
+	{long: "power", arg: requiredArgument},
+	{long: "priority", arg: requiredArgument},
+	{long: "profile", arg: requiredArgument},
+	{long: "propagate", arg: optionalArgument},
+	{long: "qos", short: 'q', arg: requiredArgument},
+	{long: "quiet", short: 'Q', arg: noArgument},
+	{long: "reboot", arg: noArgument},
+	{long: "requeue", arg: noArgument},
+	{long: "reservation", arg: requiredArgument},
+	{long: "signal", arg: requiredArgument},
+	{long: "sockets-per-node", arg: requiredArgument},
+	{long: "spread-job", arg: noArgument},
+	{long: "switches", arg: requiredArgument},
+	{long: "test-only", arg: noArgument},
+	{long: "thread-spec", arg: requiredArgument},
+	{long: "threads-per-core", arg: requiredArgument},
+	{long: "time", short: 't', arg: requiredArgument},
+	{long: "time-min", arg: requiredArgument},
+	{long: "tmp", arg: requiredArgument},
+	{long: "uid", arg: requiredArgument},
+	{long: "usage", arg: noArgument},
+	{long: "use-min-nodes", arg: noArgument},
+	{long: "verbose", short: 'v', arg: noArgument},
+	{long: "version", short: 'V', arg: noArgument},
+	{long: "wait", short: 'W', arg: noArgument},
+	{long: "wait-all-nodes", arg: requiredArgument},
+	{long: "wckey", arg: requiredArgument},
+	{long: "wrap", arg: requiredArgument},
+}
+
+var (
+	longOptions  = make(map[string]option, len(options))
+	shortOptions = make(map[byte]option, len(options))
+)
+
+func init() {
+	for _, o := range options {
+		longOptions[o.long] = o
+		if o.short != 0 {
+			shortOptions[o.short] = o
+		}
+	}
+}
@@ -0,0 +1,563 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sbatch parses sbatch directives of Slurm batch scripts into
+// a typed model and renders the model back to directives.
+package sbatch
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sylabs/wlm-operator/pkg/slurm"
+)
+
+const (
+	// Header is a prefix of batch script lines holding sbatch options.
+	Header = "#SBATCH"
+
+	// UnlimitedTime is a time limit value that stands for UNLIMITED.
+	UnlimitedTime = time.Duration(-1)
+
+	// AllMemory is a memory value that stands for --mem=0, which
+	// requests all memory of each node.
+	AllMemory = int64(-1)
+)
+
+var arrayRe = regexp.MustCompile(`^[0-9]+([-:,%][0-9]+)*$`)
+
+type (
+	// Directive is a single sbatch option as it is specified in a batch script.
+	Directive struct {
+		// Name is a long option name without leading dashes, e.g. time. Unknown
+		// short options are kept as is, e.g. y.
+		Name string
+		// Value is an option value, empty if it is not set.
+		Value string
+	}
+
+	// Gres is a single requested generic resource.
+	Gres struct {
+		Name  string
+		Type  string
+		Count int64
+	}
+
+	// Options is a typed model of sbatch options. Zero values stand for options that are not set.
+	Options struct {
+		JobName    string
+		Account    string
+		QOS        string
+		Partitions []string
+		// Time is a job time limit, UnlimitedTime if set to UNLIMITED or 0.
+		Time time.Duration
+		// TimeMin is a minimum job time limit, UnlimitedTime if set to UNLIMITED or 0.
+		TimeMin       time.Duration
+		Nodes         int64
+		MaxNodes      int64
+		NTasks        int64
+		NTasksPerNode int64
+		CPUsPerTask   int64
+		// Mem is a memory required per node in megabytes, AllMemory if set to 0.
+		Mem int64
+		// MemPerCPU is a memory required per allocated CPU in megabytes.
+		MemPerCPU     int64
+		Gres          []Gres
+		Constraint    string
+		Array         string
+		Exclusive     bool
+		ExclusiveMode string
+		Output        string
+		Error         string
+		WorkDir       string
+
+		// Other holds the rest of options in order of their appearance.
+		Other []Directive
+		// Unknown lists names of options that are not documented sbatch options.
+		// Such options are kept in Other as well.
+		Unknown []string
+	}
+)
+
+// Parse parses sbatch directives of a batch script. Like sbatch, it stops
+// at the first line that is neither empty nor a comment. When option
+// is specified several times, the last value is used.
+func Parse(script string) (*Options, error) {
+	directives, unknown, err := ParseDirectives(script)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Options{Unknown: unknown}
+	for _, d := range directives {
+		if err := o.apply(d); err != nil {
+			return nil, errors.Wrapf(err, "invalid --%s value %q", d.Name, d.Value)
+		}
+	}
+	return o, nil
+}
+
+// ParseDirectives extracts sbatch directives of a batch script in order of their appearance along
+// with names of unknown options. Short options are converted to long ones.
+func ParseDirectives(script string) ([]Directive, []string, error) {
+	var directives []Directive
+	var unknown []string
+
+	s := bufio.NewScanner(strings.NewReader(script))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		rest := strings.TrimPrefix(line, Header)
+		if rest == line || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			// shebang or a regular comment
+			continue
+		}
+
+		tokens, err := splitDirective(rest)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "line %d", n)
+		}
+		ds, u, err := parseTokens(tokens)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "line %d", n)
+		}
+		directives = append(directives, ds...)
+		unknown = append(unknown, u...)
+	}
+	if err := s.Err(); err != nil {
+		return nil, nil, errors.Wrap(err, "could not read script")
+	}
+	return directives, unknown, nil
+}
+
+// splitDirective splits directive into whitespace separated tokens. Single and double
+// quotes group tokens, unquoted # starts a comment. As sbatch does, backslash escapes
+// the next character outside of single quotes.
+func splitDirective(directive string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inToken := false
+	escaped := false
+	var quote rune
+	for _, c := range directive {
+		switch {
+		case escaped:
+			cur.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inToken = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+			cur.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote = c
+			inToken = true
+		case c == '#':
+			if inToken {
+				tokens = append(tokens, cur.String())
+			}
+			return tokens, nil
+		case c == ' ' || c == '\t':
+			if inToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inToken = false
+			}
+		default:
+			cur.WriteRune(c)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// parseTokens converts directive tokens into directives.
+func parseTokens(tokens []string) ([]Directive, []string, error) {
+	var directives []Directive
+	var unknown []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		var opt option
+		var known, hasValue bool
+		var name, value string
+		switch {
+		case strings.HasPrefix(tok, "--") && len(tok) > 2:
+			name = tok[2:]
+			if j := strings.IndexByte(name, '='); j != -1 {
+				name, value, hasValue = name[:j], name[j+1:], true
+			}
+			opt, known = longOptions[name]
+		case strings.HasPrefix(tok, "-") && len(tok) > 1 && tok[1] != '-':
+			name = tok[1:2]
+			// both -N5 and -N=5 are accepted
+			value = strings.TrimPrefix(tok[2:], "=")
+			hasValue = len(tok) > 2
+			opt, known = shortOptions[tok[1]]
+			if known {
+				name = opt.long
+			}
+		default:
+			return nil, nil, errors.Errorf("unexpected argument %q", tok)
+		}
+
+		next := func() bool {
+			if hasValue || i+1 >= len(tokens) {
+				return false
+			}
+			value, hasValue = tokens[i+1], true
+			i++
+			return true
+		}
+
+		switch {
+		case !known:
+			unknown = append(unknown, name)
+			if i+1 < len(tokens) && !strings.HasPrefix(tokens[i+1], "-") {
+				next()
+			}
+		case opt.arg == requiredArgument:
+			if !hasValue && !next() {
+				return nil, nil, errors.Errorf("option --%s requires a value", name)
+			}
+		case opt.arg == noArgument:
+			if hasValue {
+				return nil, nil, errors.Errorf("option --%s does not take a value", name)
+			}
+		}
+		directives = append(directives, Directive{Name: name, Value: value})
+	}
+	return directives, unknown, nil
+}
+
+func (o *Options) apply(d Directive) error {
+	var err error
+	switch d.Name {
+	case "job-name":
+		o.JobName = d.Value
+	case "account":
+		o.Account = d.Value
+	case "qos":
+		o.QOS = d.Value
+	case "partition":
+		o.Partitions = strings.Split(d.Value, ",")
+	case "time":
+		o.Time, err = parseTime(d.Value)
+	case "time-min":
+		o.TimeMin, err = parseTime(d.Value)
+	case "nodes":
+		o.Nodes, o.MaxNodes, err = parseNodes(d.Value)
+	case "ntasks":
+		o.NTasks, err = parsePositive(d.Value)
+	case "ntasks-per-node":
+		o.NTasksPerNode, err = parsePositive(d.Value)
+	case "cpus-per-task":
+		o.CPUsPerTask, err = parsePositive(d.Value)
+	case "mem":
+		o.Mem, err = ParseMemory(d.Value)
+		if err == nil && o.Mem == 0 {
+			o.Mem = AllMemory
+		}
+	case "mem-per-cpu":
+		o.MemPerCPU, err = ParseMemory(d.Value)
+	case "gres":
+		o.Gres, err = parseGresList(d.Value)
+	case "constraint":
+		o.Constraint = d.Value
+	case "array":
+		if !arrayRe.MatchString(d.Value) {
+			return errors.New("invalid array format")
+		}
+		o.Array = d.Value
+	case "exclusive":
+		o.Exclusive = true
+		o.ExclusiveMode = d.Value
+	case "output":
+		o.Output = d.Value
+	case "error":
+		o.Error = d.Value
+	case "chdir":
+		o.WorkDir = d.Value
+	default:
+		o.Other = append(o.Other, d)
+	}
+	return err
+}
+
+// Directives returns options as sbatch directives. Typed options go first
+// in a fixed order followed by other options.
+func (o *Options) Directives() []Directive {
+	var ds []Directive
+	add := func(name, value string) {
+		if value != "" {
+			ds = append(ds, Directive{Name: name, Value: value})
+		}
+	}
+	addInt := func(name string, v int64) {
+		if v != 0 {
+			add(name, strconv.FormatInt(v, 10))
+		}
+	}
+
+	add("job-name", o.JobName)
+	add("account", o.Account)
+	add("qos", o.QOS)
+	add("partition", strings.Join(o.Partitions, ","))
+	add("time", formatTime(o.Time))
+	add("time-min", formatTime(o.TimeMin))
+	if o.Nodes != 0 {
+		nodes := strconv.FormatInt(o.Nodes, 10)
+		if o.MaxNodes != 0 {
+			nodes += "-" + strconv.FormatInt(o.MaxNodes, 10)
+		}
+		add("nodes", nodes)
+	}
+	addInt("ntasks", o.NTasks)
+	addInt("ntasks-per-node", o.NTasksPerNode)
+	addInt("cpus-per-task", o.CPUsPerTask)
+	switch {
+	case o.Mem == AllMemory:
+		add("mem", "0")
+	case o.Mem != 0:
+		add("mem", strconv.FormatInt(o.Mem, 10)+"M")
+	}
+	if o.MemPerCPU != 0 {
+		add("mem-per-cpu", strconv.FormatInt(o.MemPerCPU, 10)+"M")
+	}
+	add("gres", formatGresList(o.Gres))
+	add("constraint", o.Constraint)
+	add("array", o.Array)
+	if o.Exclusive {
+		ds = append(ds, Directive{Name: "exclusive", Value: o.ExclusiveMode})
+	}
+	add("output", o.Output)
+	add("error", o.Error)
+	add("chdir", o.WorkDir)
+	return append(ds, o.Other...)
+}
+
+// Render returns options as #SBATCH lines, one option per line.
+func (o *Options) Render() string {
+	ds := o.Directives()
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Insert adds options as #SBATCH lines to the batch script right after its
+// shebang line, or at the very beginning if there is no shebang. Since parsing
+// stops at the first command, inserting is the only way to add directives.
+func Insert(script string, o *Options) string {
+	directives := o.Render()
+	if directives == "" {
+		return script
+	}
+	if !strings.HasPrefix(script, "#!") {
+		return directives + "\n" + script
+	}
+	i := strings.IndexByte(script, '\n')
+	if i == -1 {
+		return script + "\n" + directives + "\n"
+	}
+	return script[:i+1] + directives + "\n" + script[i+1:]
+}
+
+// directiveEscaper escapes characters that are special within double quoted directive value.
+var directiveEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// String returns directive as #SBATCH line. Values with spaces, quotes,
+// backslashes or # are double quoted with quotes and backslashes escaped.
+func (d Directive) String() string {
+	prefix := "--"
+	if len(d.Name) == 1 {
+		prefix = "-"
+	}
+	line := Header + " " + prefix + d.Name
+	if d.Value == "" {
+		return line
+	}
+	value := d.Value
+	if strings.ContainsAny(value, " \t#'\"\\") {
+		value = `"` + directiveEscaper.Replace(value) + `"`
+	}
+	if prefix == "-" {
+		return line + " " + value
+	}
+	return line + "=" + value
+}
+
+// ParseMemory parses sbatch memory value with an optional K, M, G or T suffix.
+// Megabytes are assumed when suffix is omitted. Returned value is in megabytes,
+// values that are not whole megabytes are rounded up.
+func ParseMemory(mem string) (int64, error) {
+	if mem == "" {
+		return 0, errors.New("empty memory value")
+	}
+
+	mul, div := int64(1), int64(1)
+	switch mem[len(mem)-1] {
+	case 'K', 'k':
+		div = 1 << 10
+	case 'M', 'm':
+	case 'G', 'g':
+		mul = 1 << 10
+	case 'T', 't':
+		mul = 1 << 20
+	default:
+		mem += "M"
+	}
+
+	v, err := strconv.ParseInt(mem[:len(mem)-1], 10, 64)
+	if err != nil || v < 0 {
+		return 0, errors.New("invalid memory value")
+	}
+	return (v*mul + div - 1) / div, nil
+}
+
+// parseTime parses time limit. Zero time limit means no limit is imposed, so it
+// is parsed as UnlimitedTime and is kept when options are rendered.
+func parseTime(value string) (time.Duration, error) {
+	if value == "UNLIMITED" || value == "INFINITE" {
+		return UnlimitedTime, nil
+	}
+	d, err := slurm.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if *d == 0 {
+		return UnlimitedTime, nil
+	}
+	return *d, nil
+}
+
+// formatTime formats duration as days-hours:minutes:seconds.
+func formatTime(d time.Duration) string {
+	switch {
+	case d == UnlimitedTime:
+		return "UNLIMITED"
+	case d <= 0:
+		return ""
+	}
+
+	secs := int64((d + time.Second - 1) / time.Second)
+	days, secs := secs/86400, secs%86400
+	hms := fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs%3600/60, secs%60)
+	if days != 0 {
+		return fmt.Sprintf("%d-%s", days, hms)
+	}
+	return hms
+}
+
+// parseNodes parses min[-max] nodes count.
+func parseNodes(value string) (min, max int64, err error) {
+	minV, maxV := value, ""
+	if i := strings.IndexByte(value, '-'); i != -1 {
+		minV, maxV = value[:i], value[i+1:]
+	}
+
+	min, err = parsePositive(minV)
+	if err != nil {
+		return 0, 0, err
+	}
+	if maxV == "" {
+		return min, 0, nil
+	}
+	max, err = parsePositive(maxV)
+	if err != nil {
+		return 0, 0, err
+	}
+	if max < min {
+		return 0, 0, errors.New("max nodes is less than min nodes")
+	}
+	return min, max, nil
+}
+
+func parsePositive(value string) (int64, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v <= 0 {
+		return 0, errors.New("value should be positive")
+	}
+	return v, nil
+}
+
+// parseGresList parses comma separated list of generic resources in form name[:type][:count].
+func parseGresList(value string) ([]Gres, error) {
+	var res []Gres
+	for _, g := range strings.Split(value, ",") {
+		parts := strings.Split(g, ":")
+		gres := Gres{Name: parts[0], Count: 1}
+		if gres.Name == "" {
+			return nil, errors.New("empty gres name")
+		}
+
+		var err error
+		switch len(parts) {
+		case 1:
+		case 2:
+			// the second part is either type or count
+			if c, cErr := parsePositive(parts[1]); cErr == nil {
+				gres.Count = c
+			} else {
+				gres.Type = parts[1]
+			}
+		case 3:
+			gres.Type = parts[1]
+			gres.Count, err = parsePositive(parts[2])
+		default:
+			err = errors.New("invalid gres format")
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, gres)
+	}
+	return res, nil
+}
+
+func formatGresList(gres []Gres) string {
+	res := make([]string, len(gres))
+	for i, g := range gres {
+		parts := []string{g.Name}
+		if g.Type != "" {
+			parts = append(parts, g.Type)
+		}
+		res[i] = strings.Join(append(parts, strconv.FormatInt(g.Count, 10)), ":")
+	}
+	return strings.Join(res, ",")
+}
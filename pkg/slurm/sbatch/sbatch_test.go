@@ -0,0 +1,322 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name        string
+		script      string
+		expected    *Options
+		expectedErr string
+	}{
+		{
+			name: "no directives",
+			script: `#!/bin/sh
+srun hostname
+`,
+			expected: &Options{},
+		},
+		{
+			name: "long and short forms",
+			script: `#!/bin/sh
+#SBATCH --job-name="my job" -A research
+#SBATCH -p debug,gpu --qos=high
+#SBATCH -t 1-02:03:04 --time-min=30
+#SBATCH -N2-4 --ntasks=8 --ntasks-per-node 4 -c2
+#SBATCH --output=out.log -e err.log -D /tmp
+srun hostname
+`,
+			expected: &Options{
+				JobName:       "my job",
+				Account:       "research",
+				QOS:           "high",
+				Partitions:    []string{"debug", "gpu"},
+				Time:          26*time.Hour + 3*time.Minute + 4*time.Second,
+				TimeMin:       30 * time.Minute,
+				Nodes:         2,
+				MaxNodes:      4,
+				NTasks:        8,
+				NTasksPerNode: 4,
+				CPUsPerTask:   2,
+				Output:        "out.log",
+				Error:         "err.log",
+				WorkDir:       "/tmp",
+			},
+		},
+		{
+			name: "memory suffixes",
+			script: `#!/bin/sh
+#SBATCH --mem=4G
+#SBATCH --mem-per-cpu=1500K
+`,
+			expected: &Options{
+				Mem:       4096,
+				MemPerCPU: 2,
+			},
+		},
+		{
+			name: "comments do not stop parsing",
+			script: `#!/bin/sh
+# resources
+#SBATCH --nodes=3 # three nodes
+
+##SBATCH --nodes=5
+#SBATCH --time=UNLIMITED
+hostname
+#SBATCH --nodes=10
+`,
+			expected: &Options{
+				Nodes: 3,
+				Time:  UnlimitedTime,
+			},
+		},
+		{
+			name: "zero time is unlimited",
+			script: `#SBATCH --time=0 --time-min=0
+`,
+			expected: &Options{Time: UnlimitedTime, TimeMin: UnlimitedTime},
+		},
+		{
+			name: "escaped quotes",
+			script: `#SBATCH -J "say \"hi\"" --comment=it\'s\ me
+`,
+			expected: &Options{
+				JobName: `say "hi"`,
+				Other:   []Directive{{Name: "comment", Value: "it's me"}},
+			},
+		},
+		{
+			name: "last value wins",
+			script: `#SBATCH --mem=100
+#SBATCH --mem=200
+`,
+			expected: &Options{Mem: 200},
+		},
+		{
+			name: "all memory",
+			script: `#SBATCH --mem=0 --mem-per-cpu=0
+`,
+			expected: &Options{Mem: AllMemory},
+		},
+		{
+			name: "gres constraint array exclusive",
+			script: `#!/bin/sh
+#SBATCH --gres=gpu:tesla:2,mps:100,nvme
+#SBATCH -C "intel&avx2" --array=1-10%2 --exclusive
+`,
+			expected: &Options{
+				Gres: []Gres{
+					{Name: "gpu", Type: "tesla", Count: 2},
+					{Name: "mps", Count: 100},
+					{Name: "nvme", Count: 1},
+				},
+				Constraint: "intel&avx2",
+				Array:      "1-10%2",
+				Exclusive:  true,
+			},
+		},
+		{
+			name: "exclusive mode",
+			script: `#SBATCH --exclusive=user
+`,
+			expected: &Options{Exclusive: true, ExclusiveMode: "user"},
+		},
+		{
+			name: "other and unknown options",
+			script: `#!/bin/sh
+#SBATCH --mail-type=END,FAIL -H --requeue
+#SBATCH --brand-new=1 -y
+`,
+			expected: &Options{
+				Other: []Directive{
+					{Name: "mail-type", Value: "END,FAIL"},
+					{Name: "hold"},
+					{Name: "requeue"},
+					{Name: "brand-new", Value: "1"},
+					{Name: "y"},
+				},
+				Unknown: []string{"brand-new", "y"},
+			},
+		},
+		{
+			name:        "invalid memory",
+			script:      "#SBATCH --mem=4X",
+			expectedErr: `invalid --mem value "4X": invalid memory value`,
+		},
+		{
+			name:        "invalid nodes",
+			script:      "#SBATCH --nodes=4-2",
+			expectedErr: `invalid --nodes value "4-2": max nodes is less than min nodes`,
+		},
+		{
+			name:        "invalid array",
+			script:      "#SBATCH --array=1-",
+			expectedErr: `invalid --array value "1-": invalid array format`,
+		},
+		{
+			name:        "missing value",
+			script:      "#SBATCH --partition",
+			expectedErr: "line 1: option --partition requires a value",
+		},
+		{
+			name:        "unexpected value",
+			script:      "#SBATCH --hold=yes",
+			expectedErr: "line 1: option --hold does not take a value",
+		},
+		{
+			name:        "unterminated quote",
+			script:      "#!/bin/sh\n#SBATCH -J 'name",
+			expectedErr: "line 2: unterminated quote",
+		},
+		{
+			name:        "stray argument",
+			script:      "#SBATCH -N 2 3",
+			expectedErr: `line 1: unexpected argument "3"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := Parse(tc.script)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, o)
+		})
+	}
+}
+
+func TestOptions_Render(t *testing.T) {
+	o := &Options{
+		JobName:       "my job",
+		Partitions:    []string{"debug", "gpu"},
+		Time:          26*time.Hour + 3*time.Minute + 4*time.Second,
+		Nodes:         2,
+		MaxNodes:      4,
+		NTasksPerNode: 4,
+		CPUsPerTask:   2,
+		Mem:           4096,
+		Gres:          []Gres{{Name: "gpu", Type: "tesla", Count: 2}},
+		Constraint:    "intel&avx2",
+		Exclusive:     true,
+		Other: []Directive{
+			{Name: "hold"},
+			{Name: "comment", Value: `say "hi"`},
+			{Name: "mail-user", Value: `it's "me" \ you`},
+		},
+	}
+
+	rendered := o.Render()
+	require.Equal(t, `#SBATCH --job-name="my job"
+#SBATCH --partition=debug,gpu
+#SBATCH --time=1-02:03:04
+#SBATCH --nodes=2-4
+#SBATCH --ntasks-per-node=4
+#SBATCH --cpus-per-task=2
+#SBATCH --mem=4096M
+#SBATCH --gres=gpu:tesla:2
+#SBATCH --constraint=intel&avx2
+#SBATCH --exclusive
+#SBATCH --hold
+#SBATCH --comment="say \"hi\""
+#SBATCH --mail-user="it's \"me\" \\ you"`, rendered)
+
+	parsed, err := Parse(rendered)
+	require.NoError(t, err)
+	require.Equal(t, o, parsed)
+}
+
+func TestOptions_RenderAllMemory(t *testing.T) {
+	o := &Options{Nodes: 2, Mem: AllMemory}
+	rendered := o.Render()
+	require.Equal(t, "#SBATCH --nodes=2\n#SBATCH --mem=0", rendered)
+
+	parsed, err := Parse(rendered)
+	require.NoError(t, err)
+	require.Equal(t, o, parsed)
+}
+
+func TestInsert(t *testing.T) {
+	o := &Options{Nodes: 2, Time: time.Hour}
+	tt := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			name:     "shebang",
+			script:   "#!/bin/sh\n#SBATCH --mem=1G\nsrun hostname\n",
+			expected: "#!/bin/sh\n#SBATCH --time=01:00:00\n#SBATCH --nodes=2\n#SBATCH --mem=1G\nsrun hostname\n",
+		},
+		{
+			name:     "no shebang",
+			script:   "srun hostname\n",
+			expected: "#SBATCH --time=01:00:00\n#SBATCH --nodes=2\nsrun hostname\n",
+		},
+		{
+			name:     "shebang only",
+			script:   "#!/bin/sh",
+			expected: "#!/bin/sh\n#SBATCH --time=01:00:00\n#SBATCH --nodes=2\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Insert(tc.script, o))
+		})
+	}
+	require.Equal(t, "srun hostname", Insert("srun hostname", &Options{}))
+}
+
+func TestParseMemory(t *testing.T) {
+	tt := []struct {
+		in       string
+		expected int64
+		err      bool
+	}{
+		{in: "100", expected: 100},
+		{in: "100M", expected: 100},
+		{in: "2g", expected: 2048},
+		{in: "1T", expected: 1 << 20},
+		{in: "1024K", expected: 1},
+		{in: "1025K", expected: 2},
+		{in: "0", expected: 0},
+		{in: "", err: true},
+		{in: "G", err: true},
+		{in: "-1", err: true},
+		{in: "1.5G", err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.in, func(t *testing.T) {
+			mem, err := ParseMemory(tc.in)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, mem)
+		})
+	}
+}
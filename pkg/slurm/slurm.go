@@ -35,11 +35,20 @@ const (
 	scontrolBinaryName = "scontrol"
 	sacctBinaryName    = "sacct"
 	sinfoBinaryName    = "sinfo"
+	squeueBinaryName   = "squeue"
 
 	submitTime = "SubmitTime"
 	startTime  = "StartTime"
 	runTime    = "RunTime"
 	timeLimit  = "TimeLimit"
+	comment    = "Comment"
+
+	// nullValue is printed by slurm for unset fields.
+	nullValue = "(null)"
+
+	// GRESFeaturePrefix prefixes names of generic resources among partition
+	// features, as slurm does in TRES, so that they don't clash with node features.
+	GRESFeaturePrefix = "gres/"
 )
 
 var (
@@ -52,32 +61,46 @@ var (
 
 	// ErrFileNotFound is returned when Open fails to find a file.
 	ErrFileNotFound = errors.New("file is not found")
+
+	// ErrControllerIsDown is returned by Ping when no Slurm controller is responding.
+	ErrControllerIsDown = errors.New("slurm controller is down")
 )
 
 type (
 	// Client implements Slurm interface for communicating with
 	// a local Slurm cluster by calling Slurm binaries directly.
-	Client struct{}
+	Client struct {
+		execHook ExecHook
+	}
+
+	// ClientOption configures optional Client behaviour.
+	ClientOption func(*Client)
+
+	// ExecHook is called after each Slurm binary execution with the binary name,
+	// execution duration and resulting error, if any.
+	ExecHook func(bin string, took time.Duration, err error)
 
 	// JobInfo contains information about a Slurm job.
 	JobInfo struct {
-		ID         string         `json:"id" slurm:"JobId"`
-		UserID     string         `json:"user_id" slurm:"UserId"`
-		ArrayJobID string         `json:"array_job_id" slurm:"ArrayJobId"`
-		Name       string         `json:"name" slurm:"JobName"`
-		ExitCode   string         `json:"exit_code" slurm:"ExitCode"`
-		State      string         `json:"state" slurm:"JobState"`
-		SubmitTime *time.Time     `json:"submit_time" slurm:"SubmitTime"`
-		StartTime  *time.Time     `json:"start_time" slurm:"StartTime"`
-		RunTime    *time.Duration `json:"run_time" slurm:"RunTime"`
-		TimeLimit  *time.Duration `json:"time_limit" slurm:"TimeLimit"`
-		WorkDir    string         `json:"work_dir" slurm:"WorkDir"`
-		StdOut     string         `json:"std_out" slurm:"StdOut"`
-		StdErr     string         `json:"std_err" slurm:"StdErr"`
-		Partition  string         `json:"partition" slurm:"Partition"`
-		NodeList   string         `json:"node_list" slurm:"NodeList"`
-		BatchHost  string         `json:"batch_host" slurm:"BatchHost"`
-		NumNodes   string         `json:"num_nodes" slurm:"NumNodes"`
+		ID          string         `json:"id" slurm:"JobId"`
+		UserID      string         `json:"user_id" slurm:"UserId"`
+		ArrayJobID  string         `json:"array_job_id" slurm:"ArrayJobId"`
+		ArrayTaskID string         `json:"array_task_id" slurm:"ArrayTaskId"`
+		Name        string         `json:"name" slurm:"JobName"`
+		ExitCode    string         `json:"exit_code" slurm:"ExitCode"`
+		State       string         `json:"state" slurm:"JobState"`
+		SubmitTime  *time.Time     `json:"submit_time" slurm:"SubmitTime"`
+		StartTime   *time.Time     `json:"start_time" slurm:"StartTime"`
+		RunTime     *time.Duration `json:"run_time" slurm:"RunTime"`
+		TimeLimit   *time.Duration `json:"time_limit" slurm:"TimeLimit"`
+		WorkDir     string         `json:"work_dir" slurm:"WorkDir"`
+		StdOut      string         `json:"std_out" slurm:"StdOut"`
+		StdErr      string         `json:"std_err" slurm:"StdErr"`
+		Partition   string         `json:"partition" slurm:"Partition"`
+		NodeList    string         `json:"node_list" slurm:"NodeList"`
+		BatchHost   string         `json:"batch_host" slurm:"BatchHost"`
+		NumNodes    string         `json:"num_nodes" slurm:"NumNodes"`
+		Comment     string         `json:"comment" slurm:"Comment"`
 	}
 
 	// JobStepInfo contains information about a single Slurm job step.
@@ -90,8 +113,39 @@ type (
 		State      string     `json:"state"`
 	}
 
-	// Feature represents a single feature enabled on a Slurm partition.
-	// TODO use it.
+	// JobStepUsage contains resources usage of a single Slurm job step
+	// as reported by accounting. Sizes are in bytes, energy is in joules.
+	JobStepUsage struct {
+		ID             string            `json:"id"`
+		Elapsed        time.Duration     `json:"elapsed"`
+		CPUTime        time.Duration     `json:"cpu_time"`
+		MaxRSS         int64             `json:"max_rss"`
+		AveRSS         int64             `json:"ave_rss"`
+		MaxDiskRead    int64             `json:"max_disk_read"`
+		MaxDiskWrite   int64             `json:"max_disk_write"`
+		ConsumedEnergy int64             `json:"consumed_energy"`
+		AllocTRES      map[string]string `json:"alloc_tres"`
+	}
+
+	// NodeInfo contains state and resources of a Slurm compute node.
+	// Memory is in MBs.
+	NodeInfo struct {
+		Name        string    `json:"name"`
+		State       string    `json:"state"`
+		CPUs        int64     `json:"cpus"`
+		AllocCPUs   int64     `json:"alloc_cpus"`
+		RealMemory  int64     `json:"real_memory"`
+		AllocMemory int64     `json:"alloc_memory"`
+		Features    []string  `json:"features"`
+		Gres        []Feature `json:"gres"`
+		Reason      string    `json:"reason"`
+		Partitions  []string  `json:"partitions"`
+	}
+
+	// Feature represents a single feature enabled on a Slurm partition. It may be
+	// a node feature, in which case Quantity is the number of nodes having it,
+	// a generic resource named with GRESFeaturePrefix and its type as Version,
+	// or a cluster license.
 	Feature struct {
 		Name     string
 		Version  string
@@ -106,10 +160,24 @@ type (
 		WallTime   time.Duration
 		Features   []Feature
 	}
+
+	// PartitionStatus contains a Slurm partition state and its jobs counts.
+	PartitionStatus struct {
+		State       string
+		PendingJobs int64
+		RunningJobs int64
+	}
 )
 
+// WithExecHook sets a hook that will be called after each Slurm binary execution.
+func WithExecHook(h ExecHook) ClientOption {
+	return func(c *Client) {
+		c.execHook = h
+	}
+}
+
 // NewClient returns new local client.
-func NewClient() (*Client, error) {
+func NewClient(opts ...ClientOption) (*Client, error) {
 	var missing []string
 	for _, bin := range []string{
 		sacctBinaryName,
@@ -117,6 +185,7 @@ func NewClient() (*Client, error) {
 		scancelBinaryName,
 		scontrolBinaryName,
 		sinfoBinaryName,
+		squeueBinaryName,
 	} {
 		_, err := exec.LookPath(bin)
 		if err != nil {
@@ -126,19 +195,28 @@ func NewClient() (*Client, error) {
 	if len(missing) != 0 {
 		return nil, errors.Errorf("no slurm binaries found: %s", strings.Join(missing, ", "))
 	}
-	return &Client{}, nil
+
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // SBatch submits batch job and returns job id if succeeded.
-func (*Client) SBatch(script, partition string) (int64, error) {
-	var partitionOpt string
+// Non empty comment is attached to the job and can be used to find it later.
+func (c *Client) SBatch(script, partition, jobComment string) (int64, error) {
+	args := []string{"--parsable"}
 	if partition != "" {
-		partitionOpt = "--partition=" + partition
+		args = append(args, "--partition="+partition)
 	}
-	cmd := exec.Command(sbatchBinaryName, "--parsable", partitionOpt)
+	if jobComment != "" {
+		args = append(args, "--comment="+jobComment)
+	}
+	cmd := exec.Command(sbatchBinaryName, args...)
 	cmd.Stdin = bytes.NewBufferString(script)
 
-	out, err := cmd.CombinedOutput()
+	out, err := c.combinedOutput(cmd)
 	if err != nil {
 		if out != nil {
 			log.Println(string(out))
@@ -155,10 +233,10 @@ func (*Client) SBatch(script, partition string) (int64, error) {
 }
 
 // SCancel cancels batch job.
-func (*Client) SCancel(jobID int64) error {
+func (c *Client) SCancel(jobID int64) error {
 	cmd := exec.Command(scancelBinaryName, strconv.FormatInt(jobID, 10))
 
-	out, err := cmd.CombinedOutput()
+	out, err := c.combinedOutput(cmd)
 	if err != nil && out != nil {
 		log.Println(string(out))
 	}
@@ -174,10 +252,10 @@ func (*Client) Open(path string) (io.ReadCloser, error) {
 	return file, errors.Wrapf(err, "could not open %s", path)
 }
 
-// Tail opens arbitrary file at path in a read-only mode.
+// Tail opens arbitrary file at path in a read-only mode starting from offset.
 // Unlike Open, Tail will watch file changes in a real-time.
-func (*Client) Tail(path string) (io.ReadCloser, error) {
-	tr, err := tail.NewReader(path)
+func (*Client) Tail(path string, offset int64) (*tail.Reader, error) {
+	tr, err := tail.NewReader(path, offset)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create tail reader")
 	}
@@ -186,10 +264,10 @@ func (*Client) Tail(path string) (io.ReadCloser, error) {
 }
 
 // SJobInfo returns information about a particular slurm job by ID.
-func (*Client) SJobInfo(jobID int64) ([]*JobInfo, error) {
+func (c *Client) SJobInfo(jobID int64) ([]*JobInfo, error) {
 	cmd := exec.Command(scontrolBinaryName, "show", "jobid", strconv.FormatInt(jobID, 10))
 
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get info for jobid: %d", jobID)
 	}
@@ -202,8 +280,110 @@ func (*Client) SJobInfo(jobID int64) ([]*JobInfo, error) {
 	return ji, nil
 }
 
+// SJobsInfo returns information about all slurm jobs known to the controller,
+// i.e. active and recently finished ones.
+func (c *Client) SJobsInfo() ([]*JobInfo, error) {
+	cmd := exec.Command(scontrolBinaryName, "show", "job")
+
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get jobs info")
+	}
+
+	ji, err := jobInfoFromScontrolResponse(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse scontrol response")
+	}
+
+	// scontrol prints a message instead of jobs when there are none
+	infos := ji[:0]
+	for _, info := range ji {
+		if info.ID != "" {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// SQueue returns brief information about all active jobs, i.e. pending,
+// running and completing ones. Only ID, name, user, state, partition,
+// submit time and comment are set.
+func (c *Client) SQueue() ([]*JobInfo, error) {
+	return c.squeue()
+}
+
+// squeue returns information about active jobs matching squeue filter arguments.
+func (c *Client) squeue(filter ...string) ([]*JobInfo, error) {
+	args := append([]string{"-h", "-a"}, filter...)
+	cmd := exec.Command(squeueBinaryName, append(args, "-o", "%A|%T|%P|%u|%V|%k|%j")...)
+
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute squeue")
+	}
+
+	ji, err := parseJobList(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse squeue response")
+	}
+	return ji, nil
+}
+
+// SAcctJobs returns brief information about all jobs from accounting, including
+// finished ones, submitted after since. If since is zero, sacct default is used,
+// which is the midnight of the current day. Only ID, name, user, state, partition,
+// submit time and comment are set.
+func (c *Client) SAcctJobs(since time.Time) ([]*JobInfo, error) {
+	args := []string{"-n", "-P", "-X", "-a", "-o", "jobidraw,state,partition,user,submit,comment,jobname"}
+	if !since.IsZero() {
+		args = append(args, "-S", formatTime(since))
+	}
+	cmd := exec.Command(sacctBinaryName, args...)
+
+	out, err := c.output(cmd)
+	if err != nil {
+		ee, ok := err.(*exec.ExitError)
+		if ok {
+			return nil, errors.Wrapf(err, "failed to execute sacct: %s", ee.Stderr)
+		}
+		return nil, errors.Wrap(err, "failed to execute sacct")
+	}
+
+	ji, err := parseJobList(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidSacctResponse.Error())
+	}
+	return ji, nil
+}
+
+// SAcctJobInfo returns brief information about a job from accounting, which
+// is useful when job is no longer known to slurm controller. Only ID, array IDs,
+// name, user, state and working directory are set.
+func (c *Client) SAcctJobInfo(jobID int64) (*JobInfo, error) {
+	cmd := exec.Command(sacctBinaryName,
+		"-n", "-P", "-X",
+		"-j", strconv.FormatInt(jobID, 10),
+		"-o", "jobidraw,jobid,state,user,workdir,jobname",
+	)
+
+	out, err := c.output(cmd)
+	if err != nil {
+		ee, ok := err.(*exec.ExitError)
+		if ok {
+			return nil, errors.Wrapf(err, "failed to execute sacct: %s", ee.Stderr)
+		}
+		return nil, errors.Wrap(err, "failed to execute sacct")
+	}
+
+	ji, err := parseSacctJobInfo(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidSacctResponse.Error())
+	}
+	return ji, nil
+}
+
 // SJobSteps returns information about a submitted batch job.
-func (*Client) SJobSteps(jobID int64) ([]*JobStepInfo, error) {
+func (c *Client) SJobSteps(jobID int64) ([]*JobStepInfo, error) {
 	cmd := exec.Command(sacctBinaryName,
 		"-p",
 		"-n",
@@ -212,7 +392,7 @@ func (*Client) SJobSteps(jobID int64) ([]*JobStepInfo, error) {
 		"-o start,end,exitcode,state,jobid,jobname",
 	)
 
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		ee, ok := err.(*exec.ExitError)
 		if ok {
@@ -229,10 +409,38 @@ func (*Client) SJobSteps(jobID int64) ([]*JobStepInfo, error) {
 	return jInfo, nil
 }
 
+// SJobUsage returns resources usage of a job and each of its steps. The first
+// element describes the whole job allocation.
+func (c *Client) SJobUsage(jobID int64) ([]*JobStepUsage, error) {
+	cmd := exec.Command(sacctBinaryName,
+		"-P",
+		"-n",
+		"-j",
+		strconv.FormatInt(jobID, 10),
+		"-o", "jobid,elapsedraw,totalcpu,maxrss,averss,maxdiskread,maxdiskwrite,consumedenergyraw,alloctres",
+	)
+
+	out, err := c.output(cmd)
+	if err != nil {
+		ee, ok := err.(*exec.ExitError)
+		if ok {
+			return nil, errors.Wrapf(err, "failed to execute sacct: %s", ee.Stderr)
+		}
+		return nil, errors.Wrap(err, "failed to execute sacct")
+	}
+
+	usage, err := parseSacctUsage(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidSacctResponse.Error())
+	}
+
+	return usage, nil
+}
+
 // Resources returns available resources for a partition.
-func (*Client) Resources(partition string) (*Resources, error) {
+func (c *Client) Resources(partition string) (*Resources, error) {
 	cmd := exec.Command(scontrolBinaryName, "show", "partition", partition)
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get partition info")
 	}
@@ -242,23 +450,150 @@ func (*Client) Resources(partition string) (*Resources, error) {
 		return nil, errors.Wrap(err, "could not parse partition resources")
 	}
 
+	cmd = exec.Command(sinfoBinaryName, "-h", "-N", "-p", partition, "-o", "%N|%f|%G")
+	out, err = c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get partition nodes info")
+	}
+	features, err := parseNodeFeatures(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse partition nodes features")
+	}
+
+	r.Features = features
 	return r, nil
 }
 
+// Licenses returns cluster licenses. Licenses are shared by all partitions,
+// so they are not a part of partition resources.
+func (c *Client) Licenses() ([]Feature, error) {
+	cmd := exec.Command(scontrolBinaryName, "show", "licenses", "-o")
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get licenses info")
+	}
+	licenses, err := parseLicenses(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse licenses")
+	}
+	return licenses, nil
+}
+
 // Partitions returns a list of partition names.
-func (*Client) Partitions() ([]string, error) {
+func (c *Client) Partitions() ([]string, error) {
 	cmd := exec.Command(scontrolBinaryName, "show", "partition")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get partition info")
 	}
 	return parsePartitionsNames(string(out)), nil
 }
 
+// PartitionStatus returns partition state along with numbers of its pending and running jobs.
+func (c *Client) PartitionStatus(partition string) (*PartitionStatus, error) {
+	statuses, err := c.PartitionsStatus([]string{partition})
+	if err != nil {
+		return nil, err
+	}
+	return statuses[partition], nil
+}
+
+// PartitionsStatus returns state along with numbers of pending and running jobs of each
+// of the partitions. Partitions info and their jobs are fetched with a single scontrol
+// and squeue call respectively, no matter how many partitions are requested. Pending
+// jobs submitted to several partitions are accounted in each of them.
+func (c *Client) PartitionsStatus(partitions []string) (map[string]*PartitionStatus, error) {
+	cmd := exec.Command(scontrolBinaryName, "show", "partition")
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get partition info")
+	}
+	states, err := parsePartitionStates(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse partition state")
+	}
+
+	statuses := make(map[string]*PartitionStatus, len(partitions))
+	for _, p := range partitions {
+		state, ok := states[p]
+		if !ok {
+			return nil, errors.Errorf("partition %s is not found", p)
+		}
+		statuses[p] = &PartitionStatus{State: state}
+	}
+	if len(partitions) == 0 {
+		return statuses, nil
+	}
+
+	jobs, err := c.squeue("-t", "PENDING,RUNNING", "-p", strings.Join(partitions, ","))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get partition jobs")
+	}
+	for _, j := range jobs {
+		for _, p := range strings.Split(j.Partition, ",") {
+			status, ok := statuses[p]
+			if !ok {
+				continue
+			}
+			switch j.State {
+			case "PENDING":
+				status.PendingJobs++
+			case "RUNNING":
+				status.RunningJobs++
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// Nodes returns information about compute nodes of a partition.
+// If partition is empty all nodes are returned.
+func (c *Client) Nodes(partition string) ([]*NodeInfo, error) {
+	cmd := exec.Command(scontrolBinaryName, "show", "node", "-o")
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get nodes info")
+	}
+
+	nodes, err := parseNodes(string(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse nodes info")
+	}
+	if partition == "" {
+		return nodes, nil
+	}
+
+	var res []*NodeInfo
+	for _, n := range nodes {
+		for _, p := range n.Partitions {
+			if p == partition {
+				res = append(res, n)
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// Ping checks whether Slurm controller is reachable with 'scontrol ping'.
+// If there are backup controllers configured, it is enough for one of them to be up.
+func (c *Client) Ping() error {
+	cmd := exec.Command(scontrolBinaryName, "ping")
+	out, err := c.output(cmd)
+	if len(out) == 0 && err != nil {
+		return errors.Wrap(err, "could not ping slurm controller")
+	}
+
+	if !isControllerUp(string(out)) {
+		return ErrControllerIsDown
+	}
+	return nil
+}
+
 // Version returns slurm version
-func (*Client) Version() (string, error) {
+func (c *Client) Version() (string, error) {
 	cmd := exec.Command(sinfoBinaryName, "-V")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", errors.Wrap(err, "could not get slurm info")
 	}
@@ -271,6 +606,29 @@ func (*Client) Version() (string, error) {
 	return s[1], nil
 }
 
+// output runs cmd and returns its standard output. Execution is reported to the exec hook.
+func (c *Client) output(cmd *exec.Cmd) ([]byte, error) {
+	start := time.Now()
+	out, err := cmd.Output()
+	c.observe(cmd, time.Since(start), err)
+	return out, err
+}
+
+// combinedOutput runs cmd and returns its combined standard output and standard error.
+// Execution is reported to the exec hook.
+func (c *Client) combinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	c.observe(cmd, time.Since(start), err)
+	return out, err
+}
+
+func (c *Client) observe(cmd *exec.Cmd, took time.Duration, err error) {
+	if c.execHook != nil {
+		c.execHook(cmd.Args[0], took, err)
+	}
+}
+
 func jobInfoFromScontrolResponse(jobInfo string) ([]*JobInfo, error) {
 	jobInfo = strings.TrimSpace(jobInfo)
 	rawInfos := strings.Split(jobInfo, "\n\n")
@@ -328,6 +686,11 @@ func (ji *JobInfo) fillFromSlurmFields(fields map[string]string) error {
 				return errors.Wrapf(err, "could not parse duration: %s", sField)
 			}
 			val = reflect.ValueOf(d)
+		case comment:
+			if sField == nullValue {
+				continue
+			}
+			val = reflect.ValueOf(sField)
 		default:
 			val = reflect.ValueOf(sField)
 		}
@@ -159,8 +159,8 @@ JobId=196 ArrayJobId=192 ArrayTaskId=4 JobName=sbatch
 )
 
 var (
-	testSubmitTime  = time.Date(2019, 04, 16, 11, 49, 19, 0, time.UTC)
-	testStartTime   = time.Date(2019, 04, 16, 11, 49, 20, 0, time.UTC)
+	testSubmitTime  = time.Date(2019, 04, 16, 11, 49, 19, 0, time.Local)
+	testStartTime   = time.Date(2019, 04, 16, 11, 49, 20, 0, time.Local)
 	testRunTime     = 30 * time.Second
 	testLimitTime   = 25 * time.Hour
 	testZeroRunTime = time.Duration(0)
@@ -227,42 +227,44 @@ func TestJobInfoFromScontrolResponse(t *testing.T) {
 			in:   testJobArrayScontrolResponse,
 			want: []*JobInfo{
 				{
-					ID:         "192",
-					UserID:     "vagrant(1000)",
-					Name:       "sbatch",
-					ExitCode:   "0:0",
-					State:      "PENDING",
-					SubmitTime: &testSubmitTime,
-					StartTime:  &testStartTime,
-					RunTime:    &testRunTime,
-					TimeLimit:  &testLimitTime,
-					WorkDir:    "/home/vagrant",
-					StdOut:     "/home/vagrant/slurm-192_4294967294.out",
-					StdErr:     "/home/vagrant/slurm-192_4294967294.out",
-					Partition:  "debug",
-					NodeList:   "(null)",
-					BatchHost:  "",
-					NumNodes:   "1-1",
-					ArrayJobID: "192",
+					ID:          "192",
+					UserID:      "vagrant(1000)",
+					Name:        "sbatch",
+					ExitCode:    "0:0",
+					State:       "PENDING",
+					SubmitTime:  &testSubmitTime,
+					StartTime:   &testStartTime,
+					RunTime:     &testRunTime,
+					TimeLimit:   &testLimitTime,
+					WorkDir:     "/home/vagrant",
+					StdOut:      "/home/vagrant/slurm-192_4294967294.out",
+					StdErr:      "/home/vagrant/slurm-192_4294967294.out",
+					Partition:   "debug",
+					NodeList:    "(null)",
+					BatchHost:   "",
+					NumNodes:    "1-1",
+					ArrayJobID:  "192",
+					ArrayTaskID: "5-8",
 				},
 				{
-					ID:         "196",
-					UserID:     "vagrant(1000)",
-					Name:       "sbatch",
-					ExitCode:   "0:0",
-					State:      "RUNNING",
-					SubmitTime: &testSubmitTime,
-					StartTime:  &testStartTime,
-					RunTime:    &testRunTime,
-					TimeLimit:  &testLimitTime,
-					WorkDir:    "/home/vagrant",
-					StdOut:     "/home/vagrant/slurm-192_4.out",
-					StdErr:     "/home/vagrant/slurm-192_4.out",
-					Partition:  "debug",
-					NodeList:   "vagrant",
-					BatchHost:  "vagrant",
-					NumNodes:   "1",
-					ArrayJobID: "192",
+					ID:          "196",
+					UserID:      "vagrant(1000)",
+					Name:        "sbatch",
+					ExitCode:    "0:0",
+					State:       "RUNNING",
+					SubmitTime:  &testSubmitTime,
+					StartTime:   &testStartTime,
+					RunTime:     &testRunTime,
+					TimeLimit:   &testLimitTime,
+					WorkDir:     "/home/vagrant",
+					StdOut:      "/home/vagrant/slurm-192_4.out",
+					StdErr:      "/home/vagrant/slurm-192_4.out",
+					Partition:   "debug",
+					NodeList:    "vagrant",
+					BatchHost:   "vagrant",
+					NumNodes:    "1",
+					ArrayJobID:  "192",
+					ArrayTaskID: "4",
 				},
 			},
 		},
@@ -0,0 +1,365 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tail
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// subscriberChunks is how many chunks may be queued for a subscriber
+// before it's considered too slow and is disconnected.
+const subscriberChunks = 64
+
+// ErrSlowConsumer is returned by Subscriber when it doesn't keep up with the file
+// growth and its queue is overflowed. Output is not complete after that.
+var ErrSlowConsumer = errors.New("subscriber is too slow to keep up with file output")
+
+// Hub shares one Reader per file among all its subscribers. Reader is created
+// on the first subscription and is closed once the last subscriber is closed.
+type Hub struct {
+	mu      sync.Mutex
+	tailers map[string]*tailer
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{tailers: make(map[string]*tailer)}
+}
+
+// Subscribe starts following file at path from offset. Content that existed before
+// the shared Reader is started or before subscription is read from the file directly.
+// Subscriber must be closed once it's not needed.
+func (h *Hub) Subscribe(path string, offset int64) (*Subscriber, error) {
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	path = filepath.Clean(path)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	t, ok := h.tailers[path]
+	if !ok {
+		// shared reader follows only new content, so that it's limited by the
+		// file growth rate, while subscribers read existing content at their pace
+		var size int64
+		if info, err := os.Stat(path); err == nil {
+			size = info.Size()
+		}
+		r, err := NewReader(path, size)
+		if err != nil {
+			return nil, err
+		}
+		t = &tailer{
+			hub:  h,
+			path: path,
+			r:    r,
+			pos:  size,
+			subs: make(map[*Subscriber]struct{}),
+		}
+		h.tailers[path] = t
+		go t.run()
+	}
+
+	s := &Subscriber{
+		t:      t,
+		path:   path,
+		chunks: make(chan chunk, subscriberChunks),
+		ready:  make(chan struct{}, 1),
+		cursor: offset,
+		stopAt: -1,
+	}
+	if err := t.subscribe(s); err != nil {
+		if t.refs == 0 {
+			t.close()
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// Len returns number of followed files.
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.tailers)
+}
+
+// tailer reads file with a shared Reader and sends content to subscribers.
+type tailer struct {
+	hub  *Hub
+	path string
+	r    *Reader
+
+	// refs is accessed under hub lock.
+	refs int
+
+	mu   sync.Mutex
+	pos  int64 // end offset of the last sent chunk
+	subs map[*Subscriber]struct{}
+	err  error
+}
+
+// subscribe adds s to subscribers. It's called under hub lock.
+func (t *tailer) subscribe(s *Subscriber) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+	s.last = t.pos
+	if s.cursor < t.pos {
+		f, err := os.Open(t.path)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "could not open %s", t.path)
+		}
+		if f != nil {
+			s.history = f
+			s.historyR = io.NewSectionReader(f, s.cursor, t.pos-s.cursor)
+		}
+	}
+	t.subs[s] = struct{}{}
+	t.refs++
+	return nil
+}
+
+// close stops reading file. It's called under hub lock.
+func (t *tailer) close() {
+	if t.hub.tailers[t.path] == t {
+		delete(t.hub.tailers, t.path)
+	}
+	_ = t.r.Close()
+}
+
+func (t *tailer) run() {
+	for {
+		buf := make([]byte, chunkSize)
+		n, err := t.r.Read(buf)
+		if n > 0 {
+			// Read returns data of a single chunk, so
+			// the chunk starts right before the offset
+			t.send(chunk{data: buf[:n], offset: t.r.Offset() - int64(n)})
+			continue
+		}
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		<-t.r.Ready()
+	}
+}
+
+// send queues chunk to all subscribers disconnecting the slow ones.
+func (t *tailer) send(c chunk) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pos = c.offset + int64(len(c.data))
+	for s := range t.subs {
+		select {
+		case s.chunks <- c:
+			s.notify()
+		default:
+			t.disconnect(s, ErrSlowConsumer)
+		}
+	}
+}
+
+// fail disconnects all subscribers with err. New subscriptions will start a new tailer.
+func (t *tailer) fail(err error) {
+	t.hub.mu.Lock()
+	if t.hub.tailers[t.path] == t {
+		delete(t.hub.tailers, t.path)
+	}
+	t.hub.mu.Unlock()
+
+	if err == io.EOF {
+		// reader is closed when there are no subscribers left
+		err = errors.New("tail is stopped")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.err = err
+	for s := range t.subs {
+		t.disconnect(s, err)
+	}
+}
+
+// disconnect stops sending chunks to s. It's called under tailer lock.
+func (t *tailer) disconnect(s *Subscriber, err error) {
+	s.err = err
+	close(s.chunks)
+	delete(t.subs, s)
+	s.notify()
+}
+
+// unsubscribe removes s from tailer and closes tailer when s is the last subscriber.
+func (t *tailer) unsubscribe(s *Subscriber) {
+	t.hub.mu.Lock()
+	defer t.hub.mu.Unlock()
+
+	t.mu.Lock()
+	delete(t.subs, s)
+	t.mu.Unlock()
+
+	t.refs--
+	if t.refs == 0 {
+		t.close()
+	}
+}
+
+// Subscriber reads file shared by Hub with its own cursor. Like Reader, it never blocks
+// and returns no data and nil error when there is nothing new in the file, Ready channel
+// should be waited on before the next Read.
+type Subscriber struct {
+	t      *tailer
+	path   string
+	chunks chan chunk
+	ready  chan struct{}
+	err    error // set before chunks is closed
+
+	closeOnce sync.Once
+
+	mu       sync.Mutex
+	history  *os.File
+	historyR io.Reader
+	pending  []byte
+	cursor   int64 // offset of the next byte returned by Read
+	last     int64 // end offset of the last received chunk
+	stopAt   int64 // offset to return EOF at, -1 when not stopped
+}
+
+// Read reads followed file content. It returns 0 and nil error when no data is available yet.
+// When subscriber is disconnected the rest of queued data is returned followed by the error.
+func (s *Subscriber) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.historyR != nil {
+		n, err := s.historyR.Read(p)
+		s.cursor += int64(n)
+		if err == io.EOF {
+			s.closeHistory()
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, errors.Wrapf(err, "could not read %s", s.path)
+		}
+	}
+
+	for len(s.pending) == 0 {
+		if s.stopAt >= 0 && s.cursor >= s.stopAt {
+			return 0, io.EOF
+		}
+		select {
+		case c, ok := <-s.chunks:
+			if !ok {
+				return 0, s.err
+			}
+			s.accept(c)
+		default:
+			return 0, nil
+		}
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	s.cursor += int64(n)
+	return n, nil
+}
+
+// accept makes part of chunk after cursor pending.
+func (s *Subscriber) accept(c chunk) {
+	if c.offset != s.last {
+		// file is truncated or replaced, start from the beginning
+		s.cursor = c.offset
+		if s.stopAt >= 0 {
+			s.stopAt = 0
+		}
+	}
+	s.last = c.offset + int64(len(c.data))
+
+	skip := s.cursor - c.offset
+	if skip < 0 {
+		// history is shorter than expected
+		skip = 0
+		s.cursor = c.offset
+	}
+	if skip >= int64(len(c.data)) {
+		s.pending = nil
+		return
+	}
+	s.pending = c.data[skip:]
+}
+
+// Ready returns a channel that receives a value when new data may be read
+// or subscriber is disconnected. It's meant to wait after Read returns no data.
+func (s *Subscriber) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Offset returns offset in the currently followed file of the next byte returned by Read.
+func (s *Subscriber) Offset() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor
+}
+
+// StopAtEOF makes Read return io.EOF once file is read up to its current size.
+// Other subscribers of the same file are not affected.
+func (s *Subscriber) StopAtEOF() {
+	var size int64
+	info, err := os.Stat(s.path)
+	if err == nil {
+		size = info.Size()
+	}
+
+	s.mu.Lock()
+	s.stopAt = size
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Close unsubscribes from file updates. File is not followed anymore
+// when there are no subscribers left.
+func (s *Subscriber) Close() error {
+	s.closeOnce.Do(func() {
+		s.t.unsubscribe(s)
+		s.mu.Lock()
+		s.closeHistory()
+		s.mu.Unlock()
+	})
+	return nil
+}
+
+func (s *Subscriber) closeHistory() {
+	if s.history != nil {
+		_ = s.history.Close()
+	}
+	s.history, s.historyR = nil, nil
+}
+
+func (s *Subscriber) notify() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tail
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_Subscribe(t *testing.T) {
+	path, cleanup := tempFile(t)
+	defer cleanup()
+
+	appendFile(t, path, "one\n")
+	h := NewHub()
+	s1, err := h.Subscribe(path, 0)
+	require.NoError(t, err)
+	data, err := readN(t, s1, 4)
+	require.NoError(t, err)
+	require.Equal(t, "one\n", string(data))
+
+	appendFile(t, path, "two\n")
+	data, err = readN(t, s1, 4)
+	require.NoError(t, err)
+	require.Equal(t, "two\n", string(data))
+
+	// late subscribers read what's already sent from file
+	s2, err := h.Subscribe(path, 0)
+	require.NoError(t, err)
+	s3, err := h.Subscribe(path, 6)
+	require.NoError(t, err)
+	s4, err := h.Subscribe(path, 12)
+	require.NoError(t, err)
+	require.Equal(t, 1, h.Len())
+
+	appendFile(t, path, "three\n")
+	for _, tc := range []struct {
+		s      *Subscriber
+		expect string
+	}{
+		{s: s1, expect: "three\n"},
+		{s: s2, expect: "one\ntwo\nthree\n"},
+		{s: s3, expect: "o\nthree\n"},
+		{s: s4, expect: "e\n"},
+	} {
+		data, err = readN(t, tc.s, len(tc.expect))
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, string(data))
+	}
+	require.EqualValues(t, 14, s2.Offset())
+
+	// stop affects only one subscriber
+	s2.StopAtEOF()
+	data, err = readN(t, s2, 1)
+	require.Equal(t, io.EOF, err)
+	require.Empty(t, data)
+
+	appendFile(t, path, "four\n")
+	data, err = readN(t, s1, 5)
+	require.NoError(t, err)
+	require.Equal(t, "four\n", string(data))
+	data, err = readN(t, s4, 5)
+	require.NoError(t, err)
+	require.Equal(t, "four\n", string(data))
+
+	for _, s := range []*Subscriber{s1, s2, s3} {
+		require.NoError(t, s.Close())
+		require.Equal(t, 1, h.Len())
+	}
+	require.NoError(t, s4.Close())
+	require.Equal(t, 0, h.Len())
+}
+
+func TestHub_SlowConsumer(t *testing.T) {
+	path, cleanup := tempFile(t)
+	defer cleanup()
+
+	h := NewHub()
+	slow, err := h.Subscribe(path, 0)
+	require.NoError(t, err)
+	defer slow.Close()
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), chunkSize*subscriberChunks*2/16)
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+
+	data, err := readN(t, slow, len(content))
+	require.Equal(t, ErrSlowConsumer, err)
+	require.True(t, len(data) < len(content))
+	require.Equal(t, content[:len(data)], data)
+
+	// other subscribers are not affected
+	s, err := h.Subscribe(path, 0)
+	require.NoError(t, err)
+	defer s.Close()
+	appendFile(t, path, "end")
+	data, err = readN(t, s, len(content)+3)
+	require.NoError(t, err)
+	require.Equal(t, append(content, "end"...), data)
+}
@@ -15,79 +15,308 @@
 package tail
 
 import (
-	"bytes"
 	"io"
-	"log"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
-	"github.com/hpcloud/tail"
+	"github.com/pkg/errors"
 )
 
+const (
+	// chunkSize is a maximum size of data read from file at once.
+	chunkSize = 32 << 10
+
+	// bufferChunks is how many chunks may be read ahead. When buffer is full
+	// file is not read until Read is called.
+	bufferChunks = 16
+
+	// pollInterval is how often file is checked regardless of inotify events, since
+	// changes made on other hosts of a network file system don't trigger them. It's
+	// also the only way to follow file when inotify can't be used.
+	pollInterval = time.Second
+)
+
+// errClosed is returned internally when reader is closed while sending data.
+var errClosed = errors.New("reader is closed")
+
+type chunk struct {
+	data   []byte
+	offset int64
+}
+
+// Reader follows a file as it grows, like tail -F does. File is read as is, without
+// splitting it into lines. When file is truncated it's read from the beginning,
+// when file is replaced, e.g. rotated, the rest of the old file is read before
+// switching to the new one. File may not exist when Reader is created, in that case
+// reading starts once it appears. All readers share a single inotify instance.
+//
+// Read never blocks: it returns no data and nil error when there is nothing new in
+// the file, callers should wait on Ready channel before the next Read. Read returns
+// io.EOF only after StopAtEOF or Close is called and all the data is consumed.
 type Reader struct {
-	t        *tail.Tail
-	isClosed bool
+	path    string
+	events  <-chan struct{}
+	unwatch func()
+
+	chunks    chan chunk
+	ready     chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	finished  chan struct{}
+	stopOnce  sync.Once
+	closeOnce sync.Once
+	err       error // set before finished is closed
 
-	mu   sync.Mutex
-	buff *bytes.Buffer
+	mu      sync.Mutex
+	pending []byte
+	offset  int64
 }
 
-func NewReader(path string) (*Reader, error) {
-	t, err := tail.TailFile(path, tail.Config{Follow: true, ReOpen: true})
-	if err != nil {
-		return nil, err
+// NewReader starts following file at path from offset. If file is shorter than
+// offset it's considered truncated and is read from the beginning.
+func NewReader(path string, offset int64) (*Reader, error) {
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
 	}
 
+	path = filepath.Clean(path)
+	events, unwatch := watcher.watch(path)
 	tr := &Reader{
-		t:    t,
-		buff: &bytes.Buffer{},
+		path:     path,
+		events:   events,
+		unwatch:  unwatch,
+		chunks:   make(chan chunk, bufferChunks),
+		ready:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+		offset:   offset,
 	}
-
-	go tr.readTail()
-
+	// file is opened right away, so that it's followed even
+	// if it's replaced before the first read
+	f := &follower{offset: offset}
+	if err := tr.reopen(f); err != nil {
+		unwatch()
+		return nil, err
+	}
+	go tr.run(f)
 	return tr, nil
 }
 
-// Read returns EOF error only after invoking Close.
-// Before close in case of EOF errors it will be returning nil.
+// Read reads followed file content. It returns 0 and nil error when no data is available yet.
 func (tr *Reader) Read(p []byte) (int, error) {
 	tr.mu.Lock()
-	n, err := io.ReadFull(tr.buff, p)
-	tr.mu.Unlock()
-	if (err == io.EOF || err == io.ErrUnexpectedEOF) && !tr.isClosed {
-		return n, nil
+	defer tr.mu.Unlock()
+
+	if len(tr.pending) == 0 && !tr.next() {
+		select {
+		case <-tr.finished:
+			// all chunks are sent before reader is finished
+			if !tr.next() {
+				return 0, tr.err
+			}
+		default:
+			return 0, nil
+		}
 	}
 
-	return n, err
+	n := copy(p, tr.pending)
+	tr.pending = tr.pending[n:]
+	tr.offset += int64(n)
+	return n, nil
 }
 
+// next takes the next buffered chunk if there is one.
+func (tr *Reader) next() bool {
+	select {
+	case c := <-tr.chunks:
+		tr.pending = c.data
+		tr.offset = c.offset
+		return true
+	default:
+		return false
+	}
+}
+
+// Ready returns a channel that receives a value when new data may be read
+// or reader is finished. It's meant to wait after Read returns no data.
+func (tr *Reader) Ready() <-chan struct{} {
+	return tr.ready
+}
+
+// Offset returns offset in the currently followed file of the next byte returned by Read.
+// It may be passed to NewReader to resume reading, unless the file is replaced by then.
+func (tr *Reader) Offset() int64 {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.offset
+}
+
+// StopAtEOF stops following file once all its current content is read.
+// Read returns io.EOF after that.
+func (tr *Reader) StopAtEOF() {
+	tr.stopOnce.Do(func() { close(tr.stop) })
+}
+
+// Close stops following file immediately and releases resources.
+// Data that is not read yet may be lost.
 func (tr *Reader) Close() error {
-	_ = tr.t.StopAtEOF() // it returns stop reason instead of err
+	tr.closeOnce.Do(func() { close(tr.done) })
+	<-tr.finished
 	return nil
 }
 
-func (tr *Reader) readTail() {
+func (tr *Reader) notify() {
+	select {
+	case tr.ready <- struct{}{}:
+	default:
+	}
+}
+
+// follower is a state of the followed file, it's accessed by run goroutine only.
+type follower struct {
+	file   *os.File
+	info   os.FileInfo
+	offset int64
+	buf    []byte
+}
+
+func (tr *Reader) run(f *follower) {
 	defer func() {
-		log.Println("Read tail finished")
-		tr.isClosed = true
+		if f.file != nil {
+			_ = f.file.Close()
+		}
+		tr.unwatch()
+		close(tr.finished)
+		tr.notify()
 	}()
 
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	stop := tr.stop
 	for {
-		l, ok := <-tr.t.Lines
-		if !ok {
+		err := tr.sync(f)
+		if err == errClosed || (err == nil && stop == nil) {
+			err = io.EOF
+		}
+		if err != nil {
+			tr.err = err
 			return
 		}
 
-		if l.Err != nil {
-			log.Printf("Tail line err: %s", l.Err)
-			return
+	wait:
+		for {
+			select {
+			case <-tr.done:
+				tr.err = io.EOF
+				return
+			case <-stop:
+				stop = nil
+				break wait
+			case <-poll.C:
+				break wait
+			case <-tr.events:
+				break wait
+			}
 		}
+	}
+}
 
-		tr.mu.Lock()
-		_, err := tr.buff.WriteString(l.Text + "\n")
-		tr.mu.Unlock()
+// sync sends content appended to the followed file since the last sync.
+// It also reopens file when it's created or replaced and rewinds it when
+// it's truncated.
+func (tr *Reader) sync(f *follower) error {
+	for {
+		if f.file != nil {
+			if err := tr.readToEnd(f); err != nil {
+				return err
+			}
+			info, err := f.file.Stat()
+			if err != nil {
+				return errors.Wrapf(err, "could not stat %s", tr.path)
+			}
+			if info.Size() < f.offset {
+				if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+					return errors.Wrapf(err, "could not rewind %s", tr.path)
+				}
+				f.offset = 0
+				continue
+			}
+		}
+
+		info, err := os.Stat(tr.path)
+		if os.IsNotExist(err) {
+			return nil
+		}
 		if err != nil {
-			log.Printf("Could not write to buffer err: %s", err)
-			return
+			return errors.Wrapf(err, "could not stat %s", tr.path)
+		}
+		if f.file != nil && os.SameFile(f.info, info) {
+			return nil
+		}
+		if err := tr.reopen(f); err != nil {
+			return err
+		}
+	}
+}
+
+// reopen switches to the file currently located at path. Offset is
+// preserved only when file is opened for the first time.
+func (tr *Reader) reopen(f *follower) error {
+	file, err := os.Open(tr.path)
+	if os.IsNotExist(err) {
+		// removed again, wait for the next event
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", tr.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "could not stat %s", tr.path)
+	}
+
+	if f.file != nil {
+		_ = f.file.Close()
+		f.offset = 0
+	}
+	if info.Size() < f.offset {
+		f.offset = 0
+	}
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "could not seek %s", tr.path)
+	}
+	f.file, f.info = file, info
+	return nil
+}
+
+// readToEnd sends file content till EOF. It blocks while buffer is full.
+func (tr *Reader) readToEnd(f *follower) error {
+	for {
+		if f.buf == nil {
+			f.buf = make([]byte, chunkSize)
+		}
+		n, err := f.file.Read(f.buf)
+		if n > 0 {
+			select {
+			case tr.chunks <- chunk{data: f.buf[:n], offset: f.offset}:
+			case <-tr.done:
+				return errClosed
+			}
+			f.buf = nil
+			f.offset += int64(n)
+			tr.notify()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", tr.path)
 		}
 	}
 }
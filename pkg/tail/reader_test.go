@@ -0,0 +1,200 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tail
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type readyReader interface {
+	io.Reader
+	Ready() <-chan struct{}
+}
+
+// readN reads from tr until n bytes are read or an error is returned.
+func readN(t *testing.T, tr readyReader, n int) ([]byte, error) {
+	var out bytes.Buffer
+	buf := make([]byte, 7)
+	timeout := time.After(5 * time.Second)
+	for out.Len() < n {
+		m, err := tr.Read(buf)
+		out.Write(buf[:m])
+		if err != nil {
+			return out.Bytes(), err
+		}
+		if m > 0 {
+			continue
+		}
+		select {
+		case <-tr.Ready():
+		case <-timeout:
+			t.Fatalf("timeout waiting for data, got %q", out.String())
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func appendFile(t *testing.T, path, data string) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func tempFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "tail")
+	require.NoError(t, err)
+	return filepath.Join(dir, "slurm-42.out"), func() { os.RemoveAll(dir) }
+}
+
+func TestReader(t *testing.T) {
+	tt := []struct {
+		name   string
+		offset int64
+		before func(t *testing.T, path string)
+		after  func(t *testing.T, path string)
+		expect string
+	}{
+		{
+			name:   "byte exact",
+			before: func(t *testing.T, path string) { appendFile(t, path, "one\r\ntwo\x00") },
+			after:  func(t *testing.T, path string) { appendFile(t, path, "\xff\xfe three") },
+			expect: "one\r\ntwo\x00\xff\xfe three",
+		},
+		{
+			name:   "offset",
+			offset: 4,
+			before: func(t *testing.T, path string) { appendFile(t, path, "one\ntwo\n") },
+			after:  func(t *testing.T, path string) { appendFile(t, path, "three") },
+			expect: "two\nthree",
+		},
+		{
+			name:   "offset after end",
+			offset: 100,
+			before: func(t *testing.T, path string) { appendFile(t, path, "one\n") },
+			after:  func(t *testing.T, path string) {},
+			expect: "one\n",
+		},
+		{
+			name:   "created later",
+			before: func(t *testing.T, path string) {},
+			after:  func(t *testing.T, path string) { appendFile(t, path, "one\n") },
+			expect: "one\n",
+		},
+		{
+			name:   "rotated",
+			before: func(t *testing.T, path string) { appendFile(t, path, "one\n") },
+			after: func(t *testing.T, path string) {
+				require.NoError(t, os.Rename(path, path+".1"))
+				appendFile(t, path+".1", "two\n")
+				appendFile(t, path, "three\n")
+			},
+			expect: "one\ntwo\nthree\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			path, cleanup := tempFile(t)
+			defer cleanup()
+
+			tc.before(t, path)
+			tr, err := NewReader(path, tc.offset)
+			require.NoError(t, err)
+			defer tr.Close()
+
+			tc.after(t, path)
+			data, err := readN(t, tr, len(tc.expect))
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, string(data))
+
+			tr.StopAtEOF()
+			data, err = readN(t, tr, 1)
+			require.Equal(t, io.EOF, err)
+			require.Empty(t, data)
+		})
+	}
+}
+
+func TestReader_Truncated(t *testing.T) {
+	path, cleanup := tempFile(t)
+	defer cleanup()
+
+	appendFile(t, path, "hello world\n")
+	tr, err := NewReader(path, 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	data, err := readN(t, tr, 12)
+	require.NoError(t, err)
+	require.Equal(t, "hello world\n", string(data))
+	require.EqualValues(t, 12, tr.Offset())
+
+	require.NoError(t, os.Truncate(path, 0))
+	appendFile(t, path, "bye\n")
+	data, err = readN(t, tr, 4)
+	require.NoError(t, err)
+	require.Equal(t, "bye\n", string(data))
+	require.EqualValues(t, 4, tr.Offset())
+}
+
+func TestReader_StopAtEOF(t *testing.T) {
+	path, cleanup := tempFile(t)
+	defer cleanup()
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), (chunkSize*bufferChunks*2)/16)
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+	tr, err := NewReader(path, 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	// buffer is filled while reading, the rest must be read after stop
+	tr.StopAtEOF()
+	data, err := readN(t, tr, len(content)+1)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, content, data)
+	require.EqualValues(t, len(content), tr.Offset())
+}
+
+func TestReader_Close(t *testing.T) {
+	path, cleanup := tempFile(t)
+	defer cleanup()
+
+	content := bytes.Repeat([]byte{'x'}, chunkSize*bufferChunks*2)
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+	tr, err := NewReader(path, 0)
+	require.NoError(t, err)
+
+	// reader is blocked on full buffer, close must not wait for it to be read
+	closed := make(chan struct{})
+	go func() {
+		_ = tr.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close is blocked")
+	}
+}
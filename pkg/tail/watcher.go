@@ -0,0 +1,154 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tail
+
+import (
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/fsnotify.v1"
+)
+
+// watcher is shared by all readers, since number of inotify instances
+// per user is limited and is rather low by default.
+var watcher = &dirWatcher{dirs: make(map[string]*watchedDir)}
+
+// dirWatcher watches directories of followed files with a single inotify instance
+// and notifies readers about events on their files. When inotify can't be used,
+// e.g. due to reached limits, readers fall back to polling.
+type dirWatcher struct {
+	mu   sync.Mutex
+	w    *fsnotify.Watcher
+	dirs map[string]*watchedDir
+}
+
+type watchedDir struct {
+	refs    int
+	watched bool
+	files   map[string]map[chan struct{}]struct{}
+}
+
+// watch starts watching file at path. Returned channel receives a value when
+// file may have changed, it's never closed. Cancel must be called once
+// events are not needed.
+func (dw *dirWatcher) watch(path string) (<-chan struct{}, func()) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	dir := filepath.Dir(path)
+	d, ok := dw.dirs[dir]
+	if !ok {
+		d = &watchedDir{files: make(map[string]map[chan struct{}]struct{})}
+		dw.dirs[dir] = d
+	}
+	if !d.watched && dw.start() {
+		// directory is watched to find out when file is created or replaced
+		d.watched = dw.w.Add(dir) == nil
+	}
+	d.refs++
+
+	events := make(chan struct{}, 1)
+	if d.files[path] == nil {
+		d.files[path] = make(map[chan struct{}]struct{})
+	}
+	d.files[path][events] = struct{}{}
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() { dw.unwatch(dir, path, events) })
+	}
+}
+
+func (dw *dirWatcher) unwatch(dir, path string, events chan struct{}) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	d := dw.dirs[dir]
+	delete(d.files[path], events)
+	if len(d.files[path]) == 0 {
+		delete(d.files, path)
+	}
+	d.refs--
+	if d.refs == 0 {
+		if d.watched {
+			_ = dw.w.Remove(dir)
+		}
+		delete(dw.dirs, dir)
+	}
+}
+
+// start creates inotify watcher unless it's already running.
+// It's called under lock.
+func (dw *dirWatcher) start() bool {
+	if dw.w != nil {
+		return true
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return false
+	}
+	dw.w = w
+	go dw.run(w)
+	return true
+}
+
+func (dw *dirWatcher) run(w *fsnotify.Watcher) {
+	for {
+		select {
+		case e, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			dw.notify(filepath.Clean(e.Name))
+		case _, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			// events might be lost, e.g. due to queue overflow
+			dw.notifyAll()
+		}
+	}
+}
+
+// notify sends event to watchers of file at path.
+func (dw *dirWatcher) notify(path string) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if d, ok := dw.dirs[filepath.Dir(path)]; ok {
+		send(d.files[path])
+	}
+}
+
+// notifyAll sends event to all watchers.
+func (dw *dirWatcher) notifyAll() {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	for _, d := range dw.dirs {
+		for _, subs := range d.files {
+			send(subs)
+		}
+	}
+}
+
+func send(subs map[chan struct{}]struct{}) {
+	for events := range subs {
+		select {
+		case events <- struct{}{}:
+		default:
+		}
+	}
+}
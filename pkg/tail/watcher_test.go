@@ -0,0 +1,78 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_SharedWatcher(t *testing.T) {
+	root, err := ioutil.TempDir("", "tail")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	// more readers than the default inotify instances limit
+	const n = 200
+	readers := make([]*Reader, n)
+	for i := range readers {
+		dir := filepath.Join(root, fmt.Sprint(i))
+		require.NoError(t, os.Mkdir(dir, 0755))
+		tr, err := NewReader(filepath.Join(dir, "slurm-42.out"), 0)
+		require.NoError(t, err)
+		readers[i] = tr
+	}
+
+	watcher.mu.Lock()
+	require.Len(t, watcher.dirs, n)
+	watcher.mu.Unlock()
+
+	for _, i := range []int{0, n / 2, n - 1} {
+		appendFile(t, filepath.Join(root, fmt.Sprint(i), "slurm-42.out"), "hello")
+		out, err := readN(t, readers[i], 5)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(out))
+	}
+
+	for _, tr := range readers {
+		require.NoError(t, tr.Close())
+	}
+	watcher.mu.Lock()
+	require.Empty(t, watcher.dirs)
+	watcher.mu.Unlock()
+}
+
+func TestReader_Polling(t *testing.T) {
+	root, err := ioutil.TempDir("", "tail")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	// directory can't be watched until it's created, so file is polled
+	dir := filepath.Join(root, "missing")
+	tr, err := NewReader(filepath.Join(dir, "slurm-42.out"), 0)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	require.NoError(t, os.Mkdir(dir, 0755))
+	appendFile(t, filepath.Join(dir, "slurm-42.out"), "polled")
+	out, err := readN(t, tr, 6)
+	require.NoError(t, err)
+	require.Equal(t, "polled", string(out))
+}
@@ -26,6 +26,72 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ContainerRuntime int32
+
+const (
+	ContainerRuntime_SINGULARITY ContainerRuntime = 0
+	ContainerRuntime_APPTAINER   ContainerRuntime = 1
+	// Enroot with pyxis srun plugin.
+	ContainerRuntime_ENROOT       ContainerRuntime = 2
+	ContainerRuntime_PODMAN_HPC   ContainerRuntime = 3
+	ContainerRuntime_CHARLIECLOUD ContainerRuntime = 4
+)
+
+var ContainerRuntime_name = map[int32]string{
+	0: "SINGULARITY",
+	1: "APPTAINER",
+	2: "ENROOT",
+	3: "PODMAN_HPC",
+	4: "CHARLIECLOUD",
+}
+
+var ContainerRuntime_value = map[string]int32{
+	"SINGULARITY":  0,
+	"APPTAINER":    1,
+	"ENROOT":       2,
+	"PODMAN_HPC":   3,
+	"CHARLIECLOUD": 4,
+}
+
+func (x ContainerRuntime) String() string {
+	return proto.EnumName(ContainerRuntime_name, int32(x))
+}
+
+func (ContainerRuntime) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{0}
+}
+
+type MPIType int32
+
+const (
+	MPIType_MPI_DEFAULT MPIType = 0
+	MPIType_MPI_PMIX    MPIType = 1
+	MPIType_MPI_PMI2    MPIType = 2
+	MPIType_MPI_NONE    MPIType = 3
+)
+
+var MPIType_name = map[int32]string{
+	0: "MPI_DEFAULT",
+	1: "MPI_PMIX",
+	2: "MPI_PMI2",
+	3: "MPI_NONE",
+}
+
+var MPIType_value = map[string]int32{
+	"MPI_DEFAULT": 0,
+	"MPI_PMIX":    1,
+	"MPI_PMI2":    2,
+	"MPI_NONE":    3,
+}
+
+func (x MPIType) String() string {
+	return proto.EnumName(MPIType_name, int32(x))
+}
+
+func (MPIType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{1}
+}
+
 type TailAction int32
 
 const (
@@ -48,7 +114,37 @@ func (x TailAction) String() string {
 }
 
 func (TailAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{0}
+	return fileDescriptor_5a3bd06263c8633f, []int{2}
+}
+
+type LogStream int32
+
+const (
+	LogStream_STDOUT LogStream = 0
+	LogStream_STDERR LogStream = 1
+	// Both standard output and error. When job writes them to
+	// the same file, output is streamed as STDOUT.
+	LogStream_BOTH LogStream = 2
+)
+
+var LogStream_name = map[int32]string{
+	0: "STDOUT",
+	1: "STDERR",
+	2: "BOTH",
+}
+
+var LogStream_value = map[string]int32{
+	"STDOUT": 0,
+	"STDERR": 1,
+	"BOTH":   2,
+}
+
+func (x LogStream) String() string {
+	return proto.EnumName(LogStream_name, int32(x))
+}
+
+func (LogStream) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{3}
 }
 
 type JobStatus int32
@@ -59,6 +155,7 @@ const (
 	JobStatus_FAILED    JobStatus = 2
 	JobStatus_TIMEOUT   JobStatus = 3
 	JobStatus_PENDING   JobStatus = 4
+	JobStatus_RUNNING   JobStatus = 5
 	JobStatus_UNKNOWN   JobStatus = 10
 )
 
@@ -68,6 +165,7 @@ var JobStatus_name = map[int32]string{
 	2:  "FAILED",
 	3:  "TIMEOUT",
 	4:  "PENDING",
+	5:  "RUNNING",
 	10: "UNKNOWN",
 }
 
@@ -77,6 +175,7 @@ var JobStatus_value = map[string]int32{
 	"FAILED":    2,
 	"TIMEOUT":   3,
 	"PENDING":   4,
+	"RUNNING":   5,
 	"UNKNOWN":   10,
 }
 
@@ -85,7 +184,44 @@ func (x JobStatus) String() string {
 }
 
 func (JobStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{1}
+	return fileDescriptor_5a3bd06263c8633f, []int{4}
+}
+
+type NodeState int32
+
+const (
+	NodeState_NODE_UNKNOWN NodeState = 0
+	NodeState_IDLE         NodeState = 1
+	NodeState_MIXED        NodeState = 2
+	NodeState_ALLOCATED    NodeState = 3
+	NodeState_DRAIN        NodeState = 4
+	NodeState_DOWN         NodeState = 5
+)
+
+var NodeState_name = map[int32]string{
+	0: "NODE_UNKNOWN",
+	1: "IDLE",
+	2: "MIXED",
+	3: "ALLOCATED",
+	4: "DRAIN",
+	5: "DOWN",
+}
+
+var NodeState_value = map[string]int32{
+	"NODE_UNKNOWN": 0,
+	"IDLE":         1,
+	"MIXED":        2,
+	"ALLOCATED":    3,
+	"DRAIN":        4,
+	"DOWN":         5,
+}
+
+func (x NodeState) String() string {
+	return proto.EnumName(NodeState_name, int32(x))
+}
+
+func (NodeState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{5}
 }
 
 type SubmitJobRequest struct {
@@ -417,277 +553,899 @@ func (m *JobStepsResponse) GetJobSteps() []*JobStepInfo {
 	return nil
 }
 
-type OpenFileRequest struct {
-	// Path to file to open.
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+type JobUsageRequest struct {
+	// ID of a job to fetch usage of.
+	JobId                int64    `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OpenFileRequest) Reset()         { *m = OpenFileRequest{} }
-func (m *OpenFileRequest) String() string { return proto.CompactTextString(m) }
-func (*OpenFileRequest) ProtoMessage()    {}
-func (*OpenFileRequest) Descriptor() ([]byte, []int) {
+func (m *JobUsageRequest) Reset()         { *m = JobUsageRequest{} }
+func (m *JobUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*JobUsageRequest) ProtoMessage()    {}
+func (*JobUsageRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{8}
 }
 
-func (m *OpenFileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OpenFileRequest.Unmarshal(m, b)
+func (m *JobUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobUsageRequest.Unmarshal(m, b)
 }
-func (m *OpenFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OpenFileRequest.Marshal(b, m, deterministic)
+func (m *JobUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobUsageRequest.Marshal(b, m, deterministic)
 }
-func (m *OpenFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OpenFileRequest.Merge(m, src)
+func (m *JobUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobUsageRequest.Merge(m, src)
 }
-func (m *OpenFileRequest) XXX_Size() int {
-	return xxx_messageInfo_OpenFileRequest.Size(m)
+func (m *JobUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_JobUsageRequest.Size(m)
 }
-func (m *OpenFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_OpenFileRequest.DiscardUnknown(m)
+func (m *JobUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobUsageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OpenFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_JobUsageRequest proto.InternalMessageInfo
 
-func (m *OpenFileRequest) GetPath() string {
+func (m *JobUsageRequest) GetJobId() int64 {
 	if m != nil {
-		return m.Path
+		return m.JobId
 	}
-	return ""
+	return 0
 }
 
-type ResourcesRequest struct {
-	// Partition which resources should be returned.
-	Partition            string   `protobuf:"bytes,1,opt,name=partition,proto3" json:"partition,omitempty"`
+type JobUsageResponse struct {
+	// Usage of the job steps. The first one describes the whole job allocation.
+	Steps []*JobStepUsage `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Ratio of consumed to allocated CPU time, in percent.
+	// Zero if job has not finished yet.
+	CpuEfficiency int32 `protobuf:"varint,2,opt,name=cpu_efficiency,json=cpuEfficiency,proto3" json:"cpu_efficiency,omitempty"`
+	// Ratio of maximum resident set size to allocated memory, in percent.
+	MemoryEfficiency     int32    `protobuf:"varint,3,opt,name=memory_efficiency,json=memoryEfficiency,proto3" json:"memory_efficiency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResourcesRequest) Reset()         { *m = ResourcesRequest{} }
-func (m *ResourcesRequest) String() string { return proto.CompactTextString(m) }
-func (*ResourcesRequest) ProtoMessage()    {}
-func (*ResourcesRequest) Descriptor() ([]byte, []int) {
+func (m *JobUsageResponse) Reset()         { *m = JobUsageResponse{} }
+func (m *JobUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*JobUsageResponse) ProtoMessage()    {}
+func (*JobUsageResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{9}
 }
 
-func (m *ResourcesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResourcesRequest.Unmarshal(m, b)
+func (m *JobUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobUsageResponse.Unmarshal(m, b)
 }
-func (m *ResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResourcesRequest.Marshal(b, m, deterministic)
+func (m *JobUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobUsageResponse.Marshal(b, m, deterministic)
 }
-func (m *ResourcesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResourcesRequest.Merge(m, src)
+func (m *JobUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobUsageResponse.Merge(m, src)
 }
-func (m *ResourcesRequest) XXX_Size() int {
-	return xxx_messageInfo_ResourcesRequest.Size(m)
+func (m *JobUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_JobUsageResponse.Size(m)
 }
-func (m *ResourcesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResourcesRequest.DiscardUnknown(m)
+func (m *JobUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobUsageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResourcesRequest proto.InternalMessageInfo
+var xxx_messageInfo_JobUsageResponse proto.InternalMessageInfo
 
-func (m *ResourcesRequest) GetPartition() string {
+func (m *JobUsageResponse) GetSteps() []*JobStepUsage {
 	if m != nil {
-		return m.Partition
+		return m.Steps
 	}
-	return ""
+	return nil
 }
 
-type ResourcesResponse struct {
-	// Number of nodes in the partition.
-	Nodes int64 `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
-	// Number of cpus on each node.
-	CpuPerNode int64 `protobuf:"varint,2,opt,name=cpuPerNode,proto3" json:"cpuPerNode,omitempty"`
-	// Amount of memory on each node.
-	MemPerNode int64 `protobuf:"varint,3,opt,name=memPerNode,proto3" json:"memPerNode,omitempty"`
-	// Wall time setting for the partition.
-	WallTime int64 `protobuf:"varint,4,opt,name=wallTime,proto3" json:"wallTime,omitempty"`
-	// Set of features of the partition.
-	Features             []*Feature `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *JobUsageResponse) GetCpuEfficiency() int32 {
+	if m != nil {
+		return m.CpuEfficiency
+	}
+	return 0
 }
 
-func (m *ResourcesResponse) Reset()         { *m = ResourcesResponse{} }
-func (m *ResourcesResponse) String() string { return proto.CompactTextString(m) }
-func (*ResourcesResponse) ProtoMessage()    {}
-func (*ResourcesResponse) Descriptor() ([]byte, []int) {
+func (m *JobUsageResponse) GetMemoryEfficiency() int32 {
+	if m != nil {
+		return m.MemoryEfficiency
+	}
+	return 0
+}
+
+// JobFilter selects jobs to be listed. Empty fields match any job,
+// repeated fields match job if any of the values matches.
+type JobFilter struct {
+	// Job statuses.
+	States []JobStatus `protobuf:"varint,1,rep,packed,name=states,proto3,enum=api.JobStatus" json:"states,omitempty"`
+	// Partitions where jobs reside.
+	Partitions []string `protobuf:"bytes,2,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	// Names of users who submitted jobs.
+	Users []string `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	// Job name prefix.
+	NamePrefix string `protobuf:"bytes,4,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// Only jobs submitted after this time are returned. When not set
+	// finished jobs are listed since the beginning of the current day.
+	SubmittedSince *timestamp.Timestamp `protobuf:"bytes,5,opt,name=submitted_since,json=submittedSince,proto3" json:"submitted_since,omitempty"`
+	// ID of a client who submitted jobs.
+	ClientId             string   `protobuf:"bytes,6,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobFilter) Reset()         { *m = JobFilter{} }
+func (m *JobFilter) String() string { return proto.CompactTextString(m) }
+func (*JobFilter) ProtoMessage()    {}
+func (*JobFilter) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{10}
 }
 
-func (m *ResourcesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResourcesResponse.Unmarshal(m, b)
+func (m *JobFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobFilter.Unmarshal(m, b)
 }
-func (m *ResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResourcesResponse.Marshal(b, m, deterministic)
+func (m *JobFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobFilter.Marshal(b, m, deterministic)
 }
-func (m *ResourcesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResourcesResponse.Merge(m, src)
+func (m *JobFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobFilter.Merge(m, src)
 }
-func (m *ResourcesResponse) XXX_Size() int {
-	return xxx_messageInfo_ResourcesResponse.Size(m)
+func (m *JobFilter) XXX_Size() int {
+	return xxx_messageInfo_JobFilter.Size(m)
 }
-func (m *ResourcesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResourcesResponse.DiscardUnknown(m)
+func (m *JobFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobFilter.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResourcesResponse proto.InternalMessageInfo
+var xxx_messageInfo_JobFilter proto.InternalMessageInfo
 
-func (m *ResourcesResponse) GetNodes() int64 {
+func (m *JobFilter) GetStates() []JobStatus {
 	if m != nil {
-		return m.Nodes
+		return m.States
 	}
-	return 0
+	return nil
 }
 
-func (m *ResourcesResponse) GetCpuPerNode() int64 {
+func (m *JobFilter) GetPartitions() []string {
 	if m != nil {
-		return m.CpuPerNode
+		return m.Partitions
 	}
-	return 0
+	return nil
 }
 
-func (m *ResourcesResponse) GetMemPerNode() int64 {
+func (m *JobFilter) GetUsers() []string {
 	if m != nil {
-		return m.MemPerNode
+		return m.Users
 	}
-	return 0
+	return nil
 }
 
-func (m *ResourcesResponse) GetWallTime() int64 {
+func (m *JobFilter) GetNamePrefix() string {
 	if m != nil {
-		return m.WallTime
+		return m.NamePrefix
 	}
-	return 0
+	return ""
 }
 
-func (m *ResourcesResponse) GetFeatures() []*Feature {
+func (m *JobFilter) GetSubmittedSince() *timestamp.Timestamp {
 	if m != nil {
-		return m.Features
+		return m.SubmittedSince
 	}
 	return nil
 }
 
-type PartitionsRequest struct {
+func (m *JobFilter) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	// Filter to select jobs.
+	Filter *JobFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Maximum number of jobs to return. Server default is used when not set.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token received from a previous ListJobs call to fetch the next page.
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PartitionsRequest) Reset()         { *m = PartitionsRequest{} }
-func (m *PartitionsRequest) String() string { return proto.CompactTextString(m) }
-func (*PartitionsRequest) ProtoMessage()    {}
-func (*PartitionsRequest) Descriptor() ([]byte, []int) {
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{11}
 }
 
-func (m *PartitionsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PartitionsRequest.Unmarshal(m, b)
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
 }
-func (m *PartitionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PartitionsRequest.Marshal(b, m, deterministic)
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
 }
-func (m *PartitionsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PartitionsRequest.Merge(m, src)
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
 }
-func (m *PartitionsRequest) XXX_Size() int {
-	return xxx_messageInfo_PartitionsRequest.Size(m)
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
 }
-func (m *PartitionsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PartitionsRequest.DiscardUnknown(m)
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PartitionsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
 
-type PartitionsResponse struct {
-	Partition            []string `protobuf:"bytes,1,rep,name=partition,proto3" json:"partition,omitempty"`
+func (m *ListJobsRequest) GetFilter() *JobFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListJobsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListJobsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	// Jobs brief information. Only id, user_id, name, status, submit_time,
+	// partition and client_id are set.
+	Jobs []*JobInfo `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Token to fetch the next page, empty if there are no more jobs.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PartitionsResponse) Reset()         { *m = PartitionsResponse{} }
-func (m *PartitionsResponse) String() string { return proto.CompactTextString(m) }
-func (*PartitionsResponse) ProtoMessage()    {}
-func (*PartitionsResponse) Descriptor() ([]byte, []int) {
+func (m *ListJobsResponse) Reset()         { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()    {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{12}
 }
 
-func (m *PartitionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PartitionsResponse.Unmarshal(m, b)
+func (m *ListJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponse.Unmarshal(m, b)
 }
-func (m *PartitionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PartitionsResponse.Marshal(b, m, deterministic)
+func (m *ListJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponse.Marshal(b, m, deterministic)
 }
-func (m *PartitionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PartitionsResponse.Merge(m, src)
+func (m *ListJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponse.Merge(m, src)
 }
-func (m *PartitionsResponse) XXX_Size() int {
-	return xxx_messageInfo_PartitionsResponse.Size(m)
+func (m *ListJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponse.Size(m)
 }
-func (m *PartitionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PartitionsResponse.DiscardUnknown(m)
+func (m *ListJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PartitionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListJobsResponse proto.InternalMessageInfo
 
-func (m *PartitionsResponse) GetPartition() []string {
+func (m *ListJobsResponse) GetJobs() []*JobInfo {
 	if m != nil {
-		return m.Partition
+		return m.Jobs
 	}
 	return nil
 }
 
-type WorkloadInfoRequest struct {
+func (m *ListJobsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type OpenFileRequest struct {
+	// Path to file to open.
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WorkloadInfoRequest) Reset()         { *m = WorkloadInfoRequest{} }
-func (m *WorkloadInfoRequest) String() string { return proto.CompactTextString(m) }
-func (*WorkloadInfoRequest) ProtoMessage()    {}
-func (*WorkloadInfoRequest) Descriptor() ([]byte, []int) {
+func (m *OpenFileRequest) Reset()         { *m = OpenFileRequest{} }
+func (m *OpenFileRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenFileRequest) ProtoMessage()    {}
+func (*OpenFileRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5a3bd06263c8633f, []int{13}
 }
 
-func (m *WorkloadInfoRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WorkloadInfoRequest.Unmarshal(m, b)
+func (m *OpenFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenFileRequest.Unmarshal(m, b)
 }
-func (m *WorkloadInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WorkloadInfoRequest.Marshal(b, m, deterministic)
+func (m *OpenFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenFileRequest.Marshal(b, m, deterministic)
 }
-func (m *WorkloadInfoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WorkloadInfoRequest.Merge(m, src)
+func (m *OpenFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenFileRequest.Merge(m, src)
 }
-func (m *WorkloadInfoRequest) XXX_Size() int {
-	return xxx_messageInfo_WorkloadInfoRequest.Size(m)
+func (m *OpenFileRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenFileRequest.Size(m)
 }
-func (m *WorkloadInfoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WorkloadInfoRequest.DiscardUnknown(m)
+func (m *OpenFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WorkloadInfoRequest proto.InternalMessageInfo
+var xxx_messageInfo_OpenFileRequest proto.InternalMessageInfo
 
-type WorkloadInfoResponse struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Uid                  int64    `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+func (m *OpenFileRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type JobLogsRequest struct {
+	// ID of a job to stream output of.
+	JobId int64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Output streams to return.
+	Stream LogStream `protobuf:"varint,2,opt,name=stream,proto3,enum=api.LogStream" json:"stream,omitempty"`
+	// Whether output should be streamed until job is finished.
+	Follow bool `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Number of bytes to skip at the beginning of each stream,
+	// e.g. to resume streaming after reconnect.
+	SinceBytes int64 `protobuf:"varint,4,opt,name=since_bytes,json=sinceBytes,proto3" json:"since_bytes,omitempty"`
+	// Number of last lines of each stream to start with, 0 means all.
+	TailLines            int64    `protobuf:"varint,5,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobLogsRequest) Reset()         { *m = JobLogsRequest{} }
+func (m *JobLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*JobLogsRequest) ProtoMessage()    {}
+func (*JobLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{14}
+}
+
+func (m *JobLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobLogsRequest.Unmarshal(m, b)
+}
+func (m *JobLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *JobLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobLogsRequest.Merge(m, src)
+}
+func (m *JobLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_JobLogsRequest.Size(m)
+}
+func (m *JobLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobLogsRequest proto.InternalMessageInfo
+
+func (m *JobLogsRequest) GetJobId() int64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *JobLogsRequest) GetStream() LogStream {
+	if m != nil {
+		return m.Stream
+	}
+	return LogStream_STDOUT
+}
+
+func (m *JobLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *JobLogsRequest) GetSinceBytes() int64 {
+	if m != nil {
+		return m.SinceBytes
+	}
+	return 0
+}
+
+func (m *JobLogsRequest) GetTailLines() int64 {
+	if m != nil {
+		return m.TailLines
+	}
+	return 0
+}
+
+// JobLogsChunk is a piece of job output.
+type JobLogsChunk struct {
+	// Stream the chunk belongs to, either STDOUT or STDERR.
+	Stream               LogStream `protobuf:"varint,1,opt,name=stream,proto3,enum=api.LogStream" json:"stream,omitempty"`
+	Content              []byte    `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *JobLogsChunk) Reset()         { *m = JobLogsChunk{} }
+func (m *JobLogsChunk) String() string { return proto.CompactTextString(m) }
+func (*JobLogsChunk) ProtoMessage()    {}
+func (*JobLogsChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{15}
+}
+
+func (m *JobLogsChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobLogsChunk.Unmarshal(m, b)
+}
+func (m *JobLogsChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobLogsChunk.Marshal(b, m, deterministic)
+}
+func (m *JobLogsChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobLogsChunk.Merge(m, src)
+}
+func (m *JobLogsChunk) XXX_Size() int {
+	return xxx_messageInfo_JobLogsChunk.Size(m)
+}
+func (m *JobLogsChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobLogsChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobLogsChunk proto.InternalMessageInfo
+
+func (m *JobLogsChunk) GetStream() LogStream {
+	if m != nil {
+		return m.Stream
+	}
+	return LogStream_STDOUT
+}
+
+func (m *JobLogsChunk) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type ResourcesRequest struct {
+	// Partition which resources should be returned.
+	Partition            string   `protobuf:"bytes,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourcesRequest) Reset()         { *m = ResourcesRequest{} }
+func (m *ResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*ResourcesRequest) ProtoMessage()    {}
+func (*ResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{16}
+}
+
+func (m *ResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourcesRequest.Unmarshal(m, b)
+}
+func (m *ResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *ResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourcesRequest.Merge(m, src)
+}
+func (m *ResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_ResourcesRequest.Size(m)
+}
+func (m *ResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourcesRequest proto.InternalMessageInfo
+
+func (m *ResourcesRequest) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+type ResourcesResponse struct {
+	// Number of nodes in the partition.
+	Nodes int64 `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	// Number of cpus on each node.
+	CpuPerNode int64 `protobuf:"varint,2,opt,name=cpuPerNode,proto3" json:"cpuPerNode,omitempty"`
+	// Amount of memory on each node.
+	MemPerNode int64 `protobuf:"varint,3,opt,name=memPerNode,proto3" json:"memPerNode,omitempty"`
+	// Wall time setting for the partition.
+	WallTime int64 `protobuf:"varint,4,opt,name=wallTime,proto3" json:"wallTime,omitempty"`
+	// Set of features of the partition.
+	Features             []*Feature `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ResourcesResponse) Reset()         { *m = ResourcesResponse{} }
+func (m *ResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*ResourcesResponse) ProtoMessage()    {}
+func (*ResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{17}
+}
+
+func (m *ResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourcesResponse.Unmarshal(m, b)
+}
+func (m *ResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *ResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourcesResponse.Merge(m, src)
+}
+func (m *ResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_ResourcesResponse.Size(m)
+}
+func (m *ResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourcesResponse proto.InternalMessageInfo
+
+func (m *ResourcesResponse) GetNodes() int64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *ResourcesResponse) GetCpuPerNode() int64 {
+	if m != nil {
+		return m.CpuPerNode
+	}
+	return 0
+}
+
+func (m *ResourcesResponse) GetMemPerNode() int64 {
+	if m != nil {
+		return m.MemPerNode
+	}
+	return 0
+}
+
+func (m *ResourcesResponse) GetWallTime() int64 {
+	if m != nil {
+		return m.WallTime
+	}
+	return 0
+}
+
+func (m *ResourcesResponse) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type PartitionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionsRequest) Reset()         { *m = PartitionsRequest{} }
+func (m *PartitionsRequest) String() string { return proto.CompactTextString(m) }
+func (*PartitionsRequest) ProtoMessage()    {}
+func (*PartitionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{18}
+}
+
+func (m *PartitionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionsRequest.Unmarshal(m, b)
+}
+func (m *PartitionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionsRequest.Marshal(b, m, deterministic)
+}
+func (m *PartitionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionsRequest.Merge(m, src)
+}
+func (m *PartitionsRequest) XXX_Size() int {
+	return xxx_messageInfo_PartitionsRequest.Size(m)
+}
+func (m *PartitionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionsRequest proto.InternalMessageInfo
+
+type PartitionsResponse struct {
+	Partition            []string `protobuf:"bytes,1,rep,name=partition,proto3" json:"partition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionsResponse) Reset()         { *m = PartitionsResponse{} }
+func (m *PartitionsResponse) String() string { return proto.CompactTextString(m) }
+func (*PartitionsResponse) ProtoMessage()    {}
+func (*PartitionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{19}
+}
+
+func (m *PartitionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionsResponse.Unmarshal(m, b)
+}
+func (m *PartitionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionsResponse.Marshal(b, m, deterministic)
+}
+func (m *PartitionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionsResponse.Merge(m, src)
+}
+func (m *PartitionsResponse) XXX_Size() int {
+	return xxx_messageInfo_PartitionsResponse.Size(m)
+}
+func (m *PartitionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionsResponse proto.InternalMessageInfo
+
+func (m *PartitionsResponse) GetPartition() []string {
+	if m != nil {
+		return m.Partition
+	}
+	return nil
+}
+
+type NodesRequest struct {
+	// Partition which nodes should be returned. All nodes
+	// are returned when not set.
+	Partition            string   `protobuf:"bytes,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodesRequest) Reset()         { *m = NodesRequest{} }
+func (m *NodesRequest) String() string { return proto.CompactTextString(m) }
+func (*NodesRequest) ProtoMessage()    {}
+func (*NodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{20}
+}
+
+func (m *NodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodesRequest.Unmarshal(m, b)
+}
+func (m *NodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodesRequest.Marshal(b, m, deterministic)
+}
+func (m *NodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodesRequest.Merge(m, src)
+}
+func (m *NodesRequest) XXX_Size() int {
+	return xxx_messageInfo_NodesRequest.Size(m)
+}
+func (m *NodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodesRequest proto.InternalMessageInfo
+
+func (m *NodesRequest) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+type NodesResponse struct {
+	Nodes                []*NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *NodesResponse) Reset()         { *m = NodesResponse{} }
+func (m *NodesResponse) String() string { return proto.CompactTextString(m) }
+func (*NodesResponse) ProtoMessage()    {}
+func (*NodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{21}
+}
+
+func (m *NodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodesResponse.Unmarshal(m, b)
+}
+func (m *NodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodesResponse.Marshal(b, m, deterministic)
+}
+func (m *NodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodesResponse.Merge(m, src)
+}
+func (m *NodesResponse) XXX_Size() int {
+	return xxx_messageInfo_NodesResponse.Size(m)
+}
+func (m *NodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodesResponse proto.InternalMessageInfo
+
+func (m *NodesResponse) GetNodes() []*NodeInfo {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type PartitionStatusRequest struct {
+	Partition string `protobuf:"bytes,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	// Partitions to return status of at once, partition field is
+	// ignored when set. Jobs of all partitions are fetched together.
+	Partitions           []string `protobuf:"bytes,2,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionStatusRequest) Reset()         { *m = PartitionStatusRequest{} }
+func (m *PartitionStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*PartitionStatusRequest) ProtoMessage()    {}
+func (*PartitionStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{22}
+}
+
+func (m *PartitionStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionStatusRequest.Unmarshal(m, b)
+}
+func (m *PartitionStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *PartitionStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionStatusRequest.Merge(m, src)
+}
+func (m *PartitionStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_PartitionStatusRequest.Size(m)
+}
+func (m *PartitionStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionStatusRequest proto.InternalMessageInfo
+
+func (m *PartitionStatusRequest) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+func (m *PartitionStatusRequest) GetPartitions() []string {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+type PartitionStatusResponse struct {
+	// Partition state as reported by Slurm, e.g. UP, DOWN, DRAIN or INACTIVE.
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of pending jobs submitted to the partition.
+	PendingJobs int64 `protobuf:"varint,2,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
+	// Number of running jobs in the partition.
+	RunningJobs int64 `protobuf:"varint,3,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	// Status of each of the requested partitions by partition name,
+	// set only when partitions are requested.
+	Partitions           map[string]*PartitionStatusResponse `protobuf:"bytes,4,rep,name=partitions,proto3" json:"partitions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *PartitionStatusResponse) Reset()         { *m = PartitionStatusResponse{} }
+func (m *PartitionStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*PartitionStatusResponse) ProtoMessage()    {}
+func (*PartitionStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{23}
+}
+
+func (m *PartitionStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionStatusResponse.Unmarshal(m, b)
+}
+func (m *PartitionStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *PartitionStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionStatusResponse.Merge(m, src)
+}
+func (m *PartitionStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_PartitionStatusResponse.Size(m)
+}
+func (m *PartitionStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionStatusResponse proto.InternalMessageInfo
+
+func (m *PartitionStatusResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *PartitionStatusResponse) GetPendingJobs() int64 {
+	if m != nil {
+		return m.PendingJobs
+	}
+	return 0
+}
+
+func (m *PartitionStatusResponse) GetRunningJobs() int64 {
+	if m != nil {
+		return m.RunningJobs
+	}
+	return 0
+}
+
+func (m *PartitionStatusResponse) GetPartitions() map[string]*PartitionStatusResponse {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+type WorkloadInfoRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *WorkloadInfoRequest) Reset()         { *m = WorkloadInfoRequest{} }
+func (m *WorkloadInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkloadInfoRequest) ProtoMessage()    {}
+func (*WorkloadInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{24}
+}
+
+func (m *WorkloadInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkloadInfoRequest.Unmarshal(m, b)
+}
+func (m *WorkloadInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkloadInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *WorkloadInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkloadInfoRequest.Merge(m, src)
+}
+func (m *WorkloadInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_WorkloadInfoRequest.Size(m)
+}
+func (m *WorkloadInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkloadInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkloadInfoRequest proto.InternalMessageInfo
+
+type WorkloadInfoResponse struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Uid     int64  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Container runtimes found on the cluster.
+	Runtimes []ContainerRuntime `protobuf:"varint,4,rep,packed,name=runtimes,proto3,enum=api.ContainerRuntime" json:"runtimes,omitempty"`
+	// Cluster licenses, they are shared by all partitions.
+	Licenses             []*Feature `protobuf:"bytes,5,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *WorkloadInfoResponse) Reset()         { *m = WorkloadInfoResponse{} }
 func (m *WorkloadInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*WorkloadInfoResponse) ProtoMessage()    {}
 func (*WorkloadInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{14}
+	return fileDescriptor_5a3bd06263c8633f, []int{25}
 }
 
 func (m *WorkloadInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -729,6 +1487,20 @@ func (m *WorkloadInfoResponse) GetUid() int64 {
 	return 0
 }
 
+func (m *WorkloadInfoResponse) GetRuntimes() []ContainerRuntime {
+	if m != nil {
+		return m.Runtimes
+	}
+	return nil
+}
+
+func (m *WorkloadInfoResponse) GetLicenses() []*Feature {
+	if m != nil {
+		return m.Licenses
+	}
+	return nil
+}
+
 type SubmitJobContainerRequest struct {
 	// Job image name
 	ImageName string `protobuf:"bytes,1,opt,name=imageName,proto3" json:"imageName,omitempty"`
@@ -743,18 +1515,32 @@ type SubmitJobContainerRequest struct {
 	// Partition where job should be submitted.
 	Partition string `protobuf:"bytes,6,opt,name=partition,proto3" json:"partition,omitempty"`
 	// ID of a client who submitted this job.
-	ClientId             string              `protobuf:"bytes,7,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Options              *SingularityOptions `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	ClientId string `protobuf:"bytes,7,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Options are used by singularity and apptainer runtimes.
+	Options *SingularityOptions `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	// Container runtime that runs the image.
+	Runtime      ContainerRuntime     `protobuf:"varint,9,opt,name=runtime,proto3,enum=api.ContainerRuntime" json:"runtime,omitempty"`
+	Enroot       *EnrootOptions       `protobuf:"bytes,10,opt,name=enroot,proto3" json:"enroot,omitempty"`
+	PodmanHPC    *PodmanHPCOptions    `protobuf:"bytes,11,opt,name=podmanHPC,proto3" json:"podmanHPC,omitempty"`
+	Charliecloud *CharliecloudOptions `protobuf:"bytes,12,opt,name=charliecloud,proto3" json:"charliecloud,omitempty"`
+	// MPI task layout, job isn't started as MPI ranks when not set.
+	Mpi *MPIOptions `protobuf:"bytes,13,opt,name=mpi,proto3" json:"mpi,omitempty"`
+	// Command overriding image entrypoint.
+	Command []string `protobuf:"bytes,14,rep,name=command,proto3" json:"command,omitempty"`
+	// Arguments of the command or image runscript.
+	Args []string `protobuf:"bytes,15,rep,name=args,proto3" json:"args,omitempty"`
+	// Environment variables to set in container.
+	Env                  map[string]string `protobuf:"bytes,16,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *SubmitJobContainerRequest) Reset()         { *m = SubmitJobContainerRequest{} }
 func (m *SubmitJobContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitJobContainerRequest) ProtoMessage()    {}
 func (*SubmitJobContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{15}
+	return fileDescriptor_5a3bd06263c8633f, []int{26}
 }
 
 func (m *SubmitJobContainerRequest) XXX_Unmarshal(b []byte) error {
@@ -796,37 +1582,93 @@ func (m *SubmitJobContainerRequest) GetCpuPerNode() int64 {
 	return 0
 }
 
-func (m *SubmitJobContainerRequest) GetMemPerNode() int64 {
+func (m *SubmitJobContainerRequest) GetMemPerNode() int64 {
+	if m != nil {
+		return m.MemPerNode
+	}
+	return 0
+}
+
+func (m *SubmitJobContainerRequest) GetWallTime() int64 {
+	if m != nil {
+		return m.WallTime
+	}
+	return 0
+}
+
+func (m *SubmitJobContainerRequest) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+func (m *SubmitJobContainerRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *SubmitJobContainerRequest) GetOptions() *SingularityOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *SubmitJobContainerRequest) GetRuntime() ContainerRuntime {
+	if m != nil {
+		return m.Runtime
+	}
+	return ContainerRuntime_SINGULARITY
+}
+
+func (m *SubmitJobContainerRequest) GetEnroot() *EnrootOptions {
+	if m != nil {
+		return m.Enroot
+	}
+	return nil
+}
+
+func (m *SubmitJobContainerRequest) GetPodmanHPC() *PodmanHPCOptions {
+	if m != nil {
+		return m.PodmanHPC
+	}
+	return nil
+}
+
+func (m *SubmitJobContainerRequest) GetCharliecloud() *CharliecloudOptions {
 	if m != nil {
-		return m.MemPerNode
+		return m.Charliecloud
 	}
-	return 0
+	return nil
 }
 
-func (m *SubmitJobContainerRequest) GetWallTime() int64 {
+func (m *SubmitJobContainerRequest) GetMpi() *MPIOptions {
 	if m != nil {
-		return m.WallTime
+		return m.Mpi
 	}
-	return 0
+	return nil
 }
 
-func (m *SubmitJobContainerRequest) GetPartition() string {
+func (m *SubmitJobContainerRequest) GetCommand() []string {
 	if m != nil {
-		return m.Partition
+		return m.Command
 	}
-	return ""
+	return nil
 }
 
-func (m *SubmitJobContainerRequest) GetClientId() string {
+func (m *SubmitJobContainerRequest) GetArgs() []string {
 	if m != nil {
-		return m.ClientId
+		return m.Args
 	}
-	return ""
+	return nil
 }
 
-func (m *SubmitJobContainerRequest) GetOptions() *SingularityOptions {
+func (m *SubmitJobContainerRequest) GetEnv() map[string]string {
 	if m != nil {
-		return m.Options
+		return m.Env
 	}
 	return nil
 }
@@ -851,7 +1693,7 @@ func (m *SingularityOptions) Reset()         { *m = SingularityOptions{} }
 func (m *SingularityOptions) String() string { return proto.CompactTextString(m) }
 func (*SingularityOptions) ProtoMessage()    {}
 func (*SingularityOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{16}
+	return fileDescriptor_5a3bd06263c8633f, []int{27}
 }
 
 func (m *SingularityOptions) XXX_Unmarshal(b []byte) error {
@@ -942,6 +1784,271 @@ func (m *SingularityOptions) GetWritable() bool {
 	return false
 }
 
+type EnrootOptions struct {
+	// Command to run in container, required.
+	Command              []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"`
+	Mounts               []string `protobuf:"bytes,2,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Workdir              string   `protobuf:"bytes,3,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	MountHome            bool     `protobuf:"varint,4,opt,name=mountHome,proto3" json:"mountHome,omitempty"`
+	RemapRoot            bool     `protobuf:"varint,5,opt,name=remapRoot,proto3" json:"remapRoot,omitempty"`
+	Writable             bool     `protobuf:"varint,6,opt,name=writable,proto3" json:"writable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnrootOptions) Reset()         { *m = EnrootOptions{} }
+func (m *EnrootOptions) String() string { return proto.CompactTextString(m) }
+func (*EnrootOptions) ProtoMessage()    {}
+func (*EnrootOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{28}
+}
+
+func (m *EnrootOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnrootOptions.Unmarshal(m, b)
+}
+func (m *EnrootOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnrootOptions.Marshal(b, m, deterministic)
+}
+func (m *EnrootOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnrootOptions.Merge(m, src)
+}
+func (m *EnrootOptions) XXX_Size() int {
+	return xxx_messageInfo_EnrootOptions.Size(m)
+}
+func (m *EnrootOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnrootOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnrootOptions proto.InternalMessageInfo
+
+func (m *EnrootOptions) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *EnrootOptions) GetMounts() []string {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
+func (m *EnrootOptions) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+func (m *EnrootOptions) GetMountHome() bool {
+	if m != nil {
+		return m.MountHome
+	}
+	return false
+}
+
+func (m *EnrootOptions) GetRemapRoot() bool {
+	if m != nil {
+		return m.RemapRoot
+	}
+	return false
+}
+
+func (m *EnrootOptions) GetWritable() bool {
+	if m != nil {
+		return m.Writable
+	}
+	return false
+}
+
+type PodmanHPCOptions struct {
+	Volumes              []string `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Workdir              string   `protobuf:"bytes,2,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	Gpu                  bool     `protobuf:"varint,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	Mpi                  bool     `protobuf:"varint,4,opt,name=mpi,proto3" json:"mpi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodmanHPCOptions) Reset()         { *m = PodmanHPCOptions{} }
+func (m *PodmanHPCOptions) String() string { return proto.CompactTextString(m) }
+func (*PodmanHPCOptions) ProtoMessage()    {}
+func (*PodmanHPCOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{29}
+}
+
+func (m *PodmanHPCOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodmanHPCOptions.Unmarshal(m, b)
+}
+func (m *PodmanHPCOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodmanHPCOptions.Marshal(b, m, deterministic)
+}
+func (m *PodmanHPCOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodmanHPCOptions.Merge(m, src)
+}
+func (m *PodmanHPCOptions) XXX_Size() int {
+	return xxx_messageInfo_PodmanHPCOptions.Size(m)
+}
+func (m *PodmanHPCOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodmanHPCOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodmanHPCOptions proto.InternalMessageInfo
+
+func (m *PodmanHPCOptions) GetVolumes() []string {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *PodmanHPCOptions) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+func (m *PodmanHPCOptions) GetGpu() bool {
+	if m != nil {
+		return m.Gpu
+	}
+	return false
+}
+
+func (m *PodmanHPCOptions) GetMpi() bool {
+	if m != nil {
+		return m.Mpi
+	}
+	return false
+}
+
+type CharliecloudOptions struct {
+	// Command to run in container, required.
+	Command              []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"`
+	Binds                []string `protobuf:"bytes,2,rep,name=binds,proto3" json:"binds,omitempty"`
+	Writable             bool     `protobuf:"varint,3,opt,name=writable,proto3" json:"writable,omitempty"`
+	SetEnv               bool     `protobuf:"varint,4,opt,name=setEnv,proto3" json:"setEnv,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CharliecloudOptions) Reset()         { *m = CharliecloudOptions{} }
+func (m *CharliecloudOptions) String() string { return proto.CompactTextString(m) }
+func (*CharliecloudOptions) ProtoMessage()    {}
+func (*CharliecloudOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{30}
+}
+
+func (m *CharliecloudOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CharliecloudOptions.Unmarshal(m, b)
+}
+func (m *CharliecloudOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CharliecloudOptions.Marshal(b, m, deterministic)
+}
+func (m *CharliecloudOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CharliecloudOptions.Merge(m, src)
+}
+func (m *CharliecloudOptions) XXX_Size() int {
+	return xxx_messageInfo_CharliecloudOptions.Size(m)
+}
+func (m *CharliecloudOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_CharliecloudOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CharliecloudOptions proto.InternalMessageInfo
+
+func (m *CharliecloudOptions) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *CharliecloudOptions) GetBinds() []string {
+	if m != nil {
+		return m.Binds
+	}
+	return nil
+}
+
+func (m *CharliecloudOptions) GetWritable() bool {
+	if m != nil {
+		return m.Writable
+	}
+	return false
+}
+
+func (m *CharliecloudOptions) GetSetEnv() bool {
+	if m != nil {
+		return m.SetEnv
+	}
+	return false
+}
+
+type MPIOptions struct {
+	// Number of MPI ranks.
+	Ntasks int64 `protobuf:"varint,1,opt,name=ntasks,proto3" json:"ntasks,omitempty"`
+	// Maximum number of ranks on each node.
+	TasksPerNode int64 `protobuf:"varint,2,opt,name=tasksPerNode,proto3" json:"tasksPerNode,omitempty"`
+	// Slurm MPI plugin, cluster default is used when not set.
+	Type                 MPIType  `protobuf:"varint,3,opt,name=type,proto3,enum=api.MPIType" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MPIOptions) Reset()         { *m = MPIOptions{} }
+func (m *MPIOptions) String() string { return proto.CompactTextString(m) }
+func (*MPIOptions) ProtoMessage()    {}
+func (*MPIOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{31}
+}
+
+func (m *MPIOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MPIOptions.Unmarshal(m, b)
+}
+func (m *MPIOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MPIOptions.Marshal(b, m, deterministic)
+}
+func (m *MPIOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MPIOptions.Merge(m, src)
+}
+func (m *MPIOptions) XXX_Size() int {
+	return xxx_messageInfo_MPIOptions.Size(m)
+}
+func (m *MPIOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_MPIOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MPIOptions proto.InternalMessageInfo
+
+func (m *MPIOptions) GetNtasks() int64 {
+	if m != nil {
+		return m.Ntasks
+	}
+	return 0
+}
+
+func (m *MPIOptions) GetTasksPerNode() int64 {
+	if m != nil {
+		return m.TasksPerNode
+	}
+	return 0
+}
+
+func (m *MPIOptions) GetType() MPIType {
+	if m != nil {
+		return m.Type
+	}
+	return MPIType_MPI_DEFAULT
+}
+
 type SubmitJobContainerResponse struct {
 	// Job ID to track submitted job.
 	JobId                int64    `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -954,7 +2061,7 @@ func (m *SubmitJobContainerResponse) Reset()         { *m = SubmitJobContainerRe
 func (m *SubmitJobContainerResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitJobContainerResponse) ProtoMessage()    {}
 func (*SubmitJobContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{17}
+	return fileDescriptor_5a3bd06263c8633f, []int{32}
 }
 
 func (m *SubmitJobContainerResponse) XXX_Unmarshal(b []byte) error {
@@ -995,7 +2102,7 @@ func (m *TailFileRequest) Reset()         { *m = TailFileRequest{} }
 func (m *TailFileRequest) String() string { return proto.CompactTextString(m) }
 func (*TailFileRequest) ProtoMessage()    {}
 func (*TailFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{18}
+	return fileDescriptor_5a3bd06263c8633f, []int{33}
 }
 
 func (m *TailFileRequest) XXX_Unmarshal(b []byte) error {
@@ -1065,7 +2172,9 @@ type JobInfo struct {
 	// Number of nodes requested by job.
 	NumNodes string `protobuf:"bytes,16,opt,name=num_nodes,json=numNodes,proto3" json:"num_nodes,omitempty"`
 	// Job array id.
-	ArrayId              string   `protobuf:"bytes,17,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
+	ArrayId string `protobuf:"bytes,17,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
+	// ID of a client who submitted the job.
+	ClientId             string   `protobuf:"bytes,18,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1075,7 +2184,7 @@ func (m *JobInfo) Reset()         { *m = JobInfo{} }
 func (m *JobInfo) String() string { return proto.CompactTextString(m) }
 func (*JobInfo) ProtoMessage()    {}
 func (*JobInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{19}
+	return fileDescriptor_5a3bd06263c8633f, []int{34}
 }
 
 func (m *JobInfo) XXX_Unmarshal(b []byte) error {
@@ -1124,95 +2233,233 @@ func (m *JobInfo) GetExitCode() string {
 	return ""
 }
 
-func (m *JobInfo) GetStatus() JobStatus {
+func (m *JobInfo) GetStatus() JobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return JobStatus_COMPLETED
+}
+
+func (m *JobInfo) GetSubmitTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SubmitTime
+	}
+	return nil
+}
+
+func (m *JobInfo) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *JobInfo) GetRunTime() *duration.Duration {
+	if m != nil {
+		return m.RunTime
+	}
+	return nil
+}
+
+func (m *JobInfo) GetTimeLimit() *duration.Duration {
+	if m != nil {
+		return m.TimeLimit
+	}
+	return nil
+}
+
+func (m *JobInfo) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
+}
+
+func (m *JobInfo) GetStdOut() string {
+	if m != nil {
+		return m.StdOut
+	}
+	return ""
+}
+
+func (m *JobInfo) GetStdErr() string {
+	if m != nil {
+		return m.StdErr
+	}
+	return ""
+}
+
+func (m *JobInfo) GetPartition() string {
+	if m != nil {
+		return m.Partition
+	}
+	return ""
+}
+
+func (m *JobInfo) GetNodeList() string {
+	if m != nil {
+		return m.NodeList
+	}
+	return ""
+}
+
+func (m *JobInfo) GetBatchHost() string {
+	if m != nil {
+		return m.BatchHost
+	}
+	return ""
+}
+
+func (m *JobInfo) GetNumNodes() string {
+	if m != nil {
+		return m.NumNodes
+	}
+	return ""
+}
+
+func (m *JobInfo) GetArrayId() string {
+	if m != nil {
+		return m.ArrayId
+	}
+	return ""
+}
+
+func (m *JobInfo) GetClientId() string {
 	if m != nil {
-		return m.Status
+		return m.ClientId
 	}
-	return JobStatus_COMPLETED
+	return ""
 }
 
-func (m *JobInfo) GetSubmitTime() *timestamp.Timestamp {
-	if m != nil {
-		return m.SubmitTime
-	}
-	return nil
+// NodeInfo represents state and resources of a single compute node.
+type NodeInfo struct {
+	// Node name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Node current state.
+	State NodeState `protobuf:"varint,2,opt,name=state,proto3,enum=api.NodeState" json:"state,omitempty"`
+	// Node state as reported by workload manager, e.g. IDLE+DRAIN.
+	RawState string `protobuf:"bytes,3,opt,name=raw_state,json=rawState,proto3" json:"raw_state,omitempty"`
+	// Total number of cpus on the node.
+	Cpus int64 `protobuf:"varint,4,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// Number of allocated cpus.
+	AllocCpus int64 `protobuf:"varint,5,opt,name=alloc_cpus,json=allocCpus,proto3" json:"alloc_cpus,omitempty"`
+	// Total amount of memory on the node, in MBs.
+	RealMemory int64 `protobuf:"varint,6,opt,name=real_memory,json=realMemory,proto3" json:"real_memory,omitempty"`
+	// Amount of allocated memory, in MBs.
+	AllocMemory int64 `protobuf:"varint,7,opt,name=alloc_memory,json=allocMemory,proto3" json:"alloc_memory,omitempty"`
+	// Node features.
+	Features []string `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`
+	// Generic resources available on the node.
+	Gres []*Feature `protobuf:"bytes,9,rep,name=gres,proto3" json:"gres,omitempty"`
+	// Reason why node is unavailable, e.g. drained or down.
+	Reason string `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Partitions the node belongs to.
+	Partitions           []string `protobuf:"bytes,11,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *JobInfo) GetStartTime() *timestamp.Timestamp {
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{35}
+}
+
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
+}
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
+}
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
+}
+func (m *NodeInfo) XXX_Size() int {
+	return xxx_messageInfo_NodeInfo.Size(m)
+}
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
+
+func (m *NodeInfo) GetName() string {
 	if m != nil {
-		return m.StartTime
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *JobInfo) GetRunTime() *duration.Duration {
+func (m *NodeInfo) GetState() NodeState {
 	if m != nil {
-		return m.RunTime
+		return m.State
 	}
-	return nil
+	return NodeState_NODE_UNKNOWN
 }
 
-func (m *JobInfo) GetTimeLimit() *duration.Duration {
+func (m *NodeInfo) GetRawState() string {
 	if m != nil {
-		return m.TimeLimit
+		return m.RawState
 	}
-	return nil
+	return ""
 }
 
-func (m *JobInfo) GetWorkingDir() string {
+func (m *NodeInfo) GetCpus() int64 {
 	if m != nil {
-		return m.WorkingDir
+		return m.Cpus
 	}
-	return ""
+	return 0
 }
 
-func (m *JobInfo) GetStdOut() string {
+func (m *NodeInfo) GetAllocCpus() int64 {
 	if m != nil {
-		return m.StdOut
+		return m.AllocCpus
 	}
-	return ""
+	return 0
 }
 
-func (m *JobInfo) GetStdErr() string {
+func (m *NodeInfo) GetRealMemory() int64 {
 	if m != nil {
-		return m.StdErr
+		return m.RealMemory
 	}
-	return ""
+	return 0
 }
 
-func (m *JobInfo) GetPartition() string {
+func (m *NodeInfo) GetAllocMemory() int64 {
 	if m != nil {
-		return m.Partition
+		return m.AllocMemory
 	}
-	return ""
+	return 0
 }
 
-func (m *JobInfo) GetNodeList() string {
+func (m *NodeInfo) GetFeatures() []string {
 	if m != nil {
-		return m.NodeList
+		return m.Features
 	}
-	return ""
+	return nil
 }
 
-func (m *JobInfo) GetBatchHost() string {
+func (m *NodeInfo) GetGres() []*Feature {
 	if m != nil {
-		return m.BatchHost
+		return m.Gres
 	}
-	return ""
+	return nil
 }
 
-func (m *JobInfo) GetNumNodes() string {
+func (m *NodeInfo) GetReason() string {
 	if m != nil {
-		return m.NumNodes
+		return m.Reason
 	}
 	return ""
 }
 
-func (m *JobInfo) GetArrayId() string {
+func (m *NodeInfo) GetPartitions() []string {
 	if m != nil {
-		return m.ArrayId
+		return m.Partitions
 	}
-	return ""
+	return nil
 }
 
 // JobStepInfo represents information about a single job step.
@@ -1238,7 +2485,7 @@ func (m *JobStepInfo) Reset()         { *m = JobStepInfo{} }
 func (m *JobStepInfo) String() string { return proto.CompactTextString(m) }
 func (*JobStepInfo) ProtoMessage()    {}
 func (*JobStepInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{20}
+	return fileDescriptor_5a3bd06263c8633f, []int{36}
 }
 
 func (m *JobStepInfo) XXX_Unmarshal(b []byte) error {
@@ -1301,6 +2548,119 @@ func (m *JobStepInfo) GetEndTime() *timestamp.Timestamp {
 	return nil
 }
 
+// JobStepUsage represents resources usage of a single job step.
+type JobStepUsage struct {
+	// ID of a job step.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Job step elapsed time.
+	Elapsed *duration.Duration `protobuf:"bytes,2,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	// Total CPU time consumed by job step, i.e. user and system time.
+	CpuTime *duration.Duration `protobuf:"bytes,3,opt,name=cpu_time,json=cpuTime,proto3" json:"cpu_time,omitempty"`
+	// Maximum resident set size of all tasks in job step, in bytes.
+	MaxRss int64 `protobuf:"varint,4,opt,name=max_rss,json=maxRss,proto3" json:"max_rss,omitempty"`
+	// Average resident set size of all tasks in job step, in bytes.
+	AveRss int64 `protobuf:"varint,5,opt,name=ave_rss,json=aveRss,proto3" json:"ave_rss,omitempty"`
+	// Maximum number of bytes read by all tasks in job step.
+	MaxDiskRead int64 `protobuf:"varint,6,opt,name=max_disk_read,json=maxDiskRead,proto3" json:"max_disk_read,omitempty"`
+	// Maximum number of bytes written by all tasks in job step.
+	MaxDiskWrite int64 `protobuf:"varint,7,opt,name=max_disk_write,json=maxDiskWrite,proto3" json:"max_disk_write,omitempty"`
+	// Energy consumed by job step, in joules.
+	ConsumedEnergy int64 `protobuf:"varint,8,opt,name=consumed_energy,json=consumedEnergy,proto3" json:"consumed_energy,omitempty"`
+	// Trackable resources allocated to job step, e.g. cpu=2, mem=1G.
+	AllocTres            map[string]string `protobuf:"bytes,9,rep,name=alloc_tres,json=allocTres,proto3" json:"alloc_tres,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *JobStepUsage) Reset()         { *m = JobStepUsage{} }
+func (m *JobStepUsage) String() string { return proto.CompactTextString(m) }
+func (*JobStepUsage) ProtoMessage()    {}
+func (*JobStepUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a3bd06263c8633f, []int{37}
+}
+
+func (m *JobStepUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStepUsage.Unmarshal(m, b)
+}
+func (m *JobStepUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStepUsage.Marshal(b, m, deterministic)
+}
+func (m *JobStepUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStepUsage.Merge(m, src)
+}
+func (m *JobStepUsage) XXX_Size() int {
+	return xxx_messageInfo_JobStepUsage.Size(m)
+}
+func (m *JobStepUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStepUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStepUsage proto.InternalMessageInfo
+
+func (m *JobStepUsage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobStepUsage) GetElapsed() *duration.Duration {
+	if m != nil {
+		return m.Elapsed
+	}
+	return nil
+}
+
+func (m *JobStepUsage) GetCpuTime() *duration.Duration {
+	if m != nil {
+		return m.CpuTime
+	}
+	return nil
+}
+
+func (m *JobStepUsage) GetMaxRss() int64 {
+	if m != nil {
+		return m.MaxRss
+	}
+	return 0
+}
+
+func (m *JobStepUsage) GetAveRss() int64 {
+	if m != nil {
+		return m.AveRss
+	}
+	return 0
+}
+
+func (m *JobStepUsage) GetMaxDiskRead() int64 {
+	if m != nil {
+		return m.MaxDiskRead
+	}
+	return 0
+}
+
+func (m *JobStepUsage) GetMaxDiskWrite() int64 {
+	if m != nil {
+		return m.MaxDiskWrite
+	}
+	return 0
+}
+
+func (m *JobStepUsage) GetConsumedEnergy() int64 {
+	if m != nil {
+		return m.ConsumedEnergy
+	}
+	return 0
+}
+
+func (m *JobStepUsage) GetAllocTres() map[string]string {
+	if m != nil {
+		return m.AllocTres
+	}
+	return nil
+}
+
 // Chunk is an arbitrary amount of bytes.
 type Chunk struct {
 	Content              []byte   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
@@ -1313,7 +2673,7 @@ func (m *Chunk) Reset()         { *m = Chunk{} }
 func (m *Chunk) String() string { return proto.CompactTextString(m) }
 func (*Chunk) ProtoMessage()    {}
 func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{21}
+	return fileDescriptor_5a3bd06263c8633f, []int{38}
 }
 
 func (m *Chunk) XXX_Unmarshal(b []byte) error {
@@ -1354,7 +2714,7 @@ func (m *Feature) Reset()         { *m = Feature{} }
 func (m *Feature) String() string { return proto.CompactTextString(m) }
 func (*Feature) ProtoMessage()    {}
 func (*Feature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5a3bd06263c8633f, []int{22}
+	return fileDescriptor_5a3bd06263c8633f, []int{39}
 }
 
 func (m *Feature) XXX_Unmarshal(b []byte) error {
@@ -1397,8 +2757,12 @@ func (m *Feature) GetQuantity() int64 {
 }
 
 func init() {
+	proto.RegisterEnum("api.ContainerRuntime", ContainerRuntime_name, ContainerRuntime_value)
+	proto.RegisterEnum("api.MPIType", MPIType_name, MPIType_value)
 	proto.RegisterEnum("api.TailAction", TailAction_name, TailAction_value)
+	proto.RegisterEnum("api.LogStream", LogStream_name, LogStream_value)
 	proto.RegisterEnum("api.JobStatus", JobStatus_name, JobStatus_value)
+	proto.RegisterEnum("api.NodeState", NodeState_name, NodeState_value)
 	proto.RegisterType((*SubmitJobRequest)(nil), "api.SubmitJobRequest")
 	proto.RegisterType((*SubmitJobResponse)(nil), "api.SubmitJobResponse")
 	proto.RegisterType((*CancelJobRequest)(nil), "api.CancelJobRequest")
@@ -1407,19 +2771,39 @@ func init() {
 	proto.RegisterType((*JobInfoResponse)(nil), "api.JobInfoResponse")
 	proto.RegisterType((*JobStepsRequest)(nil), "api.JobStepsRequest")
 	proto.RegisterType((*JobStepsResponse)(nil), "api.JobStepsResponse")
+	proto.RegisterType((*JobUsageRequest)(nil), "api.JobUsageRequest")
+	proto.RegisterType((*JobUsageResponse)(nil), "api.JobUsageResponse")
+	proto.RegisterType((*JobFilter)(nil), "api.JobFilter")
+	proto.RegisterType((*ListJobsRequest)(nil), "api.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "api.ListJobsResponse")
 	proto.RegisterType((*OpenFileRequest)(nil), "api.OpenFileRequest")
+	proto.RegisterType((*JobLogsRequest)(nil), "api.JobLogsRequest")
+	proto.RegisterType((*JobLogsChunk)(nil), "api.JobLogsChunk")
 	proto.RegisterType((*ResourcesRequest)(nil), "api.ResourcesRequest")
 	proto.RegisterType((*ResourcesResponse)(nil), "api.ResourcesResponse")
 	proto.RegisterType((*PartitionsRequest)(nil), "api.PartitionsRequest")
 	proto.RegisterType((*PartitionsResponse)(nil), "api.PartitionsResponse")
+	proto.RegisterType((*NodesRequest)(nil), "api.NodesRequest")
+	proto.RegisterType((*NodesResponse)(nil), "api.NodesResponse")
+	proto.RegisterType((*PartitionStatusRequest)(nil), "api.PartitionStatusRequest")
+	proto.RegisterType((*PartitionStatusResponse)(nil), "api.PartitionStatusResponse")
+	proto.RegisterMapType((map[string]*PartitionStatusResponse)(nil), "api.PartitionStatusResponse.PartitionsEntry")
 	proto.RegisterType((*WorkloadInfoRequest)(nil), "api.WorkloadInfoRequest")
 	proto.RegisterType((*WorkloadInfoResponse)(nil), "api.WorkloadInfoResponse")
 	proto.RegisterType((*SubmitJobContainerRequest)(nil), "api.SubmitJobContainerRequest")
+	proto.RegisterMapType((map[string]string)(nil), "api.SubmitJobContainerRequest.EnvEntry")
 	proto.RegisterType((*SingularityOptions)(nil), "api.SingularityOptions")
+	proto.RegisterType((*EnrootOptions)(nil), "api.EnrootOptions")
+	proto.RegisterType((*PodmanHPCOptions)(nil), "api.PodmanHPCOptions")
+	proto.RegisterType((*CharliecloudOptions)(nil), "api.CharliecloudOptions")
+	proto.RegisterType((*MPIOptions)(nil), "api.MPIOptions")
 	proto.RegisterType((*SubmitJobContainerResponse)(nil), "api.SubmitJobContainerResponse")
 	proto.RegisterType((*TailFileRequest)(nil), "api.TailFileRequest")
 	proto.RegisterType((*JobInfo)(nil), "api.JobInfo")
+	proto.RegisterType((*NodeInfo)(nil), "api.NodeInfo")
 	proto.RegisterType((*JobStepInfo)(nil), "api.JobStepInfo")
+	proto.RegisterType((*JobStepUsage)(nil), "api.JobStepUsage")
+	proto.RegisterMapType((map[string]string)(nil), "api.JobStepUsage.AllocTresEntry")
 	proto.RegisterType((*Chunk)(nil), "api.Chunk")
 	proto.RegisterType((*Feature)(nil), "api.Feature")
 }
@@ -1427,92 +2811,179 @@ func init() {
 func init() { proto.RegisterFile("pkg/workload/api/workload.proto", fileDescriptor_5a3bd06263c8633f) }
 
 var fileDescriptor_5a3bd06263c8633f = []byte{
-	// 1355 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdb, 0x72, 0xdb, 0x36,
-	0x13, 0x8e, 0xce, 0xe4, 0xca, 0xb1, 0x69, 0xc4, 0x4e, 0x68, 0xe5, 0xff, 0x63, 0x97, 0xd3, 0x36,
-	0x6e, 0x66, 0x2a, 0xbb, 0x4e, 0x3a, 0x6d, 0xd3, 0xe9, 0x85, 0x47, 0x56, 0x5a, 0xa5, 0x8e, 0xec,
-	0xa1, 0xed, 0xe6, 0xa6, 0x33, 0x1a, 0x48, 0x84, 0x65, 0xc4, 0x14, 0xc0, 0x80, 0x60, 0xd2, 0xbc,
-	0x45, 0x6f, 0xfb, 0x14, 0x7d, 0x9d, 0x3e, 0x42, 0x6f, 0xfb, 0x06, 0x1d, 0x80, 0x20, 0x45, 0x49,
-	0x3e, 0x34, 0x77, 0xd8, 0x6f, 0x77, 0x71, 0xd8, 0xfd, 0xb0, 0xbb, 0xb0, 0x19, 0x5d, 0x8e, 0x77,
-	0xde, 0x73, 0x71, 0x19, 0x72, 0x1c, 0xec, 0xe0, 0x88, 0xe6, 0x42, 0x3b, 0x12, 0x5c, 0x72, 0x54,
-	0xc1, 0x11, 0x6d, 0x6d, 0x8e, 0x39, 0x1f, 0x87, 0x64, 0x47, 0x43, 0xc3, 0xe4, 0x7c, 0x47, 0xd2,
-	0x09, 0x89, 0x25, 0x9e, 0x44, 0xa9, 0x55, 0xeb, 0xd1, 0xbc, 0x41, 0x90, 0x08, 0x2c, 0x29, 0x67,
-	0xa9, 0xde, 0x23, 0xe0, 0x9c, 0x24, 0xc3, 0x09, 0x95, 0x2f, 0xf9, 0xd0, 0x27, 0x6f, 0x13, 0x12,
-	0x4b, 0x74, 0x1f, 0xea, 0xf1, 0x48, 0xd0, 0x48, 0xba, 0xa5, 0xad, 0xd2, 0xb6, 0xed, 0x1b, 0x09,
-	0xfd, 0x0f, 0xec, 0x08, 0x0b, 0x49, 0x95, 0xbb, 0x5b, 0xd6, 0xaa, 0x29, 0x80, 0x1e, 0x82, 0x3d,
-	0x0a, 0x29, 0x61, 0x72, 0x40, 0x03, 0xb7, 0xa2, 0xb5, 0x56, 0x0a, 0xf4, 0x02, 0xef, 0x09, 0xac,
-	0x16, 0x8e, 0x89, 0x23, 0xce, 0x62, 0x82, 0xd6, 0xa1, 0xfe, 0x86, 0x0f, 0x95, 0xb9, 0x3a, 0xa7,
-	0xe2, 0xd7, 0xde, 0xf0, 0x61, 0x2f, 0xf0, 0xbe, 0x00, 0xa7, 0x83, 0xd9, 0x88, 0x84, 0x85, 0x2b,
-	0x5d, 0x63, 0x7a, 0x0f, 0x56, 0x0b, 0xa6, 0xe9, 0xb6, 0xde, 0x63, 0x58, 0x7e, 0xc9, 0x87, 0x3d,
-	0x76, 0xce, 0x6f, 0xf1, 0x7e, 0x0a, 0x2b, 0xb9, 0xa1, 0xb9, 0xd2, 0x16, 0x54, 0x29, 0x3b, 0xe7,
-	0x6e, 0x69, 0xab, 0xb2, 0xdd, 0xdc, 0x5b, 0x6a, 0xe3, 0x88, 0xb6, 0x33, 0x1b, 0xad, 0xf1, 0xb6,
-	0xb5, 0xd3, 0x89, 0x24, 0x51, 0x7c, 0xcb, 0xf6, 0xfb, 0xe0, 0x4c, 0x2d, 0xcd, 0xfe, 0x5f, 0x82,
-	0xad, 0x4c, 0x63, 0x05, 0x9a, 0x43, 0x9c, 0xec, 0x10, 0x65, 0xa9, 0x0f, 0xb2, 0xde, 0x18, 0x37,
-	0xef, 0x33, 0x58, 0x39, 0x8a, 0x08, 0x7b, 0x41, 0x43, 0x92, 0x1d, 0x86, 0xa0, 0x1a, 0x61, 0x79,
-	0x61, 0x52, 0xa3, 0xd7, 0xde, 0x2e, 0x38, 0x3e, 0x89, 0x79, 0x22, 0x46, 0x24, 0xbf, 0xd4, 0x4c,
-	0xb2, 0x4a, 0x73, 0xc9, 0xf2, 0xfe, 0x2c, 0xc1, 0x6a, 0xc1, 0xc5, 0xdc, 0x6e, 0x0d, 0x6a, 0x8c,
-	0x07, 0x24, 0xce, 0xde, 0xa1, 0x05, 0xf4, 0x08, 0x60, 0x14, 0x25, 0xc7, 0x44, 0xf4, 0x79, 0x40,
-	0x74, 0xde, 0x2b, 0x7e, 0x01, 0x51, 0xfa, 0x09, 0x99, 0x64, 0xfa, 0x4a, 0xaa, 0x9f, 0x22, 0xa8,
-	0x05, 0xd6, 0x7b, 0x1c, 0x86, 0xa7, 0x74, 0x42, 0xdc, 0xaa, 0xd6, 0xe6, 0x32, 0xda, 0x06, 0xeb,
-	0x9c, 0x60, 0x99, 0x08, 0x12, 0xbb, 0xb5, 0x42, 0xcc, 0x5f, 0xa4, 0xa0, 0x9f, 0x6b, 0x55, 0xaa,
-	0x8f, 0xb3, 0xeb, 0x67, 0x8f, 0xf4, 0xf6, 0x00, 0x15, 0x41, 0xf3, 0x8c, 0xb9, 0xa7, 0x57, 0x66,
-	0x9f, 0xbe, 0x0e, 0xf7, 0x5e, 0x9b, 0x9f, 0x54, 0xe0, 0x88, 0xf7, 0x0b, 0xac, 0xcd, 0xc2, 0x66,
-	0x33, 0x04, 0x55, 0x86, 0x27, 0x24, 0x8b, 0xb7, 0x5a, 0x23, 0x17, 0x1a, 0xef, 0x88, 0x88, 0xa7,
-	0xdf, 0x20, 0x13, 0x91, 0x03, 0x95, 0xc4, 0xd0, 0xbf, 0xe2, 0xab, 0xa5, 0xf7, 0x47, 0x19, 0x36,
-	0x72, 0xea, 0x77, 0x38, 0x93, 0x98, 0x32, 0x22, 0x0a, 0x59, 0xa2, 0x13, 0x3c, 0x26, 0xfd, 0xe9,
-	0x11, 0x53, 0x60, 0x9a, 0x8f, 0xf2, 0xf5, 0xf9, 0xa8, 0xdc, 0x92, 0x8f, 0xea, 0x8d, 0xf9, 0xa8,
-	0xcd, 0xe5, 0x63, 0x26, 0x74, 0xf5, 0x1b, 0xbf, 0x78, 0x63, 0xf6, 0x8b, 0xa3, 0xaf, 0xa0, 0xc1,
-	0x23, 0x9d, 0x08, 0xd7, 0xda, 0x2a, 0x6d, 0x37, 0xf7, 0x1e, 0xe8, 0x4c, 0x9e, 0x50, 0x36, 0x4e,
-	0x42, 0x2c, 0xa8, 0xfc, 0x70, 0x94, 0xaa, 0xfd, 0xcc, 0xce, 0xfb, 0xbd, 0x0c, 0x68, 0x51, 0xaf,
-	0x82, 0x88, 0xa3, 0xc8, 0x84, 0x43, 0x2d, 0xd1, 0xa7, 0x70, 0x17, 0x87, 0x21, 0x7f, 0x7f, 0xc6,
-	0x62, 0x3a, 0x66, 0x24, 0xd0, 0x01, 0xb1, 0xfc, 0x59, 0x50, 0x85, 0x6b, 0x48, 0x59, 0x10, 0xbb,
-	0x15, 0x9d, 0xf3, 0x54, 0x50, 0xcf, 0x1d, 0x85, 0x04, 0x8b, 0x2e, 0x7b, 0xa7, 0x83, 0x61, 0xf9,
-	0xb9, 0xac, 0x74, 0xe7, 0xf8, 0x92, 0xf8, 0x9c, 0x4b, 0x1d, 0x0a, 0xcb, 0xcf, 0x65, 0xa5, 0xbb,
-	0xe0, 0xb1, 0xd4, 0x99, 0x49, 0x23, 0x91, 0xcb, 0xea, 0x86, 0x34, 0x1a, 0xe9, 0x10, 0x58, 0xbe,
-	0x5a, 0x2a, 0x24, 0xa2, 0x81, 0x7e, 0xb9, 0xe5, 0xab, 0xa5, 0x22, 0x09, 0xe3, 0xc7, 0x82, 0xbe,
-	0x8b, 0x5d, 0x5b, 0xa3, 0x99, 0xa8, 0x13, 0x20, 0xa8, 0xc4, 0xc3, 0x90, 0xb8, 0x90, 0x9e, 0x9a,
-	0xc9, 0xde, 0x53, 0x68, 0x5d, 0xc5, 0x96, 0x9b, 0x2b, 0x66, 0x1f, 0x56, 0x4e, 0x31, 0x0d, 0x8b,
-	0x65, 0xe2, 0x31, 0xd4, 0xf1, 0x28, 0xff, 0xfb, 0xcb, 0x7b, 0x2b, 0x3a, 0x19, 0xca, 0x6a, 0x5f,
-	0xc3, 0xbe, 0x51, 0xe7, 0xf5, 0xa4, 0x5c, 0xa8, 0x27, 0x7f, 0x55, 0xa1, 0x61, 0xaa, 0x1e, 0x5a,
-	0x86, 0xb2, 0x39, 0xce, 0xf6, 0xcb, 0x34, 0x40, 0x0f, 0xa0, 0x91, 0xc4, 0x44, 0xa8, 0x3b, 0xa4,
-	0x2e, 0x75, 0x25, 0xf6, 0x82, 0xfc, 0xa3, 0x54, 0x0a, 0x1f, 0xe5, 0x21, 0xd8, 0xe4, 0x37, 0x2a,
-	0x07, 0xa3, 0x8c, 0x89, 0xb6, 0x6f, 0x29, 0xa0, 0xa3, 0x78, 0xf8, 0x39, 0xd4, 0x63, 0x89, 0x65,
-	0x12, 0xeb, 0xd0, 0x2f, 0xef, 0x2d, 0x4f, 0x0b, 0xa1, 0x42, 0x7d, 0xa3, 0x45, 0xdf, 0x43, 0x33,
-	0xd6, 0x21, 0x19, 0xa8, 0xe6, 0xa6, 0x73, 0xd1, 0xdc, 0x6b, 0xb5, 0xd3, 0xc6, 0xd6, 0xce, 0x1a,
-	0x5b, 0xfb, 0x34, 0xeb, 0x7c, 0x3e, 0xa4, 0xe6, 0x9a, 0xd0, 0xdf, 0x01, 0xc4, 0x12, 0x0b, 0xe3,
-	0xdb, 0xb8, 0xd5, 0xd7, 0xd6, 0xd6, 0xda, 0xf5, 0x19, 0x58, 0x22, 0x61, 0xa9, 0x63, 0xca, 0xe8,
-	0x8d, 0x05, 0xc7, 0x03, 0xd3, 0x4d, 0xfd, 0x86, 0x48, 0x98, 0xf6, 0xfa, 0x16, 0x40, 0x79, 0x0c,
-	0x42, 0x3a, 0xa1, 0x52, 0x67, 0xfe, 0x46, 0x3f, 0x5b, 0x19, 0x1f, 0x2a, 0x5b, 0xb4, 0x09, 0x4d,
-	0xd5, 0xe2, 0x29, 0x1b, 0x0f, 0x02, 0x2a, 0x34, 0x33, 0x6c, 0x1f, 0x0c, 0x74, 0x40, 0x85, 0x0a,
-	0x7d, 0x2c, 0x83, 0x01, 0x4f, 0xa4, 0xdb, 0x34, 0x8d, 0x59, 0x06, 0x47, 0x89, 0xcc, 0x14, 0x44,
-	0x08, 0x77, 0x29, 0x57, 0x74, 0x85, 0x98, 0xfd, 0xce, 0x77, 0xaf, 0xf8, 0xce, 0xaa, 0xa2, 0x0c,
-	0x42, 0x1a, 0x4b, 0x77, 0x39, 0xcd, 0x8e, 0x02, 0x0e, 0x69, 0x2c, 0xd1, 0xff, 0x01, 0x86, 0x58,
-	0x8e, 0x2e, 0x06, 0x8a, 0xf4, 0xee, 0x4a, 0xea, 0xab, 0x91, 0x9f, 0x78, 0x2c, 0xb5, 0x6f, 0x32,
-	0x19, 0xa4, 0xe5, 0xc9, 0x31, 0xbe, 0xc9, 0xa4, 0xaf, 0x2b, 0xd4, 0x06, 0x58, 0x58, 0x08, 0xfc,
-	0x41, 0x91, 0x64, 0x35, 0x2d, 0x90, 0x5a, 0xee, 0x05, 0xde, 0xdf, 0x25, 0x68, 0x16, 0x7a, 0xdd,
-	0x02, 0xbd, 0x32, 0x16, 0x95, 0xaf, 0x63, 0x91, 0xa2, 0x57, 0xed, 0x4a, 0x16, 0x55, 0x6f, 0x64,
-	0xd1, 0x2c, 0x11, 0x6a, 0x1f, 0x43, 0x84, 0xaf, 0xc1, 0x22, 0x2c, 0xf8, 0xaf, 0xec, 0x6b, 0x10,
-	0x16, 0x28, 0xc9, 0xfb, 0x04, 0x6a, 0x9d, 0x8b, 0x84, 0x5d, 0xaa, 0x4a, 0x30, 0xe2, 0x4c, 0x12,
-	0x96, 0x0e, 0x54, 0x4b, 0x7e, 0x26, 0x7a, 0x27, 0xd0, 0x30, 0x9d, 0xee, 0x23, 0xfb, 0x4c, 0x0b,
-	0xac, 0xb7, 0x09, 0x66, 0x92, 0xca, 0x0f, 0xa6, 0x03, 0xe4, 0xf2, 0x93, 0x36, 0xc0, 0xf4, 0x9f,
-	0x23, 0x1b, 0x6a, 0x27, 0xea, 0x25, 0xce, 0x1d, 0xb4, 0xae, 0x7a, 0x3e, 0x0e, 0x4e, 0x79, 0x97,
-	0x05, 0xfb, 0x2c, 0xe8, 0x84, 0x3c, 0x26, 0x4e, 0xe9, 0xc9, 0xaf, 0x60, 0xe7, 0xe1, 0x42, 0x77,
-	0xc1, 0xee, 0x1c, 0xbd, 0x3a, 0x3e, 0xec, 0x9e, 0x76, 0x0f, 0x9c, 0x3b, 0x5a, 0xdc, 0xef, 0x77,
-	0xba, 0x87, 0x87, 0xdd, 0x03, 0xa7, 0x84, 0x00, 0xea, 0x2f, 0xf6, 0x7b, 0x6a, 0x5d, 0x46, 0x4d,
-	0x68, 0x9c, 0xf6, 0x5e, 0x75, 0x8f, 0xce, 0x4e, 0x9d, 0x8a, 0x12, 0x8e, 0xbb, 0xfd, 0x83, 0x5e,
-	0xff, 0x47, 0xa7, 0xaa, 0x84, 0xb3, 0xfe, 0xcf, 0xfd, 0xa3, 0xd7, 0x7d, 0x07, 0xf6, 0xfe, 0xa9,
-	0xc2, 0x4a, 0xd6, 0x58, 0x5f, 0x61, 0x86, 0xc7, 0x44, 0xa0, 0xe7, 0x60, 0xe7, 0x45, 0x0e, 0xad,
-	0xa7, 0x6d, 0x62, 0x6e, 0x08, 0x6d, 0xdd, 0x9f, 0x87, 0x4d, 0x09, 0x3c, 0x03, 0xb4, 0x58, 0x20,
-	0xd1, 0xa3, 0x59, 0xeb, 0xf9, 0x3e, 0xdb, 0xda, 0xbc, 0x56, 0x6f, 0xb6, 0x7d, 0x0e, 0x76, 0x3e,
-	0x49, 0x9a, 0x2b, 0xcd, 0x0f, 0xa1, 0xe6, 0x4a, 0x0b, 0x03, 0x27, 0x7a, 0x36, 0xad, 0x96, 0xf7,
-	0x66, 0x26, 0x46, 0xe3, 0xb7, 0x36, 0x0b, 0x1a, 0xaf, 0x6f, 0xc0, 0xca, 0xc6, 0x43, 0xb4, 0x56,
-	0x9c, 0x01, 0xb3, 0xe9, 0xa6, 0xb5, 0x3e, 0x87, 0x1a, 0xc7, 0x36, 0x58, 0xd9, 0x50, 0x68, 0x1c,
-	0xe7, 0x66, 0xc4, 0x16, 0xa4, 0x17, 0x55, 0xe4, 0xdb, 0x2d, 0xa1, 0x5d, 0xb0, 0xb2, 0xee, 0x60,
-	0xec, 0xe7, 0x9a, 0x45, 0xd1, 0x7e, 0xbb, 0xb4, 0x5b, 0x52, 0xc1, 0xc8, 0x87, 0x43, 0x13, 0x8c,
-	0xf9, 0xf9, 0xd2, 0x04, 0x63, 0x71, 0x86, 0xfc, 0x01, 0x60, 0x3a, 0x92, 0xa1, 0xd4, 0x6a, 0x61,
-	0x70, 0x6b, 0x3d, 0x58, 0xc0, 0x8d, 0x7b, 0x07, 0x96, 0x8a, 0x63, 0x18, 0x72, 0xb5, 0xe1, 0x15,
-	0x03, 0x5b, 0x6b, 0xe3, 0x0a, 0x4d, 0xba, 0xc9, 0xb0, 0xae, 0xbf, 0xe5, 0xd3, 0x7f, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0x93, 0x49, 0x4b, 0x26, 0x44, 0x0d, 0x00, 0x00,
+	// 2740 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4f, 0x73, 0x1b, 0xc7,
+	0xb1, 0x17, 0xb0, 0x04, 0xb0, 0xdb, 0x00, 0xc1, 0xe5, 0x48, 0xa4, 0x20, 0xc8, 0xcf, 0xa2, 0xf1,
+	0x6c, 0x8b, 0x8f, 0xcf, 0xa6, 0x64, 0xca, 0xef, 0xc5, 0x56, 0x9c, 0x4a, 0xc1, 0x00, 0x64, 0xc1,
+	0x01, 0x01, 0xd4, 0x12, 0xb4, 0x9d, 0x4a, 0xaa, 0x36, 0x43, 0xec, 0x10, 0x5a, 0x71, 0xb1, 0xbb,
+	0xde, 0x3f, 0x94, 0xe8, 0x2f, 0x91, 0x9c, 0x72, 0xce, 0x2d, 0x27, 0x57, 0x0e, 0xf9, 0x1e, 0xf9,
+	0x1c, 0x39, 0xe4, 0x9a, 0x7b, 0xaa, 0x67, 0x66, 0xff, 0x81, 0xff, 0xec, 0xdb, 0xf6, 0xaf, 0xbb,
+	0x67, 0x7a, 0x66, 0xba, 0x7b, 0x7a, 0x7a, 0xe1, 0x91, 0x7f, 0xb6, 0x78, 0xf2, 0xc6, 0x0b, 0xce,
+	0x1c, 0x8f, 0x5a, 0x4f, 0xa8, 0x6f, 0xa7, 0xc4, 0xbe, 0x1f, 0x78, 0x91, 0x47, 0x14, 0xea, 0xdb,
+	0xed, 0x47, 0x0b, 0xcf, 0x5b, 0x38, 0xec, 0x09, 0x87, 0x4e, 0xe2, 0xd3, 0x27, 0x91, 0xbd, 0x64,
+	0x61, 0x44, 0x97, 0xbe, 0x90, 0x6a, 0xbf, 0xbb, 0x2a, 0x60, 0xc5, 0x01, 0x8d, 0x6c, 0xcf, 0x15,
+	0xfc, 0x0e, 0x03, 0xfd, 0x28, 0x3e, 0x59, 0xda, 0xd1, 0xd7, 0xde, 0x89, 0xc1, 0xbe, 0x8f, 0x59,
+	0x18, 0x91, 0x6d, 0xa8, 0x86, 0xf3, 0xc0, 0xf6, 0xa3, 0x56, 0x69, 0xa7, 0xb4, 0xab, 0x19, 0x92,
+	0x22, 0xef, 0x80, 0xe6, 0xd3, 0x20, 0xb2, 0x51, 0xbd, 0x55, 0xe6, 0xac, 0x0c, 0x20, 0x0f, 0x41,
+	0x9b, 0x3b, 0x36, 0x73, 0x23, 0xd3, 0xb6, 0x5a, 0x0a, 0xe7, 0xaa, 0x02, 0x18, 0x5a, 0x9d, 0x3d,
+	0xd8, 0xcc, 0x4d, 0x13, 0xfa, 0x9e, 0x1b, 0x32, 0xb2, 0x05, 0xd5, 0xd7, 0xde, 0x09, 0x8a, 0xe3,
+	0x3c, 0x8a, 0x51, 0x79, 0xed, 0x9d, 0x0c, 0xad, 0xce, 0xff, 0x80, 0xde, 0xa3, 0xee, 0x9c, 0x39,
+	0x39, 0x93, 0xae, 0x11, 0xbd, 0x0b, 0x9b, 0x39, 0x51, 0x31, 0x6c, 0xe7, 0x31, 0x34, 0xbf, 0xf6,
+	0x4e, 0x86, 0xee, 0xa9, 0x77, 0x8b, 0xf6, 0x33, 0xd8, 0x48, 0x05, 0xa5, 0x49, 0x3b, 0xb0, 0x66,
+	0xbb, 0xa7, 0x5e, 0xab, 0xb4, 0xa3, 0xec, 0xd6, 0x0f, 0x1a, 0xfb, 0xd4, 0xb7, 0xf7, 0x13, 0x19,
+	0xce, 0xe9, 0xec, 0x72, 0xa5, 0xa3, 0x88, 0xf9, 0xe1, 0x2d, 0xc3, 0x77, 0x41, 0xcf, 0x24, 0xe5,
+	0xf8, 0x1f, 0x83, 0x86, 0xa2, 0x21, 0x82, 0x72, 0x12, 0x3d, 0x99, 0x04, 0x25, 0xf9, 0x44, 0xea,
+	0x6b, 0xa9, 0x26, 0x27, 0x3b, 0x0e, 0xe9, 0x82, 0xdd, 0x32, 0xd9, 0x1f, 0x4b, 0xa0, 0x67, 0xa2,
+	0x72, 0xb6, 0xc7, 0x50, 0xc9, 0xcf, 0xb4, 0x99, 0x9f, 0x49, 0x48, 0x0a, 0x3e, 0xf9, 0x00, 0x9a,
+	0x73, 0x3f, 0x36, 0xd9, 0xe9, 0xa9, 0x3d, 0xb7, 0x99, 0x3b, 0xbf, 0xe0, 0xc7, 0x5b, 0x31, 0xd6,
+	0xe7, 0x7e, 0x3c, 0x48, 0x41, 0xf2, 0xbf, 0xb0, 0xb9, 0x64, 0x4b, 0x2f, 0xb8, 0xc8, 0x4b, 0x2a,
+	0x5c, 0x52, 0x17, 0x8c, 0x4c, 0xb8, 0xf3, 0xaf, 0x12, 0x68, 0x5f, 0x7b, 0x27, 0x2f, 0x6c, 0x27,
+	0x62, 0x01, 0xf9, 0x10, 0xaa, 0x61, 0x44, 0x23, 0x26, 0x6c, 0x69, 0x1e, 0x34, 0x33, 0x5b, 0x68,
+	0x14, 0x87, 0x86, 0xe4, 0x92, 0x77, 0x01, 0x52, 0x97, 0x0a, 0x5b, 0xe5, 0x1d, 0x65, 0x57, 0x33,
+	0x72, 0x08, 0xb9, 0x07, 0x95, 0x38, 0x64, 0x41, 0xd8, 0x52, 0x38, 0x4b, 0x10, 0xe4, 0x11, 0xd4,
+	0x5d, 0xba, 0x64, 0xa6, 0x1f, 0xb0, 0x53, 0xfb, 0x6d, 0x6b, 0x8d, 0x7b, 0x1f, 0x20, 0x34, 0xe5,
+	0x08, 0xe9, 0xc1, 0x46, 0xc8, 0xfd, 0x2f, 0x62, 0x96, 0x19, 0xda, 0xee, 0x9c, 0xb5, 0x2a, 0x3b,
+	0xa5, 0xdd, 0xfa, 0x41, 0x7b, 0x5f, 0x04, 0xc8, 0x7e, 0x12, 0x20, 0xfb, 0xb3, 0x24, 0x82, 0x8c,
+	0x66, 0xaa, 0x72, 0x84, 0x1a, 0x45, 0x0f, 0xaf, 0xae, 0x78, 0x78, 0x0c, 0x1b, 0x23, 0x3b, 0x44,
+	0xff, 0x4e, 0xfd, 0xe2, 0x43, 0xa8, 0x9e, 0xf2, 0xd5, 0xf3, 0xa3, 0xaa, 0x67, 0x6b, 0x16, 0x7b,
+	0x62, 0x48, 0x2e, 0x8e, 0xeb, 0xd3, 0x05, 0x33, 0x43, 0xfb, 0x07, 0x26, 0x37, 0x5e, 0x45, 0xe0,
+	0xc8, 0xfe, 0x81, 0x91, 0xff, 0xc2, 0x0d, 0x59, 0x30, 0x33, 0xf2, 0xce, 0x98, 0x2b, 0xe3, 0x8a,
+	0x8b, 0xcf, 0x10, 0xe8, 0xfc, 0x1e, 0xf4, 0x6c, 0xda, 0xcc, 0x89, 0x5f, 0x7b, 0x27, 0xe1, 0xd5,
+	0x4e, 0x8c, 0x1c, 0xf2, 0x21, 0x6c, 0xb8, 0xec, 0x6d, 0x64, 0xe6, 0x46, 0x16, 0xf1, 0xbc, 0x8e,
+	0xf0, 0x34, 0x1d, 0xfd, 0x03, 0xd8, 0x98, 0xf8, 0xcc, 0x7d, 0x61, 0x3b, 0xa9, 0xff, 0x11, 0x58,
+	0xf3, 0x69, 0xf4, 0x4a, 0xa6, 0x06, 0xfe, 0xdd, 0xf9, 0x6b, 0x89, 0x87, 0xdc, 0xc8, 0x5b, 0xdc,
+	0x12, 0x13, 0xc2, 0x0d, 0x02, 0x46, 0x97, 0x7c, 0xbe, 0xc4, 0x0d, 0x46, 0xde, 0xe2, 0x88, 0xa3,
+	0x86, 0xe4, 0x62, 0x0a, 0x3a, 0xf5, 0x1c, 0xc7, 0x7b, 0xc3, 0x57, 0xac, 0x1a, 0x92, 0xc2, 0x83,
+	0xe6, 0xa7, 0x67, 0x9e, 0x5c, 0xa0, 0x2f, 0xad, 0xf1, 0xb1, 0x81, 0x43, 0x5f, 0x22, 0x82, 0xdb,
+	0x15, 0x51, 0xdb, 0x31, 0x1d, 0xdb, 0x65, 0x21, 0x3f, 0x63, 0xc5, 0xd0, 0x10, 0x19, 0x21, 0xd0,
+	0x99, 0x42, 0x43, 0x1a, 0xda, 0x7b, 0x15, 0xbb, 0x67, 0x39, 0x7b, 0x4a, 0x37, 0xda, 0xd3, 0x82,
+	0xda, 0xdc, 0x73, 0x23, 0xe6, 0x46, 0xdc, 0xf0, 0x86, 0x91, 0x90, 0x9d, 0xa7, 0xa0, 0x1b, 0x2c,
+	0xf4, 0xe2, 0x60, 0xce, 0xd2, 0xc5, 0x17, 0x12, 0x65, 0x69, 0x25, 0x51, 0x76, 0xfe, 0x56, 0x82,
+	0xcd, 0x9c, 0x8a, 0x3c, 0xb4, 0x7b, 0x50, 0x71, 0x3d, 0x8b, 0xc7, 0x07, 0xdf, 0x2f, 0x4e, 0x60,
+	0x38, 0xcc, 0xfd, 0x78, 0xca, 0x82, 0xb1, 0x67, 0x09, 0xdf, 0x50, 0x8c, 0x1c, 0x82, 0xfc, 0x25,
+	0x5b, 0x26, 0x7c, 0x45, 0xf0, 0x33, 0x84, 0xb4, 0x41, 0x7d, 0x43, 0x1d, 0x07, 0x7d, 0x5a, 0x6e,
+	0x56, 0x4a, 0x93, 0x5d, 0x50, 0x4f, 0x19, 0x8d, 0xe2, 0x80, 0x6f, 0x54, 0xe6, 0x2a, 0x2f, 0x04,
+	0x68, 0xa4, 0x5c, 0x4c, 0xb3, 0xd3, 0x34, 0x04, 0xe5, 0x22, 0x3b, 0x07, 0x40, 0xf2, 0xa0, 0x5c,
+	0xc6, 0xca, 0xd2, 0x95, 0xe2, 0xd2, 0x3f, 0x82, 0x06, 0x9a, 0xf5, 0x13, 0x37, 0xea, 0x53, 0x58,
+	0x97, 0xd2, 0x72, 0xf0, 0xff, 0xce, 0xf6, 0x08, 0xcd, 0x5d, 0xe7, 0xe6, 0xa2, 0x08, 0x77, 0x6d,
+	0xc1, 0xeb, 0x7c, 0x03, 0xdb, 0xa9, 0x5d, 0x32, 0xb9, 0xfc, 0x94, 0xd9, 0x6e, 0xcb, 0x3c, 0x9d,
+	0xbf, 0x94, 0xe1, 0xfe, 0xa5, 0x81, 0xb3, 0xc3, 0xe3, 0xf9, 0x4b, 0x8e, 0x2a, 0x08, 0xf2, 0x1e,
+	0x34, 0x7c, 0xe6, 0x5a, 0xb6, 0xbb, 0x30, 0x79, 0x3c, 0x8a, 0xe3, 0xab, 0x4b, 0x0c, 0x43, 0x16,
+	0x45, 0x82, 0xd8, 0x75, 0x53, 0x11, 0x71, 0x82, 0x75, 0x89, 0x71, 0x91, 0x51, 0xc1, 0xae, 0x35,
+	0xbe, 0xf2, 0x8f, 0xf8, 0xca, 0xaf, 0xb1, 0x26, 0xc3, 0xc3, 0x81, 0x1b, 0x05, 0x17, 0xf9, 0x55,
+	0xb4, 0x7f, 0x07, 0x1b, 0x2b, 0x6c, 0xa2, 0x83, 0x72, 0xc6, 0x2e, 0xa4, 0xe9, 0xf8, 0x49, 0x0e,
+	0xa0, 0x72, 0x4e, 0x9d, 0x58, 0x38, 0x5c, 0xfd, 0xe0, 0x9d, 0x9b, 0x66, 0x33, 0x84, 0xe8, 0xf3,
+	0xf2, 0x67, 0xa5, 0xce, 0x16, 0xdc, 0xfd, 0x56, 0x16, 0x29, 0xb9, 0xeb, 0xb7, 0xf3, 0xf7, 0x12,
+	0xdc, 0x2b, 0xe2, 0x72, 0xdb, 0x08, 0xac, 0x61, 0x8e, 0x4e, 0x72, 0x09, 0x7e, 0x63, 0xa4, 0x9d,
+	0xb3, 0x20, 0xcc, 0x4a, 0x8c, 0x84, 0x44, 0x3b, 0x63, 0x59, 0x5a, 0x28, 0x06, 0x7e, 0x92, 0x4f,
+	0x40, 0x0d, 0x62, 0x97, 0x97, 0x3c, 0x7c, 0x63, 0x9a, 0x07, 0x5b, 0xdc, 0xd4, 0x9e, 0xe7, 0x46,
+	0xd4, 0x76, 0x59, 0x60, 0x08, 0xae, 0x91, 0x8a, 0xa1, 0xd3, 0x3b, 0xf6, 0x9c, 0xb9, 0xe1, 0x75,
+	0x4e, 0x9f, 0x70, 0x3b, 0x3f, 0x56, 0xe0, 0x41, 0x5a, 0xb3, 0x64, 0x23, 0x66, 0xbe, 0x64, 0x2f,
+	0xe9, 0x82, 0x8d, 0x33, 0xfb, 0x33, 0x20, 0x0b, 0xe6, 0xf2, 0xf5, 0xc1, 0xac, 0xdc, 0x12, 0xcc,
+	0x6b, 0x37, 0x06, 0x73, 0x65, 0x25, 0x98, 0x0b, 0xbe, 0x5d, 0xbd, 0xb1, 0x36, 0xab, 0x15, 0x6f,
+	0x2e, 0xf2, 0x09, 0xd4, 0x3c, 0x5f, 0x78, 0x97, 0xca, 0xcf, 0xfb, 0x3e, 0xdf, 0x91, 0x23, 0xdb,
+	0x5d, 0xc4, 0x0e, 0x0d, 0xec, 0xe8, 0x62, 0x22, 0xd8, 0x46, 0x22, 0x47, 0x9e, 0x40, 0x4d, 0xee,
+	0x68, 0x4b, 0xdb, 0x29, 0x5d, 0xbf, 0xef, 0x89, 0x14, 0xd9, 0x83, 0x2a, 0x73, 0x03, 0xcf, 0x8b,
+	0x5a, 0xc0, 0xa7, 0x20, 0x5c, 0x7e, 0xc0, 0xa1, 0x64, 0x74, 0x29, 0x41, 0x9e, 0x81, 0xe6, 0x7b,
+	0xd6, 0x92, 0xba, 0x2f, 0xa7, 0xbd, 0x56, 0x9d, 0x8b, 0x8b, 0xe1, 0xa7, 0x09, 0x9a, 0x68, 0x64,
+	0x72, 0xe4, 0x0b, 0x68, 0xcc, 0x5f, 0xd1, 0xc0, 0xb1, 0xd9, 0xdc, 0xf1, 0x62, 0xab, 0xd5, 0xe0,
+	0x7a, 0x2d, 0x61, 0x56, 0x8e, 0x91, 0xa8, 0x16, 0xa4, 0xc9, 0x7b, 0xa0, 0x2c, 0x7d, 0xbb, 0xb5,
+	0xce, 0x95, 0x36, 0xb8, 0xd2, 0xe1, 0x74, 0x98, 0xc8, 0x22, 0x4f, 0xdc, 0x00, 0xcb, 0x25, 0x75,
+	0xad, 0x56, 0x93, 0xe7, 0x86, 0x84, 0x44, 0x2f, 0xa6, 0xc1, 0x22, 0x6c, 0x6d, 0x70, 0x98, 0x7f,
+	0x93, 0xcf, 0x41, 0x61, 0xee, 0x79, 0x4b, 0xe7, 0x1e, 0xf6, 0x58, 0xec, 0xe7, 0x75, 0xbe, 0xb4,
+	0x3f, 0x70, 0xcf, 0x45, 0xa0, 0xa2, 0x4e, 0xfb, 0xff, 0x41, 0x4d, 0x80, 0x2b, 0x42, 0xf3, 0x5e,
+	0x3e, 0x34, 0xb5, 0x7c, 0xf0, 0xfd, 0xa9, 0x0c, 0xe4, 0xf2, 0x99, 0xe1, 0x10, 0xd4, 0xf7, 0x93,
+	0x21, 0xa8, 0xef, 0x93, 0xf7, 0x61, 0x9d, 0xe2, 0x65, 0x7a, 0xec, 0x86, 0xf6, 0xc2, 0x65, 0x16,
+	0x1f, 0x4a, 0x35, 0x8a, 0x20, 0x4e, 0x74, 0x62, 0xbb, 0x56, 0x5a, 0x68, 0x71, 0x02, 0x5d, 0x70,
+	0xee, 0x30, 0x1a, 0x0c, 0xdc, 0x73, 0xee, 0xa0, 0xaa, 0x91, 0xd2, 0xc8, 0x3b, 0xa5, 0x67, 0xcc,
+	0xc0, 0x53, 0xae, 0x08, 0x5e, 0x42, 0x23, 0xef, 0x95, 0x17, 0x46, 0x3c, 0x5a, 0x64, 0xe5, 0x94,
+	0xd0, 0x68, 0xa1, 0xed, 0xcf, 0xb9, 0x5b, 0xaa, 0x06, 0x7e, 0x22, 0xe2, 0xdb, 0x16, 0xf7, 0x46,
+	0xd5, 0xc0, 0x4f, 0xdc, 0x7d, 0xd7, 0x9b, 0x06, 0xf6, 0x79, 0xc8, 0x1d, 0x4e, 0x35, 0x12, 0x92,
+	0x07, 0x45, 0x60, 0x47, 0xf4, 0xc4, 0x61, 0xdc, 0xb7, 0x54, 0x23, 0xa5, 0x31, 0xf1, 0xac, 0x17,
+	0x7c, 0x2c, 0x7f, 0x8a, 0xa5, 0xe2, 0x29, 0x6e, 0x43, 0x75, 0xe9, 0xc5, 0x6e, 0x94, 0xa4, 0x7e,
+	0x49, 0xa1, 0x06, 0x3e, 0xbc, 0x2c, 0x3b, 0x90, 0xc5, 0x57, 0x42, 0x62, 0xc8, 0x71, 0x99, 0x97,
+	0x9e, 0xbc, 0x5c, 0x55, 0x23, 0x03, 0x90, 0x1b, 0xb0, 0x25, 0xf5, 0x73, 0xdb, 0x91, 0x01, 0x05,
+	0xab, 0xab, 0x2b, 0x56, 0xbf, 0x06, 0x7d, 0xd5, 0xd3, 0x79, 0x56, 0xf4, 0x9c, 0x78, 0x29, 0xef,
+	0x3e, 0xcd, 0x48, 0xc8, 0xbc, 0x7d, 0xe5, 0xa2, 0x7d, 0x3a, 0x28, 0x0b, 0x3f, 0x96, 0x05, 0x14,
+	0x7e, 0x12, 0x5d, 0xb8, 0xb9, 0xb0, 0x15, 0x3f, 0x3b, 0x17, 0x70, 0xf7, 0x8a, 0xe8, 0xb8, 0x61,
+	0x9b, 0x52, 0xb7, 0x28, 0xaf, 0xb8, 0x45, 0xba, 0x1c, 0xa5, 0xb8, 0x1c, 0xfe, 0x9a, 0x64, 0x51,
+	0xe6, 0x30, 0x92, 0xea, 0xbc, 0x06, 0xc8, 0x62, 0x0c, 0xa5, 0xdc, 0x88, 0x86, 0x67, 0x49, 0xfd,
+	0x23, 0x29, 0xd2, 0x81, 0x06, 0xff, 0x28, 0x96, 0x40, 0x05, 0x0c, 0xeb, 0xdd, 0xe8, 0xc2, 0x17,
+	0x33, 0x37, 0x65, 0x3e, 0x3f, 0x9c, 0x0e, 0x67, 0x17, 0x3e, 0x33, 0x38, 0xa7, 0xf3, 0x0c, 0xda,
+	0x57, 0x85, 0xdf, 0xcd, 0xef, 0xd0, 0x31, 0x6c, 0xcc, 0xa8, 0xed, 0xe4, 0x8b, 0xdf, 0xc7, 0x50,
+	0xa5, 0xf3, 0xb4, 0x7c, 0x68, 0xca, 0x54, 0x81, 0x52, 0x5d, 0x0e, 0x1b, 0x92, 0x9d, 0x56, 0xc9,
+	0xe5, 0x5c, 0x95, 0xfc, 0xef, 0x35, 0xa8, 0xc9, 0x32, 0x9c, 0x34, 0xa1, 0x2c, 0xa7, 0xd3, 0x8c,
+	0xb2, 0x6d, 0x91, 0xfb, 0x50, 0xc3, 0x97, 0x0c, 0xda, 0x20, 0x54, 0xaa, 0x48, 0x0e, 0xad, 0xf4,
+	0x8a, 0x54, 0x72, 0x57, 0xe4, 0x43, 0xd0, 0xd8, 0x5b, 0x3b, 0x32, 0xe7, 0xc9, 0x35, 0xa1, 0x19,
+	0x2a, 0x02, 0x3d, 0xdc, 0x0c, 0xf9, 0xd0, 0x8a, 0x45, 0xf1, 0x7b, 0xcd, 0x43, 0x2b, 0x0e, 0xc9,
+	0x2f, 0xa1, 0x2e, 0x9e, 0x37, 0x26, 0x4f, 0xe3, 0xd5, 0x5b, 0x5f, 0x43, 0x20, 0xc4, 0x11, 0x20,
+	0x9f, 0x03, 0x84, 0x11, 0x0d, 0xa4, 0x6e, 0xed, 0x56, 0x5d, 0x8d, 0x4b, 0x73, 0xd5, 0x4f, 0xf9,
+	0x9d, 0x2d, 0x14, 0xc5, 0x75, 0xf3, 0xe0, 0x92, 0x62, 0x5f, 0xf6, 0x28, 0xf8, 0xfd, 0xc1, 0xb5,
+	0x3e, 0x03, 0x40, 0x0d, 0xd3, 0xb1, 0x97, 0x76, 0xd4, 0xd2, 0x6e, 0xd3, 0xd3, 0x50, 0x78, 0x84,
+	0xb2, 0xf8, 0x62, 0xc0, 0x80, 0xc0, 0x0a, 0x0b, 0x63, 0x04, 0xc4, 0xd3, 0x50, 0x42, 0x7d, 0x3b,
+	0xc0, 0xad, 0x0f, 0x23, 0xcb, 0xf4, 0xe2, 0xa8, 0x55, 0x97, 0xed, 0x8e, 0xc8, 0x9a, 0xc4, 0x51,
+	0xc2, 0x60, 0x41, 0xd0, 0x6a, 0xa4, 0x8c, 0x41, 0x10, 0x14, 0xef, 0xda, 0xf5, 0x2b, 0xee, 0x5a,
+	0xbc, 0xee, 0x4d, 0xc7, 0x0e, 0xa3, 0x56, 0x53, 0x9c, 0x0e, 0x02, 0xf8, 0x4c, 0xc3, 0xe7, 0xc9,
+	0x09, 0x8d, 0xe6, 0xaf, 0x4c, 0xcc, 0x7e, 0xad, 0x0d, 0xa1, 0xcb, 0x91, 0x97, 0x5e, 0x18, 0x71,
+	0xdd, 0x78, 0x69, 0x8a, 0xda, 0x41, 0x97, 0xba, 0xf1, 0x92, 0x57, 0xc1, 0xe4, 0x01, 0xa8, 0x34,
+	0x08, 0xe8, 0x05, 0x3a, 0xc9, 0xa6, 0x08, 0x75, 0x4e, 0x0f, 0xad, 0xe2, 0xfd, 0x4e, 0x56, 0x5e,
+	0xa6, 0xff, 0x28, 0x83, 0x9a, 0x14, 0xc9, 0x57, 0x96, 0x5c, 0xef, 0x27, 0xd5, 0x6b, 0xfe, 0x4d,
+	0x86, 0x1a, 0xe8, 0x32, 0x2c, 0xa9, 0x66, 0x1f, 0x82, 0x16, 0xd0, 0x37, 0xa6, 0x90, 0x94, 0xfd,
+	0x9d, 0x80, 0xbe, 0xe1, 0x32, 0x38, 0xec, 0xdc, 0x8f, 0x93, 0x07, 0x19, 0xff, 0xc6, 0xb5, 0xe2,
+	0x95, 0x32, 0x37, 0xe7, 0xbe, 0xf4, 0x46, 0xc5, 0xd0, 0x38, 0xd2, 0x43, 0xf6, 0x23, 0xa8, 0x07,
+	0x8c, 0x3a, 0xa6, 0x68, 0x1c, 0x70, 0x07, 0x54, 0x0c, 0x40, 0xe8, 0x90, 0x23, 0x58, 0x1b, 0x0b,
+	0x7d, 0x29, 0x51, 0x13, 0xb5, 0x31, 0xc7, 0xa4, 0x48, 0x3b, 0xf7, 0x84, 0x51, 0x79, 0x42, 0x4a,
+	0x69, 0xcc, 0x0a, 0x0b, 0xc4, 0xb5, 0x2b, 0xaa, 0x3c, 0xce, 0xc1, 0x9c, 0x13, 0x30, 0x1a, 0x7a,
+	0xae, 0xf4, 0x0a, 0x49, 0xad, 0xbc, 0x04, 0xea, 0x97, 0x5e, 0x02, 0xff, 0x2c, 0x41, 0x3d, 0xd7,
+	0xaf, 0xb9, 0x14, 0xcc, 0xc9, 0x1e, 0x97, 0xaf, 0x8b, 0x59, 0xd1, 0x32, 0xb9, 0x2a, 0x66, 0xd7,
+	0x6e, 0x8c, 0xd9, 0x62, 0xd8, 0x55, 0x7e, 0x4e, 0xd8, 0xfd, 0x1f, 0xa8, 0xcc, 0xb5, 0x7e, 0x6a,
+	0xac, 0xd7, 0x98, 0x6b, 0x21, 0xd5, 0xf9, 0x51, 0x81, 0x86, 0x5c, 0x2a, 0x6f, 0x18, 0x5d, 0x5a,
+	0xeb, 0x33, 0xa8, 0x31, 0x87, 0xfa, 0xa1, 0x2c, 0x23, 0x6e, 0x8e, 0x66, 0x29, 0x89, 0x39, 0x00,
+	0xdb, 0x4d, 0xdc, 0x18, 0xe5, 0x56, 0xad, 0xb9, 0x1f, 0xf3, 0x25, 0xdc, 0x87, 0xda, 0x92, 0xbe,
+	0x35, 0x83, 0x30, 0x71, 0xb3, 0xea, 0x92, 0xbe, 0x35, 0xc2, 0x10, 0x19, 0xf4, 0x9c, 0x71, 0x86,
+	0xf0, 0xb2, 0x2a, 0x3d, 0x67, 0xc8, 0xe8, 0xc0, 0x3a, 0x6a, 0x58, 0x76, 0x78, 0x66, 0x06, 0x8c,
+	0x5a, 0xd2, 0xc9, 0xea, 0x4b, 0xfa, 0xb6, 0x6f, 0x87, 0x67, 0x06, 0xa3, 0x16, 0x79, 0x1f, 0x9a,
+	0xa9, 0x0c, 0xde, 0x59, 0x4c, 0xfa, 0x59, 0x43, 0x0a, 0x7d, 0x8b, 0x18, 0x79, 0x0c, 0x1b, 0x73,
+	0xcf, 0x0d, 0xe3, 0x25, 0xb3, 0x4c, 0xe6, 0xb2, 0x60, 0x71, 0xc1, 0x93, 0x97, 0x62, 0x34, 0x13,
+	0x78, 0xc0, 0x51, 0xf2, 0xeb, 0xc4, 0xe9, 0xa3, 0xcc, 0xf7, 0x76, 0x2e, 0xf5, 0xdd, 0xf6, 0xbb,
+	0x28, 0x33, 0x0b, 0x98, 0x7c, 0xa1, 0x69, 0x34, 0xa1, 0xdb, 0x5f, 0x40, 0xb3, 0xc8, 0xfc, 0x59,
+	0x45, 0xe0, 0x7b, 0x50, 0x11, 0x8d, 0x8d, 0x5c, 0xc3, 0xa2, 0x54, 0x6c, 0x58, 0x1c, 0x41, 0x4d,
+	0x86, 0xc1, 0xcf, 0x7c, 0x7f, 0xb5, 0x41, 0xfd, 0x3e, 0xa6, 0x6e, 0x64, 0x47, 0x17, 0xf2, 0xf1,
+	0x92, 0xd2, 0x7b, 0x7f, 0x00, 0x7d, 0xb5, 0xf8, 0x27, 0x1b, 0x50, 0x3f, 0x1a, 0x8e, 0xbf, 0x3a,
+	0x1e, 0x75, 0x8d, 0xe1, 0xec, 0xb7, 0xfa, 0x1d, 0xb2, 0x0e, 0x5a, 0x77, 0x3a, 0x9d, 0x75, 0x87,
+	0xe3, 0x81, 0xa1, 0x97, 0x08, 0x40, 0x75, 0x30, 0x36, 0x26, 0x93, 0x99, 0x5e, 0x26, 0x4d, 0x80,
+	0xe9, 0xa4, 0x7f, 0xd8, 0x1d, 0x9b, 0x2f, 0xa7, 0x3d, 0x5d, 0x21, 0x3a, 0x34, 0x7a, 0x2f, 0xbb,
+	0xc6, 0x68, 0x38, 0xe8, 0x8d, 0x26, 0xc7, 0x7d, 0x7d, 0x6d, 0xaf, 0x0f, 0x35, 0x79, 0xa7, 0xe3,
+	0xc0, 0x87, 0xd3, 0xa1, 0xd9, 0x1f, 0xbc, 0xe8, 0x1e, 0x8f, 0x66, 0xfa, 0x1d, 0xd2, 0x00, 0x15,
+	0x81, 0xe9, 0xe1, 0xf0, 0x3b, 0xbd, 0x94, 0xa3, 0x0e, 0xf4, 0x72, 0x42, 0x8d, 0x27, 0xe3, 0x81,
+	0xae, 0xec, 0xed, 0x03, 0x64, 0xb7, 0x35, 0xd1, 0xa0, 0x72, 0x84, 0x11, 0xa2, 0xdf, 0x21, 0x5b,
+	0xd8, 0x93, 0xa1, 0xd6, 0xcc, 0x1b, 0xb8, 0x56, 0xd7, 0xb5, 0x7a, 0x8e, 0x17, 0x32, 0xbd, 0xb4,
+	0xf7, 0x31, 0x68, 0x69, 0x33, 0x08, 0x0d, 0x3e, 0x9a, 0xf5, 0x27, 0xc7, 0x38, 0xa5, 0xf8, 0x1e,
+	0x18, 0xb8, 0x10, 0x15, 0xd6, 0xbe, 0x9c, 0xcc, 0x5e, 0xea, 0xe5, 0x3d, 0x1b, 0xb4, 0x34, 0x6a,
+	0x71, 0xb9, 0xbd, 0xc9, 0xe1, 0x74, 0x34, 0x98, 0x0d, 0xfa, 0x62, 0xf5, 0xbd, 0xee, 0xb8, 0x37,
+	0x18, 0x8d, 0x06, 0x7d, 0xb1, 0xfa, 0x17, 0xdd, 0x21, 0x7e, 0x97, 0x49, 0x1d, 0x6a, 0xb3, 0xe1,
+	0xe1, 0x00, 0x47, 0x56, 0x90, 0x98, 0x0e, 0xc6, 0xfd, 0xe1, 0xf8, 0x2b, 0x7d, 0x0d, 0x09, 0xe3,
+	0x78, 0x3c, 0x46, 0xa2, 0x82, 0xc4, 0xf1, 0xf8, 0x37, 0xe3, 0xc9, 0xb7, 0x63, 0x1d, 0xf6, 0xbe,
+	0x01, 0x2d, 0x4d, 0xd1, 0xb8, 0x5d, 0xe3, 0x49, 0x7f, 0x60, 0x26, 0xec, 0x3b, 0x68, 0xd3, 0xb0,
+	0x3f, 0x1a, 0xe8, 0x25, 0x5c, 0xe4, 0xe1, 0xf0, 0x3b, 0x3e, 0x0f, 0x1e, 0xc0, 0x68, 0x34, 0xe9,
+	0x75, 0xd1, 0x22, 0x05, 0x39, 0x7d, 0xa3, 0x3b, 0x1c, 0xeb, 0x6b, 0x28, 0xde, 0x47, 0xc5, 0xca,
+	0xc1, 0x9f, 0x6b, 0xb0, 0x91, 0x3c, 0xd6, 0x0f, 0xa9, 0x4b, 0x17, 0x2c, 0x20, 0xcf, 0x41, 0x4b,
+	0xcb, 0x27, 0xb2, 0x55, 0x7c, 0xcd, 0xc8, 0xd2, 0xa8, 0xbd, 0xbd, 0x0a, 0xcb, 0xe2, 0xea, 0x18,
+	0xc8, 0xe5, 0xd2, 0x8b, 0xbc, 0x7b, 0xf3, 0x93, 0xa8, 0xfd, 0xe8, 0x5a, 0xbe, 0x1c, 0xf6, 0x39,
+	0x68, 0x69, 0xe7, 0x5f, 0x9a, 0xb4, 0xfa, 0xd3, 0xa0, 0xbd, 0xbd, 0x0a, 0x4b, 0xdd, 0x4f, 0xb3,
+	0x3a, 0xec, 0x6e, 0xa1, 0x39, 0x2a, 0xf5, 0xee, 0x15, 0x41, 0xa9, 0xf5, 0x0b, 0x50, 0x93, 0x76,
+	0x3e, 0xb9, 0x97, 0x8f, 0xe8, 0xa4, 0xbf, 0xd4, 0xde, 0x5a, 0x41, 0x0b, 0x8a, 0x22, 0x7d, 0xa6,
+	0x8a, 0xf9, 0x9e, 0x7e, 0x7b, 0x6b, 0x05, 0xcd, 0x14, 0x93, 0xde, 0xae, 0x54, 0x5c, 0xe9, 0x30,
+	0xb7, 0xb7, 0x56, 0x50, 0xa9, 0xb8, 0x0f, 0x6a, 0xd2, 0xb6, 0x95, 0x8a, 0x2b, 0x5d, 0xdc, 0x36,
+	0xc8, 0x87, 0x71, 0xec, 0x9e, 0x3d, 0x2d, 0x91, 0xa7, 0xa0, 0x26, 0x95, 0xae, 0x94, 0x5f, 0x29,
+	0x7c, 0xf3, 0xf2, 0xbb, 0xa5, 0xa7, 0x25, 0x4c, 0xfb, 0xb2, 0x8f, 0x9a, 0x6d, 0x61, 0xae, 0xfd,
+	0xdb, 0xde, 0xcc, 0x83, 0xc9, 0x34, 0xcf, 0x41, 0x4b, 0xfb, 0x9e, 0xf2, 0xcc, 0x56, 0x5b, 0xa7,
+	0xed, 0xed, 0x55, 0x58, 0x2e, 0xe9, 0x57, 0x00, 0x59, 0xdf, 0x8a, 0x6c, 0x17, 0x3b, 0x52, 0xa9,
+	0xf6, 0xfd, 0x4b, 0x78, 0xba, 0x23, 0x15, 0x51, 0x44, 0x6d, 0xa6, 0xc5, 0x4d, 0xaa, 0x44, 0xf2,
+	0x90, 0x94, 0x1f, 0xe5, 0xda, 0x64, 0x32, 0x9c, 0x1f, 0x5e, 0xdd, 0x05, 0x13, 0x63, 0xdc, 0xd8,
+	0x22, 0x23, 0x3d, 0x68, 0xe4, 0xfb, 0x5f, 0x44, 0xb4, 0x25, 0xae, 0x68, 0x95, 0xb5, 0x1f, 0x5c,
+	0xc1, 0x11, 0x83, 0x9c, 0x54, 0xf9, 0xd5, 0xf8, 0xec, 0x3f, 0x03, 0x00, 0x4c, 0x87, 0x0d, 0x85,
+	0x19, 0x1c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1541,6 +3012,12 @@ type WorkloadManagerClient interface {
 	JobInfo(ctx context.Context, in *JobInfoRequest, opts ...grpc.CallOption) (*JobInfoResponse, error)
 	// JobSteps returns information about each individual job step.
 	JobSteps(ctx context.Context, in *JobStepsRequest, opts ...grpc.CallOption) (*JobStepsResponse, error)
+	// JobUsage returns resources usage of a job and each of its steps
+	// from accounting along with job efficiency.
+	JobUsage(ctx context.Context, in *JobUsageRequest, opts ...grpc.CallOption) (*JobUsageResponse, error)
+	// ListJobs returns brief information about active and finished jobs
+	// matching the filter. Jobs are ordered by id, results are paginated.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 	// OpenFile opens a file and streams its content back. May be
 	// useful for results collecting.
 	OpenFile(ctx context.Context, in *OpenFileRequest, opts ...grpc.CallOption) (WorkloadManager_OpenFileClient, error)
@@ -1548,12 +3025,22 @@ type WorkloadManagerClient interface {
 	// OpenFile this call will watch file content changes and stream
 	// new chunks continuously.
 	TailFile(ctx context.Context, opts ...grpc.CallOption) (WorkloadManager_TailFileClient, error)
+	// JobLogs streams standard output and error of a job. Output
+	// paths are resolved from job info, so clients need job id only.
+	// When follow is set output is streamed until job is finished.
+	JobLogs(ctx context.Context, in *JobLogsRequest, opts ...grpc.CallOption) (WorkloadManager_JobLogsClient, error)
 	// Resources returns partition resources
 	// nodes, cpu, mem, wall-time and available features
 	Resources(ctx context.Context, in *ResourcesRequest, opts ...grpc.CallOption) (*ResourcesResponse, error)
 	// Partitions returns a list of available partitions.
 	Partitions(ctx context.Context, in *PartitionsRequest, opts ...grpc.CallOption) (*PartitionsResponse, error)
-	// WorkloadInfo provides info about workload (name, version, red-box uid)
+	// Nodes returns state and resources of compute nodes.
+	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
+	// PartitionStatus returns partition state along with
+	// numbers of pending and running jobs. Status of several
+	// partitions can be requested at once.
+	PartitionStatus(ctx context.Context, in *PartitionStatusRequest, opts ...grpc.CallOption) (*PartitionStatusResponse, error)
+	// WorkloadInfo provides info about workload (name, version, red-box uid, licenses)
 	WorkloadInfo(ctx context.Context, in *WorkloadInfoRequest, opts ...grpc.CallOption) (*WorkloadInfoResponse, error)
 }
 
@@ -1610,6 +3097,24 @@ func (c *workloadManagerClient) JobSteps(ctx context.Context, in *JobStepsReques
 	return out, nil
 }
 
+func (c *workloadManagerClient) JobUsage(ctx context.Context, in *JobUsageRequest, opts ...grpc.CallOption) (*JobUsageResponse, error) {
+	out := new(JobUsageResponse)
+	err := c.cc.Invoke(ctx, "/api.WorkloadManager/JobUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadManagerClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/api.WorkloadManager/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workloadManagerClient) OpenFile(ctx context.Context, in *OpenFileRequest, opts ...grpc.CallOption) (WorkloadManager_OpenFileClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_WorkloadManager_serviceDesc.Streams[0], "/api.WorkloadManager/OpenFile", opts...)
 	if err != nil {
@@ -1673,6 +3178,38 @@ func (x *workloadManagerTailFileClient) Recv() (*Chunk, error) {
 	return m, nil
 }
 
+func (c *workloadManagerClient) JobLogs(ctx context.Context, in *JobLogsRequest, opts ...grpc.CallOption) (WorkloadManager_JobLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WorkloadManager_serviceDesc.Streams[2], "/api.WorkloadManager/JobLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workloadManagerJobLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkloadManager_JobLogsClient interface {
+	Recv() (*JobLogsChunk, error)
+	grpc.ClientStream
+}
+
+type workloadManagerJobLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *workloadManagerJobLogsClient) Recv() (*JobLogsChunk, error) {
+	m := new(JobLogsChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *workloadManagerClient) Resources(ctx context.Context, in *ResourcesRequest, opts ...grpc.CallOption) (*ResourcesResponse, error) {
 	out := new(ResourcesResponse)
 	err := c.cc.Invoke(ctx, "/api.WorkloadManager/Resources", in, out, opts...)
@@ -1691,6 +3228,24 @@ func (c *workloadManagerClient) Partitions(ctx context.Context, in *PartitionsRe
 	return out, nil
 }
 
+func (c *workloadManagerClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
+	out := new(NodesResponse)
+	err := c.cc.Invoke(ctx, "/api.WorkloadManager/Nodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workloadManagerClient) PartitionStatus(ctx context.Context, in *PartitionStatusRequest, opts ...grpc.CallOption) (*PartitionStatusResponse, error) {
+	out := new(PartitionStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.WorkloadManager/PartitionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workloadManagerClient) WorkloadInfo(ctx context.Context, in *WorkloadInfoRequest, opts ...grpc.CallOption) (*WorkloadInfoResponse, error) {
 	out := new(WorkloadInfoResponse)
 	err := c.cc.Invoke(ctx, "/api.WorkloadManager/WorkloadInfo", in, out, opts...)
@@ -1716,6 +3271,12 @@ type WorkloadManagerServer interface {
 	JobInfo(context.Context, *JobInfoRequest) (*JobInfoResponse, error)
 	// JobSteps returns information about each individual job step.
 	JobSteps(context.Context, *JobStepsRequest) (*JobStepsResponse, error)
+	// JobUsage returns resources usage of a job and each of its steps
+	// from accounting along with job efficiency.
+	JobUsage(context.Context, *JobUsageRequest) (*JobUsageResponse, error)
+	// ListJobs returns brief information about active and finished jobs
+	// matching the filter. Jobs are ordered by id, results are paginated.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
 	// OpenFile opens a file and streams its content back. May be
 	// useful for results collecting.
 	OpenFile(*OpenFileRequest, WorkloadManager_OpenFileServer) error
@@ -1723,12 +3284,22 @@ type WorkloadManagerServer interface {
 	// OpenFile this call will watch file content changes and stream
 	// new chunks continuously.
 	TailFile(WorkloadManager_TailFileServer) error
+	// JobLogs streams standard output and error of a job. Output
+	// paths are resolved from job info, so clients need job id only.
+	// When follow is set output is streamed until job is finished.
+	JobLogs(*JobLogsRequest, WorkloadManager_JobLogsServer) error
 	// Resources returns partition resources
 	// nodes, cpu, mem, wall-time and available features
 	Resources(context.Context, *ResourcesRequest) (*ResourcesResponse, error)
 	// Partitions returns a list of available partitions.
 	Partitions(context.Context, *PartitionsRequest) (*PartitionsResponse, error)
-	// WorkloadInfo provides info about workload (name, version, red-box uid)
+	// Nodes returns state and resources of compute nodes.
+	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	// PartitionStatus returns partition state along with
+	// numbers of pending and running jobs. Status of several
+	// partitions can be requested at once.
+	PartitionStatus(context.Context, *PartitionStatusRequest) (*PartitionStatusResponse, error)
+	// WorkloadInfo provides info about workload (name, version, red-box uid, licenses)
 	WorkloadInfo(context.Context, *WorkloadInfoRequest) (*WorkloadInfoResponse, error)
 }
 
@@ -1751,18 +3322,33 @@ func (*UnimplementedWorkloadManagerServer) JobInfo(ctx context.Context, req *Job
 func (*UnimplementedWorkloadManagerServer) JobSteps(ctx context.Context, req *JobStepsRequest) (*JobStepsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JobSteps not implemented")
 }
+func (*UnimplementedWorkloadManagerServer) JobUsage(ctx context.Context, req *JobUsageRequest) (*JobUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobUsage not implemented")
+}
+func (*UnimplementedWorkloadManagerServer) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
 func (*UnimplementedWorkloadManagerServer) OpenFile(req *OpenFileRequest, srv WorkloadManager_OpenFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method OpenFile not implemented")
 }
 func (*UnimplementedWorkloadManagerServer) TailFile(srv WorkloadManager_TailFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method TailFile not implemented")
 }
+func (*UnimplementedWorkloadManagerServer) JobLogs(req *JobLogsRequest, srv WorkloadManager_JobLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method JobLogs not implemented")
+}
 func (*UnimplementedWorkloadManagerServer) Resources(ctx context.Context, req *ResourcesRequest) (*ResourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Resources not implemented")
 }
 func (*UnimplementedWorkloadManagerServer) Partitions(ctx context.Context, req *PartitionsRequest) (*PartitionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Partitions not implemented")
 }
+func (*UnimplementedWorkloadManagerServer) Nodes(ctx context.Context, req *NodesRequest) (*NodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nodes not implemented")
+}
+func (*UnimplementedWorkloadManagerServer) PartitionStatus(ctx context.Context, req *PartitionStatusRequest) (*PartitionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartitionStatus not implemented")
+}
 func (*UnimplementedWorkloadManagerServer) WorkloadInfo(ctx context.Context, req *WorkloadInfoRequest) (*WorkloadInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WorkloadInfo not implemented")
 }
@@ -1861,6 +3447,42 @@ func _WorkloadManager_JobSteps_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkloadManager_JobUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadManagerServer).JobUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WorkloadManager/JobUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadManagerServer).JobUsage(ctx, req.(*JobUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkloadManager_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadManagerServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WorkloadManager/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadManagerServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkloadManager_OpenFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(OpenFileRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1908,6 +3530,27 @@ func (x *workloadManagerTailFileServer) Recv() (*TailFileRequest, error) {
 	return m, nil
 }
 
+func _WorkloadManager_JobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkloadManagerServer).JobLogs(m, &workloadManagerJobLogsServer{stream})
+}
+
+type WorkloadManager_JobLogsServer interface {
+	Send(*JobLogsChunk) error
+	grpc.ServerStream
+}
+
+type workloadManagerJobLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *workloadManagerJobLogsServer) Send(m *JobLogsChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WorkloadManager_Resources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResourcesRequest)
 	if err := dec(in); err != nil {
@@ -1944,6 +3587,42 @@ func _WorkloadManager_Partitions_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkloadManager_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadManagerServer).Nodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WorkloadManager/Nodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadManagerServer).Nodes(ctx, req.(*NodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkloadManager_PartitionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartitionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkloadManagerServer).PartitionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WorkloadManager/PartitionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkloadManagerServer).PartitionStatus(ctx, req.(*PartitionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkloadManager_WorkloadInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WorkloadInfoRequest)
 	if err := dec(in); err != nil {
@@ -1986,6 +3665,14 @@ var _WorkloadManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "JobSteps",
 			Handler:    _WorkloadManager_JobSteps_Handler,
 		},
+		{
+			MethodName: "JobUsage",
+			Handler:    _WorkloadManager_JobUsage_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _WorkloadManager_ListJobs_Handler,
+		},
 		{
 			MethodName: "Resources",
 			Handler:    _WorkloadManager_Resources_Handler,
@@ -1994,6 +3681,14 @@ var _WorkloadManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Partitions",
 			Handler:    _WorkloadManager_Partitions_Handler,
 		},
+		{
+			MethodName: "Nodes",
+			Handler:    _WorkloadManager_Nodes_Handler,
+		},
+		{
+			MethodName: "PartitionStatus",
+			Handler:    _WorkloadManager_PartitionStatus_Handler,
+		},
 		{
 			MethodName: "WorkloadInfo",
 			Handler:    _WorkloadManager_WorkloadInfo_Handler,
@@ -2011,6 +3706,11 @@ var _WorkloadManager_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "JobLogs",
+			Handler:       _WorkloadManager_JobLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/workload/api/workload.proto",
 }
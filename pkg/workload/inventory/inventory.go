@@ -0,0 +1,111 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inventory summarizes workload manager compute nodes into
+// partition-wide capacity that can be published on a virtual node.
+package inventory
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// annotationPrefix is a prefix of virtual node annotations holding node counts.
+	annotationPrefix = "wlm.sylabs.io/nodes-"
+	// capacityPrefix and allocatablePrefix are prefixes of virtual node annotations
+	// holding partition capacity and allocatable resources.
+	capacityPrefix    = "wlm.sylabs.io/capacity-"
+	allocatablePrefix = "wlm.sylabs.io/allocatable-"
+)
+
+// Summary is a partition-wide summary of compute nodes. Memory is in MBs.
+type Summary struct {
+	// Nodes is the number of nodes in each state.
+	Nodes map[api.NodeState]int64
+
+	CPUs        int64
+	AllocCPUs   int64
+	Memory      int64
+	AllocMemory int64
+
+	// AvailableCPUs and AvailableMemory are not allocated resources of nodes
+	// that can run jobs, i.e. are not drained or down.
+	AvailableCPUs   int64
+	AvailableMemory int64
+}
+
+// Summarize aggregates compute nodes into a single summary.
+func Summarize(nodes []*api.NodeInfo) Summary {
+	s := Summary{Nodes: make(map[api.NodeState]int64)}
+	for _, n := range nodes {
+		s.Nodes[n.State]++
+		s.CPUs += n.Cpus
+		s.AllocCPUs += n.AllocCpus
+		s.Memory += n.RealMemory
+		s.AllocMemory += n.AllocMemory
+
+		switch n.State {
+		case api.NodeState_IDLE, api.NodeState_MIXED, api.NodeState_ALLOCATED:
+			s.AvailableCPUs += n.Cpus - n.AllocCpus
+			s.AvailableMemory += n.RealMemory - n.AllocMemory
+		}
+	}
+	return s
+}
+
+// Capacity returns total cpu and memory of partition nodes. Node status is owned by
+// virtual kubelet, so it is up to its provider to report them as node capacity.
+func (s Summary) Capacity() v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    *resource.NewQuantity(s.CPUs, resource.DecimalSI),
+		v1.ResourceMemory: *resource.NewQuantity(s.Memory<<20, resource.BinarySI),
+	}
+}
+
+// Allocatable returns cpu and memory that can be allocated for new jobs.
+func (s Summary) Allocatable() v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    *resource.NewQuantity(s.AvailableCPUs, resource.DecimalSI),
+		v1.ResourceMemory: *resource.NewQuantity(s.AvailableMemory<<20, resource.BinarySI),
+	}
+}
+
+// Annotations returns node counts per state, capacity and allocatable resources
+// as virtual node annotations, e.g. wlm.sylabs.io/nodes-idle or wlm.sylabs.io/capacity-cpu.
+func (s Summary) Annotations() map[string]string {
+	var total int64
+	annotations := make(map[string]string, len(api.NodeState_name)+5)
+	for v, name := range api.NodeState_name {
+		state := api.NodeState(v)
+		if state == api.NodeState_NODE_UNKNOWN {
+			name = "unknown"
+		}
+		total += s.Nodes[state]
+		annotations[annotationPrefix+strings.ToLower(name)] = strconv.FormatInt(s.Nodes[state], 10)
+	}
+	annotations[annotationPrefix+"total"] = strconv.FormatInt(total, 10)
+
+	for name, q := range s.Capacity() {
+		annotations[capacityPrefix+string(name)] = q.String()
+	}
+	for name, q := range s.Allocatable() {
+		annotations[allocatablePrefix+string(name)] = q.String()
+	}
+	return annotations
+}
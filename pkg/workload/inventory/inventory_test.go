@@ -0,0 +1,70 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sylabs/wlm-operator/pkg/workload/api"
+)
+
+var testNodes = []*api.NodeInfo{
+	{Name: "node-1", State: api.NodeState_IDLE, Cpus: 4, RealMemory: 2048},
+	{Name: "node-2", State: api.NodeState_MIXED, Cpus: 4, AllocCpus: 1, RealMemory: 2048, AllocMemory: 512},
+	{Name: "node-3", State: api.NodeState_ALLOCATED, Cpus: 2, AllocCpus: 2, RealMemory: 1024, AllocMemory: 1024},
+	{Name: "node-4", State: api.NodeState_DRAIN, Cpus: 4, RealMemory: 2048},
+	{Name: "node-5", State: api.NodeState_DOWN, Cpus: 4, RealMemory: 2048},
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(testNodes)
+	require.Equal(t, Summary{
+		Nodes: map[api.NodeState]int64{
+			api.NodeState_IDLE:      1,
+			api.NodeState_MIXED:     1,
+			api.NodeState_ALLOCATED: 1,
+			api.NodeState_DRAIN:     1,
+			api.NodeState_DOWN:      1,
+		},
+		CPUs:            18,
+		AllocCPUs:       3,
+		Memory:          9216,
+		AllocMemory:     1536,
+		AvailableCPUs:   7,
+		AvailableMemory: 3584,
+	}, s)
+
+	capacity, allocatable := s.Capacity(), s.Allocatable()
+	require.Equal(t, int64(18), capacity.Cpu().Value())
+	require.Equal(t, int64(9<<30), capacity.Memory().Value())
+	require.Equal(t, int64(7), allocatable.Cpu().Value())
+	require.Equal(t, int64(3584<<20), allocatable.Memory().Value())
+
+	require.Equal(t, map[string]string{
+		"wlm.sylabs.io/nodes-unknown":   "0",
+		"wlm.sylabs.io/nodes-idle":      "1",
+		"wlm.sylabs.io/nodes-mixed":     "1",
+		"wlm.sylabs.io/nodes-allocated": "1",
+		"wlm.sylabs.io/nodes-drain":     "1",
+		"wlm.sylabs.io/nodes-down":      "1",
+		"wlm.sylabs.io/nodes-total":     "5",
+
+		"wlm.sylabs.io/capacity-cpu":       "18",
+		"wlm.sylabs.io/capacity-memory":    "9Gi",
+		"wlm.sylabs.io/allocatable-cpu":    "7",
+		"wlm.sylabs.io/allocatable-memory": "3584Mi",
+	}, s.Annotations())
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redbox helps to connect to red-box. Virtual kubelet pods created by configurator
+// get red-box address in environment: RED_BOX_SOCK with unix socket path, or RED_BOX_ADDR
+// with TCP address along with RED_BOX_TLS_DIR holding TLS credentials, see DialEnv.
+package redbox
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// SockEnv holds red-box unix socket path.
+	SockEnv = "RED_BOX_SOCK"
+	// AddrEnv holds red-box TCP address, it is used when SockEnv is not set.
+	AddrEnv = "RED_BOX_ADDR"
+	// TLSDirEnv holds path to a directory with TLS credentials used to connect to AddrEnv.
+	TLSDirEnv = "RED_BOX_TLS_DIR"
+
+	// CACertFile, CertFile and KeyFile are names of TLS credentials files,
+	// as well as of the keys of a kubernetes TLS secret that holds them.
+	CACertFile = "ca.crt"
+	CertFile   = "tls.crt"
+	KeyFile    = "tls.key"
+)
+
+// DialEnv connects to red-box at address taken from environment. Unix socket is
+// connected with no TLS, as red-box serves it, while TCP address requires TLS credentials.
+func DialEnv() (*grpc.ClientConn, error) {
+	if sock := os.Getenv(SockEnv); sock != "" {
+		return grpc.Dial("unix://"+sock, grpc.WithInsecure())
+	}
+
+	addr := os.Getenv(AddrEnv)
+	if addr == "" {
+		return nil, errors.Errorf("either %s or %s must be set", SockEnv, AddrEnv)
+	}
+	dir := os.Getenv(TLSDirEnv)
+	if dir == "" {
+		return nil, errors.Errorf("%s must be set to connect to %s", TLSDirEnv, addr)
+	}
+
+	var files [3][]byte
+	for i, name := range []string{CACertFile, CertFile, KeyFile} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read TLS credentials")
+		}
+		files[i] = data
+	}
+	tlsConfig, err := ClientTLSConfig(files[0], files[1], files[2])
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+// ClientTLSConfig returns TLS config to connect to red-box with. Server certificate
+// is verified with CA certificate, client certificate is required by red-box.
+func ClientTLSConfig(caCert, cert, key []byte) (*tls.Config, error) {
+	pool, err := certPool(caCert)
+	if err != nil {
+		return nil, err
+	}
+	if len(cert) == 0 || len(key) == 0 {
+		return nil, errors.Errorf("both %s and %s must be set", CertFile, KeyFile)
+	}
+	crt, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse client certificate")
+	}
+	return &tls.Config{
+		RootCAs:      pool,
+		Certificates: []tls.Certificate{crt},
+	}, nil
+}
+
+// ServerTLSConfig returns TLS config red-box serves TCP connections with. Since red-box
+// submits jobs on behalf of its user, clients must present a certificate signed by client CA.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	crt, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load server certificate")
+	}
+	ca, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read client CA certificate")
+	}
+	pool, err := certPool(ca)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{crt},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+func certPool(caCert []byte) (*x509.CertPool, error) {
+	if len(caCert) == 0 {
+		return nil, errors.Errorf("%s must be set", CACertFile)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, errors.Errorf("could not parse %s", CACertFile)
+	}
+	return pool, nil
+}
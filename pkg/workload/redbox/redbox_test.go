@@ -0,0 +1,164 @@
+// Copyright (c) 2019 Sylabs, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redbox
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCert is a PEM encoded certificate along with its key.
+type testCert struct {
+	cert, key []byte
+	parsed    *x509.Certificate
+	signer    *ecdsa.PrivateKey
+}
+
+// newTestCert returns certificate signed by parent, or self-signed CA certificate when parent is nil.
+func newTestCert(t *testing.T, cn string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, parentCert := key, tmpl
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, parentCert = parent.signer, parent.parsed
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, signer)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCert{
+		cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		parsed: parsed,
+		signer: key,
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string][]byte) {
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), data, 0600))
+	}
+}
+
+func TestDialEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redbox")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "red-box", ca)
+	client := newTestCert(t, "vk", ca)
+
+	serverDir, clientDir := filepath.Join(dir, "server"), filepath.Join(dir, "client")
+	require.NoError(t, os.Mkdir(serverDir, 0700))
+	require.NoError(t, os.Mkdir(clientDir, 0700))
+	writeFiles(t, serverDir, map[string][]byte{CACertFile: ca.cert, CertFile: server.cert, KeyFile: server.key})
+	writeFiles(t, clientDir, map[string][]byte{CACertFile: ca.cert, CertFile: client.cert, KeyFile: client.key})
+
+	tlsConfig, err := ServerTLSConfig(
+		filepath.Join(serverDir, CertFile),
+		filepath.Join(serverDir, KeyFile),
+		filepath.Join(serverDir, CACertFile),
+	)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(ln)
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, env := range []string{SockEnv, AddrEnv, TLSDirEnv} {
+		defer os.Setenv(env, os.Getenv(env))
+		os.Unsetenv(env)
+	}
+	_, err = DialEnv()
+	require.Error(t, err, "address is required")
+
+	os.Setenv(AddrEnv, ln.Addr().String())
+	_, err = DialEnv()
+	require.Error(t, err, "TLS credentials are required for TCP address")
+
+	os.Setenv(TLSDirEnv, clientDir)
+	conn, err := DialEnv()
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// clients without certificate are rejected
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.parsed)
+	anonymous, err := grpc.Dial(ln.Addr().String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool})))
+	require.NoError(t, err)
+	defer anonymous.Close()
+	_, err = healthpb.NewHealthClient(anonymous).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.Error(t, err)
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	client := newTestCert(t, "vk", ca)
+
+	_, err := ClientTLSConfig(ca.cert, client.cert, client.key)
+	require.NoError(t, err)
+
+	_, err = ClientTLSConfig(nil, client.cert, client.key)
+	require.Error(t, err, "CA is required")
+	_, err = ClientTLSConfig(ca.cert, nil, nil)
+	require.Error(t, err, "client certificate is required")
+	_, err = ClientTLSConfig(ca.cert, client.cert, ca.key)
+	require.Error(t, err, "key must match certificate")
+}